@@ -52,7 +52,12 @@ func (app *Application) adminAddGroupMemberships(clientID string, current *model
 						if existingMemberships.GetMembershipBy(func(membership model.GroupMembership) bool {
 							return membership.NetID == account.GetNetID()
 						}) == nil {
-							memberships = append(memberships, account.ToMembership(groupID, status))
+							membership := account.ToMembership(groupID, status)
+							err = membership.RecordCreation(model.MembershipTransitionSourceAdmin, current, "")
+							if err != nil {
+								return err
+							}
+							memberships = append(memberships, membership)
 						}
 					}
 				}
@@ -47,9 +47,14 @@ type Application struct {
 	corebb        Core
 	rewards       Rewards
 	calendar      Calendar
+	webhooks      Webhooks
 
 	authmanSyncInProgress bool
 
+	feed *feedHub // the real-time feed subscriptions of this instance
+
+	contentFilters *contentFilterCache // the compiled content filters
+
 	//synchronize managed groups timer
 	scheduler *cron.Cron
 	logger    *logs.Logger
@@ -62,6 +67,8 @@ func (app *Application) Start() {
 	app.storage.RegisterStorageListener(&storageListener)
 
 	app.setupCronTimer()
+
+	go app.watchRealtimeFeed()
 }
 
 func (app *Application) setupCronTimer() {
@@ -93,6 +100,14 @@ func (app *Application) setupCronTimer() {
 
 	app.startScheduledPostTask()
 
+	app.startPostSeriesTask()
+
+	app.startExpiredPostsTask()
+	app.startNotificationOutboxTask()
+	app.startWebhookDeliveriesTask()
+
+	app.startDigestsTask()
+
 	app.startCoreCleanupTask()
 
 	app.scheduler.Start()
@@ -110,6 +125,76 @@ func (app *Application) startCoreCleanupTask() {
 	log.Printf("successful running of core account cleanup scheduling task")
 }
 
+func (app *Application) startPostSeriesTask() {
+	_, err := app.scheduler.AddFunc("* * * * *", func() {
+		log.Println("run scheduled post series tick")
+		err := app.processPostSeries()
+		if err != nil {
+			log.Printf("error processing post series: %s", err)
+		}
+	})
+	if err != nil {
+		log.Printf("error on running post series task: %s", err)
+	}
+	log.Printf("successful running of post series scheduling task")
+}
+
+func (app *Application) startExpiredPostsTask() {
+	_, err := app.scheduler.AddFunc("* * * * *", func() {
+		log.Println("run scheduled expired posts tick")
+		err := app.processExpiredPosts()
+		if err != nil {
+			log.Printf("error processing expired posts: %s", err)
+		}
+	})
+	if err != nil {
+		log.Printf("error on running expired posts task: %s", err)
+	}
+	log.Printf("successful running of expired posts scheduling task")
+}
+
+func (app *Application) startNotificationOutboxTask() {
+	_, err := app.scheduler.AddFunc("* * * * *", func() {
+		log.Println("run scheduled notification outbox tick")
+		err := app.processNotificationOutbox()
+		if err != nil {
+			log.Printf("error processing notification outbox: %s", err)
+		}
+	})
+	if err != nil {
+		log.Printf("error on running notification outbox task: %s", err)
+	}
+	log.Printf("successful running of notification outbox scheduling task")
+}
+
+func (app *Application) startWebhookDeliveriesTask() {
+	_, err := app.scheduler.AddFunc("* * * * *", func() {
+		log.Println("run scheduled webhook deliveries tick")
+		err := app.processWebhookDeliveries()
+		if err != nil {
+			log.Printf("error processing webhook deliveries: %s", err)
+		}
+	})
+	if err != nil {
+		log.Printf("error on running webhook deliveries task: %s", err)
+	}
+	log.Printf("successful running of webhook deliveries scheduling task")
+}
+
+func (app *Application) startDigestsTask() {
+	_, err := app.scheduler.AddFunc("0 * * * *", func() {
+		log.Println("run scheduled digests tick")
+		err := app.processDigests()
+		if err != nil {
+			log.Printf("error processing digests: %s", err)
+		}
+	})
+	if err != nil {
+		log.Printf("error on running digests task: %s", err)
+	}
+	log.Printf("successful running of digests scheduling task")
+}
+
 func (app *Application) startScheduledPostTask() {
 	// TBD: Implement CRUD APIs for config and load them from DB
 	_, err := app.scheduler.AddFunc("* * * * *", func() {
@@ -127,7 +212,7 @@ func (app *Application) startScheduledPostTask() {
 
 // NewApplication creates new Application
 func NewApplication(version string, build string, storage Storage, notifications Notifications, authman Authman, core *corebb.Adapter,
-	rewards *rewards.Adapter, calendar *calendar.Adapter, serviceID string, logger *logs.Logger, config *model.ApplicationConfig) *Application {
+	rewards *rewards.Adapter, calendar *calendar.Adapter, webhooks Webhooks, serviceID string, logger *logs.Logger, config *model.ApplicationConfig) *Application {
 
 	scheduler := cron.New(cron.WithLocation(time.UTC))
 	application := Application{version: version,
@@ -138,9 +223,13 @@ func NewApplication(version string, build string, storage Storage, notifications
 		corebb:        core,
 		rewards:       rewards,
 		calendar:      calendar,
+		webhooks:      webhooks,
 		config:        config,
 		scheduler:     scheduler,
 		logger:        logger,
+		feed:          newFeedHub(),
+
+		contentFilters: newContentFilterCache(),
 	}
 
 	//add the drivers ports/interfaces
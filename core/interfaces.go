@@ -53,8 +53,8 @@ type Services interface {
 	UpdateMemberships(clientID string, user *model.User, group *model.Group, operation model.MembershipMultiUpdate) error
 
 	GetEvents(clientID string, current *model.User, groupID string, filterByToMembers bool) ([]model.Event, error)
-	CreateEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, creator *model.Creator) (*model.Event, error)
-	UpdateEvent(clientID string, current *model.User, eventID string, groupID string, toMemberList []model.ToMember) error
+	CreateEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, toLabelIDs []string, creator *model.Creator) (*model.Event, error)
+	UpdateEvent(clientID string, current *model.User, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string) error
 	DeleteEvent(clientID string, current *model.User, eventID string, groupID string) error
 	GetEventUserIDs(eventID string) ([]string, error)
 	GetGroupMembershipsStatusAndGroupTitle(userID string) ([]model.GetGroupMembershipsResponse, error)
@@ -68,8 +68,22 @@ type Services interface {
 	CreatePost(clientID string, current *model.User, post *model.Post, group *model.Group) (*model.Post, error)
 	UpdatePost(clientID string, current *model.User, group *model.Group, post *model.Post) (*model.Post, error)
 	ReactToPost(clientID string, current *model.User, groupID string, postID string, reaction string) error
+	GetPostReactions(clientID string, current *model.User, groupID string, postID string, reaction *string, offset *int64, limit *int64) ([]model.PostReaction, error)
 	ReportPostAsAbuse(clientID string, current *model.User, group *model.Group, post *model.Post, comment string, sendToDean bool, sendToGroupAdmins bool) error
 	DeletePost(clientID string, current *model.User, groupID string, postID string, force bool) error
+	CrossPost(clientID string, current *model.User, post *model.Post, groupIDs []string) ([]model.Post, error)
+	GetPinnedPosts(clientID string, current *model.User, groupID string) ([]model.Post, error)
+	PinPost(clientID string, current *model.User, group *model.Group, postID string, pinned bool, isAnnouncement bool, dateAnnouncementExpires *time.Time) (*model.Post, error)
+	MarkGroupAsRead(clientID string, current *model.User, groupID string, dateLastRead *time.Time) error
+	MarkPostSeen(clientID string, current *model.User, groupID string, postID string) error
+	GetPostSeenRecords(clientID string, current *model.User, group *model.Group, postID string, offset *int64, limit *int64) ([]model.PostSeen, error)
+	GetPendingPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error)
+	ReviewPost(clientID string, current *model.User, group *model.Group, postID string, approve bool, rejectReason string) (*model.Post, error)
+	CreatePostSeries(clientID string, current *model.User, group *model.Group, series model.PostSeries) (*model.PostSeries, error)
+	GetPostSeries(clientID string, current *model.User, group *model.Group, statuses []string) ([]model.PostSeries, error)
+	UpdatePostSeriesStatus(clientID string, current *model.User, group *model.Group, id string, status string) (*model.PostSeries, error)
+	GetExpiredPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error)
+	GetPostReplies(clientID string, current *model.User, groupID string, postID string, offset *int64, limit *int64, depth int, order *string) ([]model.Post, error)
 
 	SynchronizeAuthman(clientID string) error
 	SynchronizeAuthmanGroup(clientID string, groupID string) error
@@ -82,10 +96,71 @@ type Services interface {
 	GetSyncConfig(clientID string) (*model.SyncConfig, error)
 	UpdateSyncConfig(config model.SyncConfig) error
 
+	GetReactionsConfig(clientID string) (*model.ReactionsConfig, error)
+	UpdateReactionsConfig(config model.ReactionsConfig) error
+
+	GetOutboxNotifications(clientID string, status *string, offset *int64, limit *int64) ([]model.OutboxNotification, error)
+	ReplayOutboxNotification(clientID string, id string) error
+
+	GetNotificationTemplates(clientID string, groupID *string, operation *string) ([]model.NotificationTemplate, error)
+	GetDefaultNotificationTemplates() map[string]model.NotificationTemplate
+	CreateNotificationTemplate(clientID string, template model.NotificationTemplate) (*model.NotificationTemplate, error)
+	UpdateNotificationTemplate(clientID string, template model.NotificationTemplate) error
+	DeleteNotificationTemplate(clientID string, id string) error
+
+	GetWebhookSubscriptions(clientID string) ([]model.WebhookSubscription, error)
+	CreateWebhookSubscription(clientID string, current *model.User, subscription model.WebhookSubscription) (*model.WebhookSubscription, error)
+	UpdateWebhookSubscription(clientID string, subscription model.WebhookSubscription) error
+	DeleteWebhookSubscription(clientID string, id string) error
+	GetWebhookDeliveries(clientID string, subscriptionID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error)
+	ReplayWebhookDelivery(clientID string, id string) error
+
+	GetChanges(clientID string, since *string, limit *int64, wait *int64) (*model.ChangeBatch, error)
+	SubscribeToFeed(clientID string, current *model.User, groupID *string) (<-chan model.FeedEvent, func(), error)
+
+	GetMembershipStatusHistory(clientID string, membershipID string) ([]model.MembershipTransition, error)
+
+	GetAttendanceSessions(clientID string, current *model.User, group *model.Group) ([]model.AttendanceSession, error)
+	CreateAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) (*model.AttendanceSession, error)
+	UpdateAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) error
+	DeleteAttendanceSession(clientID string, current *model.User, group *model.Group, id string) error
+	GetAttendanceRecords(clientID string, current *model.User, group *model.Group, sessionID string) ([]model.AttendanceRecord, error)
+	CheckInAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error
+	CheckOutAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error
+	GetAttendanceReport(clientID string, current *model.User, group *model.Group) (*model.AttendanceReport, error)
+	OpenAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string, dateOpens *time.Time, dateCloses time.Time, codePeriod int, autoAddNonMembers bool) (*model.AttendanceSession, error)
+	CloseAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string) error
+	GetAttendanceCheckInCode(clientID string, current *model.User, group *model.Group, sessionID string) (*model.AttendanceCheckInCode, error)
+	SelfCheckInAttendance(clientID string, current *model.User, group *model.Group, sessionID *string, code string) (*model.AttendanceRecord, error)
+
+	UpdateGroupProfileFields(clientID string, current *model.User, group *model.Group, fields []model.MemberProfileField) ([]model.MemberProfileField, error)
+	UpdateMemberProfileValues(clientID string, current *model.User, group *model.Group, membershipID string, values map[string]interface{}) error
+	UpdateOwnProfileValues(clientID string, current *model.User, group *model.Group, values map[string]interface{}) error
+
+	SearchGroupMembers(clientID string, current *model.User, group *model.Group, search model.MemberSearch) (*model.MemberSearchResult, error)
+
+	GetMemberNotes(clientID string, current *model.User, group *model.Group, membershipID string) ([]model.MemberNote, error)
+	CreateMemberNote(clientID string, current *model.User, group *model.Group, membershipID string, text string) (*model.MemberNote, error)
+	UpdateMemberNote(clientID string, current *model.User, group *model.Group, noteID string, text string) (*model.MemberNote, error)
+	DeleteMemberNote(clientID string, current *model.User, group *model.Group, noteID string) error
+	GetMemberRoster(clientID string, current *model.User, group *model.Group) ([]model.MemberRosterEntry, error)
+
+	UpdateGroupMemberLabels(clientID string, current *model.User, group *model.Group, labels []model.MemberLabel) ([]model.MemberLabel, error)
+	UpdateMembershipLabels(clientID string, current *model.User, group *model.Group, membershipID string, labelIDs []string) error
+
+	GetContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error)
+	CreateContentFilter(clientID string, current *model.User, filter model.ContentFilter) (*model.ContentFilter, error)
+	UpdateContentFilter(clientID string, filter model.ContentFilter) error
+	DeleteContentFilter(clientID string, id string) error
+
+	GetAbuseReports(clientID string, filter model.AbuseReportsFilter) ([]model.AbuseReport, error)
+	GetAbuseReport(clientID string, id string) (*model.AbuseReport, error)
+	UpdateAbuseReport(clientID string, current *model.User, id string, status *string, assigneeID *string, resolutionNotes *string) (*model.AbuseReport, error)
+
 	// V3
 	CheckUserGroupMembershipPermission(clientID string, current *model.User, groupID string) (*model.Group, bool)
 	FindGroupsV3(clientID string, filter model.GroupsFilter) ([]model.Group, error)
-	FindGroupMemberships(clientID string, filter model.MembershipFilter) (model.MembershipCollection, error)
+	FindGroupMemberships(clientID string, viewer model.MemberViewer, filter model.MembershipFilter) (model.MembershipCollection, error)
 	FindGroupMembership(clientID string, groupID string, userID string) (*model.GroupMembership, error)
 	FindGroupMembershipByID(clientID string, id string) (*model.GroupMembership, error)
 	FindUserGroupMemberships(clientID string, userID string) (model.MembershipCollection, error)
@@ -110,8 +185,8 @@ type Services interface {
 
 	// Calendar BB
 	CreateCalendarEventForGroups(clientID string, adminIdentifier []model.AccountIdentifiers, current *model.User, event map[string]interface{}, groupIDs []string) (map[string]interface{}, []string, error)
-	CreateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error)
-	UpdateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error)
+	CreateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error)
+	UpdateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error)
 	GetGroupCalendarEvents(clientID string, current *model.User, groupID string, published *bool, filter model.GroupEventFilter) (map[string]interface{}, error)
 }
 
@@ -196,12 +271,12 @@ func (s *servicesImpl) GetEvents(clientID string, current *model.User, groupID s
 	return s.app.getEvents(clientID, current, groupID, filterByToMembers)
 }
 
-func (s *servicesImpl) CreateEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, creator *model.Creator) (*model.Event, error) {
-	return s.app.createEvent(clientID, current, eventID, group, toMemberList, creator)
+func (s *servicesImpl) CreateEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, toLabelIDs []string, creator *model.Creator) (*model.Event, error) {
+	return s.app.createEvent(clientID, current, eventID, group, toMemberList, toLabelIDs, creator)
 }
 
-func (s *servicesImpl) UpdateEvent(clientID string, current *model.User, eventID string, groupID string, toMemberList []model.ToMember) error {
-	return s.app.updateEvent(clientID, current, eventID, groupID, toMemberList)
+func (s *servicesImpl) UpdateEvent(clientID string, current *model.User, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string) error {
+	return s.app.updateEvent(clientID, current, eventID, groupID, toMemberList, toLabelIDs)
 }
 
 func (s *servicesImpl) DeleteEvent(clientID string, current *model.User, eventID string, groupID string) error {
@@ -250,6 +325,10 @@ func (s *servicesImpl) ReactToPost(clientID string, current *model.User, groupID
 	return s.app.reactToPost(clientID, current, groupID, postID, reaction)
 }
 
+func (s *servicesImpl) GetPostReactions(clientID string, current *model.User, groupID string, postID string, reaction *string, offset *int64, limit *int64) ([]model.PostReaction, error) {
+	return s.app.getPostReactions(clientID, current, groupID, postID, reaction, offset, limit)
+}
+
 func (s *servicesImpl) ReportPostAsAbuse(clientID string, current *model.User, group *model.Group, post *model.Post, comment string, sendToDean bool, sendToGroupAdmins bool) error {
 	return s.app.reportPostAsAbuse(clientID, current, group, post, comment, sendToDean, sendToGroupAdmins)
 }
@@ -258,6 +337,58 @@ func (s *servicesImpl) DeletePost(clientID string, current *model.User, groupID
 	return s.app.deletePost(clientID, current.ID, groupID, postID, force)
 }
 
+func (s *servicesImpl) CrossPost(clientID string, current *model.User, post *model.Post, groupIDs []string) ([]model.Post, error) {
+	return s.app.crossPost(clientID, current, post, groupIDs)
+}
+
+func (s *servicesImpl) GetPinnedPosts(clientID string, current *model.User, groupID string) ([]model.Post, error) {
+	return s.app.getPinnedPosts(clientID, current, groupID)
+}
+
+func (s *servicesImpl) PinPost(clientID string, current *model.User, group *model.Group, postID string, pinned bool, isAnnouncement bool, dateAnnouncementExpires *time.Time) (*model.Post, error) {
+	return s.app.pinPost(clientID, current, group, postID, pinned, isAnnouncement, dateAnnouncementExpires)
+}
+
+func (s *servicesImpl) MarkGroupAsRead(clientID string, current *model.User, groupID string, dateLastRead *time.Time) error {
+	return s.app.markGroupAsRead(clientID, current, groupID, dateLastRead)
+}
+
+func (s *servicesImpl) MarkPostSeen(clientID string, current *model.User, groupID string, postID string) error {
+	return s.app.markPostSeen(clientID, current, groupID, postID)
+}
+
+func (s *servicesImpl) GetPostSeenRecords(clientID string, current *model.User, group *model.Group, postID string, offset *int64, limit *int64) ([]model.PostSeen, error) {
+	return s.app.getPostSeenRecords(clientID, current, group, postID, offset, limit)
+}
+
+func (s *servicesImpl) GetPendingPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error) {
+	return s.app.getPendingPosts(clientID, current, group, offset, limit)
+}
+
+func (s *servicesImpl) ReviewPost(clientID string, current *model.User, group *model.Group, postID string, approve bool, rejectReason string) (*model.Post, error) {
+	return s.app.reviewPost(clientID, current, group, postID, approve, rejectReason)
+}
+
+func (s *servicesImpl) CreatePostSeries(clientID string, current *model.User, group *model.Group, series model.PostSeries) (*model.PostSeries, error) {
+	return s.app.createPostSeries(clientID, current, group, series)
+}
+
+func (s *servicesImpl) GetPostSeries(clientID string, current *model.User, group *model.Group, statuses []string) ([]model.PostSeries, error) {
+	return s.app.getPostSeriesList(clientID, current, group, statuses)
+}
+
+func (s *servicesImpl) UpdatePostSeriesStatus(clientID string, current *model.User, group *model.Group, id string, status string) (*model.PostSeries, error) {
+	return s.app.updatePostSeriesStatus(clientID, current, group, id, status)
+}
+
+func (s *servicesImpl) GetExpiredPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error) {
+	return s.app.getExpiredPosts(clientID, current, group, offset, limit)
+}
+
+func (s *servicesImpl) GetPostReplies(clientID string, current *model.User, groupID string, postID string, offset *int64, limit *int64, depth int, order *string) ([]model.Post, error) {
+	return s.app.getPostReplies(clientID, current, groupID, postID, offset, limit, depth, order)
+}
+
 func (s *servicesImpl) SynchronizeAuthman(clientID string) error {
 	return s.app.synchronizeAuthman(clientID, false)
 }
@@ -282,6 +413,34 @@ func (s *servicesImpl) DeleteManagedGroupConfig(id string, clientID string) erro
 	return s.app.deleteManagedGroupConfig(id, clientID)
 }
 
+func (s *servicesImpl) GetContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error) {
+	return s.app.getContentFilters(clientID, groupID)
+}
+
+func (s *servicesImpl) CreateContentFilter(clientID string, current *model.User, filter model.ContentFilter) (*model.ContentFilter, error) {
+	return s.app.createContentFilter(clientID, current, filter)
+}
+
+func (s *servicesImpl) UpdateContentFilter(clientID string, filter model.ContentFilter) error {
+	return s.app.updateContentFilter(clientID, filter)
+}
+
+func (s *servicesImpl) DeleteContentFilter(clientID string, id string) error {
+	return s.app.deleteContentFilter(clientID, id)
+}
+
+func (s *servicesImpl) GetAbuseReports(clientID string, filter model.AbuseReportsFilter) ([]model.AbuseReport, error) {
+	return s.app.getAbuseReports(clientID, filter)
+}
+
+func (s *servicesImpl) GetAbuseReport(clientID string, id string) (*model.AbuseReport, error) {
+	return s.app.getAbuseReport(clientID, id)
+}
+
+func (s *servicesImpl) UpdateAbuseReport(clientID string, current *model.User, id string, status *string, assigneeID *string, resolutionNotes *string) (*model.AbuseReport, error) {
+	return s.app.updateAbuseReport(clientID, current, id, status, assigneeID, resolutionNotes)
+}
+
 func (s *servicesImpl) GetSyncConfig(clientID string) (*model.SyncConfig, error) {
 	return s.app.getSyncConfig(clientID)
 }
@@ -290,6 +449,170 @@ func (s *servicesImpl) UpdateSyncConfig(config model.SyncConfig) error {
 	return s.app.updateSyncConfig(config)
 }
 
+func (s *servicesImpl) GetReactionsConfig(clientID string) (*model.ReactionsConfig, error) {
+	return s.app.getReactionsConfig(clientID)
+}
+
+func (s *servicesImpl) UpdateReactionsConfig(config model.ReactionsConfig) error {
+	return s.app.updateReactionsConfig(config)
+}
+
+func (s *servicesImpl) GetOutboxNotifications(clientID string, status *string, offset *int64, limit *int64) ([]model.OutboxNotification, error) {
+	return s.app.getOutboxNotifications(clientID, status, offset, limit)
+}
+
+func (s *servicesImpl) ReplayOutboxNotification(clientID string, id string) error {
+	return s.app.replayOutboxNotification(clientID, id)
+}
+
+func (s *servicesImpl) GetNotificationTemplates(clientID string, groupID *string, operation *string) ([]model.NotificationTemplate, error) {
+	return s.app.getNotificationTemplates(clientID, groupID, operation)
+}
+
+func (s *servicesImpl) GetDefaultNotificationTemplates() map[string]model.NotificationTemplate {
+	return s.app.getDefaultNotificationTemplates()
+}
+
+func (s *servicesImpl) CreateNotificationTemplate(clientID string, template model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	return s.app.createNotificationTemplate(clientID, template)
+}
+
+func (s *servicesImpl) UpdateNotificationTemplate(clientID string, template model.NotificationTemplate) error {
+	return s.app.updateNotificationTemplate(clientID, template)
+}
+
+func (s *servicesImpl) DeleteNotificationTemplate(clientID string, id string) error {
+	return s.app.deleteNotificationTemplate(clientID, id)
+}
+
+func (s *servicesImpl) GetWebhookSubscriptions(clientID string) ([]model.WebhookSubscription, error) {
+	return s.app.getWebhookSubscriptions(clientID)
+}
+
+func (s *servicesImpl) CreateWebhookSubscription(clientID string, current *model.User, subscription model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	return s.app.createWebhookSubscription(clientID, current, subscription)
+}
+
+func (s *servicesImpl) UpdateWebhookSubscription(clientID string, subscription model.WebhookSubscription) error {
+	return s.app.updateWebhookSubscription(clientID, subscription)
+}
+
+func (s *servicesImpl) DeleteWebhookSubscription(clientID string, id string) error {
+	return s.app.deleteWebhookSubscription(clientID, id)
+}
+
+func (s *servicesImpl) GetWebhookDeliveries(clientID string, subscriptionID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error) {
+	return s.app.getWebhookDeliveries(clientID, subscriptionID, status, offset, limit)
+}
+
+func (s *servicesImpl) ReplayWebhookDelivery(clientID string, id string) error {
+	return s.app.replayWebhookDelivery(clientID, id)
+}
+
+func (s *servicesImpl) GetChanges(clientID string, since *string, limit *int64, wait *int64) (*model.ChangeBatch, error) {
+	return s.app.getChanges(clientID, since, limit, wait)
+}
+
+func (s *servicesImpl) SubscribeToFeed(clientID string, current *model.User, groupID *string) (<-chan model.FeedEvent, func(), error) {
+	return s.app.subscribeToFeed(clientID, current, groupID)
+}
+
+func (s *servicesImpl) GetMembershipStatusHistory(clientID string, membershipID string) ([]model.MembershipTransition, error) {
+	return s.app.getMembershipStatusHistory(clientID, membershipID)
+}
+
+func (s *servicesImpl) GetAttendanceSessions(clientID string, current *model.User, group *model.Group) ([]model.AttendanceSession, error) {
+	return s.app.getAttendanceSessions(clientID, current, group)
+}
+
+func (s *servicesImpl) CreateAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) (*model.AttendanceSession, error) {
+	return s.app.createAttendanceSession(clientID, current, group, session)
+}
+
+func (s *servicesImpl) UpdateAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) error {
+	return s.app.updateAttendanceSession(clientID, current, group, session)
+}
+
+func (s *servicesImpl) DeleteAttendanceSession(clientID string, current *model.User, group *model.Group, id string) error {
+	return s.app.deleteAttendanceSession(clientID, current, group, id)
+}
+
+func (s *servicesImpl) GetAttendanceRecords(clientID string, current *model.User, group *model.Group, sessionID string) ([]model.AttendanceRecord, error) {
+	return s.app.getAttendanceRecords(clientID, current, group, sessionID)
+}
+
+func (s *servicesImpl) CheckInAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error {
+	return s.app.checkInAttendance(clientID, current, group, sessionID, userIDs)
+}
+
+func (s *servicesImpl) CheckOutAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error {
+	return s.app.checkOutAttendance(clientID, current, group, sessionID, userIDs)
+}
+
+func (s *servicesImpl) GetAttendanceReport(clientID string, current *model.User, group *model.Group) (*model.AttendanceReport, error) {
+	return s.app.getAttendanceReport(clientID, current, group)
+}
+
+func (s *servicesImpl) OpenAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string, dateOpens *time.Time, dateCloses time.Time, codePeriod int, autoAddNonMembers bool) (*model.AttendanceSession, error) {
+	return s.app.openAttendanceCheckInWindow(clientID, current, group, sessionID, dateOpens, dateCloses, codePeriod, autoAddNonMembers)
+}
+
+func (s *servicesImpl) CloseAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string) error {
+	return s.app.closeAttendanceCheckInWindow(clientID, current, group, sessionID)
+}
+
+func (s *servicesImpl) GetAttendanceCheckInCode(clientID string, current *model.User, group *model.Group, sessionID string) (*model.AttendanceCheckInCode, error) {
+	return s.app.getAttendanceCheckInCode(clientID, current, group, sessionID)
+}
+
+func (s *servicesImpl) SelfCheckInAttendance(clientID string, current *model.User, group *model.Group, sessionID *string, code string) (*model.AttendanceRecord, error) {
+	return s.app.selfCheckInAttendance(clientID, current, group, sessionID, code)
+}
+
+func (s *servicesImpl) UpdateGroupProfileFields(clientID string, current *model.User, group *model.Group, fields []model.MemberProfileField) ([]model.MemberProfileField, error) {
+	return s.app.updateGroupProfileFields(clientID, current, group, fields)
+}
+
+func (s *servicesImpl) UpdateMemberProfileValues(clientID string, current *model.User, group *model.Group, membershipID string, values map[string]interface{}) error {
+	return s.app.updateMemberProfileValues(clientID, current, group, membershipID, values)
+}
+
+func (s *servicesImpl) UpdateOwnProfileValues(clientID string, current *model.User, group *model.Group, values map[string]interface{}) error {
+	return s.app.updateOwnProfileValues(clientID, current, group, values)
+}
+
+func (s *servicesImpl) SearchGroupMembers(clientID string, current *model.User, group *model.Group, search model.MemberSearch) (*model.MemberSearchResult, error) {
+	return s.app.searchGroupMembers(clientID, current, group, search)
+}
+
+func (s *servicesImpl) GetMemberNotes(clientID string, current *model.User, group *model.Group, membershipID string) ([]model.MemberNote, error) {
+	return s.app.getMemberNotes(clientID, current, group, membershipID)
+}
+
+func (s *servicesImpl) CreateMemberNote(clientID string, current *model.User, group *model.Group, membershipID string, text string) (*model.MemberNote, error) {
+	return s.app.createMemberNote(clientID, current, group, membershipID, text)
+}
+
+func (s *servicesImpl) UpdateMemberNote(clientID string, current *model.User, group *model.Group, noteID string, text string) (*model.MemberNote, error) {
+	return s.app.updateMemberNote(clientID, current, group, noteID, text)
+}
+
+func (s *servicesImpl) DeleteMemberNote(clientID string, current *model.User, group *model.Group, noteID string) error {
+	return s.app.deleteMemberNote(clientID, current, group, noteID)
+}
+
+func (s *servicesImpl) GetMemberRoster(clientID string, current *model.User, group *model.Group) ([]model.MemberRosterEntry, error) {
+	return s.app.getMemberRoster(clientID, current, group)
+}
+
+func (s *servicesImpl) UpdateGroupMemberLabels(clientID string, current *model.User, group *model.Group, labels []model.MemberLabel) ([]model.MemberLabel, error) {
+	return s.app.updateGroupMemberLabels(clientID, current, group, labels)
+}
+
+func (s *servicesImpl) UpdateMembershipLabels(clientID string, current *model.User, group *model.Group, membershipID string, labelIDs []string) error {
+	return s.app.updateMembershipLabels(clientID, current, group, membershipID, labelIDs)
+}
+
 // V3
 
 func (s *servicesImpl) CheckUserGroupMembershipPermission(clientID string, current *model.User, groupID string) (*model.Group, bool) {
@@ -300,8 +623,8 @@ func (s *servicesImpl) FindGroupsV3(clientID string, filter model.GroupsFilter)
 	return s.app.findGroupsV3(clientID, filter)
 }
 
-func (s *servicesImpl) FindGroupMemberships(clientID string, filter model.MembershipFilter) (model.MembershipCollection, error) {
-	return s.app.findGroupMemberships(nil, clientID, filter)
+func (s *servicesImpl) FindGroupMemberships(clientID string, viewer model.MemberViewer, filter model.MembershipFilter) (model.MembershipCollection, error) {
+	return s.app.findGroupMemberships(nil, clientID, viewer, filter)
 }
 
 func (s *servicesImpl) FindGroupMembership(clientID string, groupID string, userID string) (*model.GroupMembership, error) {
@@ -372,12 +695,12 @@ func (s *servicesImpl) CreateCalendarEventForGroups(clientID string, adminIdenti
 	return s.app.createCalendarEventForGroups(clientID, adminIdentifier, current, event, groupIDs)
 }
 
-func (s *servicesImpl) CreateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error) {
-	return s.app.createCalendarEventSingleGroup(clientID, current, event, groupID, members)
+func (s *servicesImpl) CreateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error) {
+	return s.app.createCalendarEventSingleGroup(clientID, current, event, groupID, members, labelIDs)
 }
 
-func (s *servicesImpl) UpdateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error) {
-	return s.app.updateCalendarEventSingleGroup(clientID, current, event, groupID, members)
+func (s *servicesImpl) UpdateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error) {
+	return s.app.updateCalendarEventSingleGroup(clientID, current, event, groupID, members, labelIDs)
 }
 
 func (s *servicesImpl) GetGroupCalendarEvents(clientID string, current *model.User, groupID string, published *bool, filter model.GroupEventFilter) (map[string]interface{}, error) {
@@ -435,8 +758,8 @@ type Storage interface {
 	DeleteUsersByAccountsIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
 
 	FindEvents(clientID string, current *model.User, groupID string, filterByToMembers bool) ([]model.Event, error)
-	CreateEvent(context storage.TransactionContext, clientID string, eventID string, groupID string, toMemberList []model.ToMember, creator *model.Creator) (*model.Event, error)
-	UpdateEvent(clientID string, eventID string, groupID string, toMemberList []model.ToMember) error
+	CreateEvent(context storage.TransactionContext, clientID string, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string, creator *model.Creator) (*model.Event, error)
+	UpdateEvent(clientID string, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string) error
 	DeleteEvent(clientID string, eventID string, groupID string) error
 	PullMembersFromEventsByUserIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
 
@@ -447,15 +770,92 @@ type Storage interface {
 	FindGroupsEvents(context storage.TransactionContext, eventIDs []string) ([]model.GetGroupsEvents, error)
 
 	ReportGroupAsAbuse(clientID string, userID string, group *model.Group) error
-	ReportPostAsAbuse(clientID string, userID string, group *model.Group, post *model.Post) error
+	ReportPostAsAbuse(context storage.TransactionContext, clientID string, userID string, group *model.Group, post *model.Post) error
+	AddAbuseReport(context storage.TransactionContext, report model.AbuseReport, entry model.AbuseReportEntry) (*model.AbuseReport, error)
+	FindAbuseReports(clientID string, filter model.AbuseReportsFilter) ([]model.AbuseReport, error)
+	FindAbuseReport(context storage.TransactionContext, clientID string, id string) (*model.AbuseReport, error)
+	UpdateAbuseReport(context storage.TransactionContext, report model.AbuseReport) error
 
 	FindPosts(clientID string, current *model.User, filter model.PostsFilter, filterPrivatePostsValue *bool, filterByToMembers bool) ([]model.Post, error)
 	FindPost(context storage.TransactionContext, clientID string, userID *string, groupID string, postID string, skipMembershipCheck bool, filterByToMembers bool) (*model.Post, error)
 	FindPostsByParentID(context storage.TransactionContext, clientID string, userID *string, groupID string, parentID string, skipMembershipCheck bool, filterByToMembers bool, recursive bool, order *string) ([]model.Post, error)
 
-	CreatePost(clientID string, current *model.User, post *model.Post) (*model.Post, error)
-	UpdatePost(clientID string, userID string, post *model.Post) (*model.Post, error)
+	CreatePost(context storage.TransactionContext, clientID string, current *model.User, post *model.Post) (*model.Post, error)
+	UpdatePost(context storage.TransactionContext, clientID string, userID string, post *model.Post) (*model.Post, error)
 	ReactToPost(context storage.TransactionContext, userID string, postID string, reaction string, on bool) error
+	FindPostReactions(clientID string, groupID string, postID string, reaction *string, offset *int64, limit *int64) ([]model.PostReaction, error)
+
+	FindDigestMemberships(context storage.TransactionContext, now time.Time) ([]model.GroupMembership, error)
+	UpdateMembershipsDateDigested(context storage.TransactionContext, ids []string, dateDigested time.Time) error
+	FindDigestPosts(clientID string, groupID string, since time.Time, until time.Time) ([]model.Post, error)
+	FindDigestEvents(clientID string, groupID string, since time.Time, until time.Time) ([]model.Event, error)
+	CountPendingMemberships(clientID string, groupID string) (int64, error)
+	CountPendingPosts(clientID string, groupID string) (int64, error)
+
+	InsertOutboxNotification(context storage.TransactionContext, notification model.OutboxNotification) error
+	ClaimOutboxNotification(now time.Time, lease time.Duration) (*model.OutboxNotification, error)
+	MarkOutboxNotificationSent(id string, attempts int) error
+	MarkOutboxNotificationFailed(id string, attempts int, lastError string, status string, dateNextAttempt time.Time) error
+	FindOutboxNotifications(clientID string, status *string, offset *int64, limit *int64) ([]model.OutboxNotification, error)
+	ReplayOutboxNotification(clientID string, id string) (bool, error)
+
+	FindNotificationTemplates(clientID string, groupID *string, operation *string) ([]model.NotificationTemplate, error)
+	FindApplicableNotificationTemplates(context storage.TransactionContext, clientID string, groupID *string, operation string) ([]model.NotificationTemplate, error)
+	FindNotificationTemplate(clientID string, id string) (*model.NotificationTemplate, error)
+	InsertNotificationTemplate(template model.NotificationTemplate) error
+	UpdateNotificationTemplate(template model.NotificationTemplate) error
+	DeleteNotificationTemplate(clientID string, id string) error
+
+	FindWebhookSubscriptions(context storage.TransactionContext, clientID string) ([]model.WebhookSubscription, error)
+	FindWebhookSubscription(clientID string, id string) (*model.WebhookSubscription, error)
+	InsertWebhookSubscription(subscription model.WebhookSubscription) error
+	UpdateWebhookSubscription(subscription model.WebhookSubscription) error
+	DeleteWebhookSubscription(clientID string, id string) error
+	InsertWebhookDeliveries(context storage.TransactionContext, deliveries []model.WebhookDelivery) error
+	ClaimWebhookDelivery(now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	MarkWebhookDeliveryDelivered(id string, attempts int, statusCode int) error
+	MarkWebhookDeliveryFailed(id string, attempts int, statusCode int, lastError string, status string, dateNextAttempt time.Time) error
+	FindWebhookDeliveries(clientID string, subscriptionID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error)
+	ReplayWebhookDelivery(clientID string, id string) (bool, error)
+
+	FindChanges(clientID string, since *string, limit int, maxWait time.Duration) (*model.ChangeBatch, error)
+	WatchChanges(since *string, handler func(change model.ChangeEvent)) error
+
+	FindAttendanceSessions(clientID string, groupID string) ([]model.AttendanceSession, error)
+	FindAttendanceSession(clientID string, groupID string, id string) (*model.AttendanceSession, error)
+	InsertAttendanceSession(session model.AttendanceSession) error
+	UpdateAttendanceSession(session model.AttendanceSession) error
+	DeleteAttendanceSession(clientID string, groupID string, id string) error
+	FindAttendanceRecords(clientID string, groupID string, sessionID *string) ([]model.AttendanceRecord, error)
+	CheckInAttendance(clientID string, groupID string, records []model.AttendanceRecord) error
+	CheckOutAttendance(clientID string, groupID string, sessionID string, membershipIDs []string, checkedOutBy string, dateCheckedOut time.Time) error
+	SetAttendanceCheckInWindow(clientID string, groupID string, sessionID string, window *model.AttendanceCheckInWindow) error
+	FindOpenAttendanceSessions(clientID string, groupID string, now time.Time) ([]model.AttendanceSession, error)
+	InsertAttendanceCheckInFailure(clientID string, groupID string, userID string, date time.Time) error
+	CountAttendanceCheckInFailures(clientID string, groupID string, userID string, since time.Time) (int64, error)
+
+	UpdateGroupProfileFields(clientID string, groupID string, fields []model.MemberProfileField, removedKeys []string) error
+	UpdateMembershipProfileValues(clientID string, membershipID string, values map[string]interface{}) error
+
+	SearchGroupMemberships(clientID string, groupID string, search model.MemberSearch) ([]model.GroupMembership, int64, error)
+	FindMemberSearchCandidates(clientID string, groupID string, search model.MemberSearch, offset int64, limit int64) ([]model.GroupMembership, error)
+	FindMemberSearchUserIDs(clientID string, groupID string, search model.MemberSearch) ([]string, error)
+
+	FindMemberNotes(clientID string, membership model.GroupMembership) ([]model.MemberNote, error)
+	FindGroupMemberNotes(clientID string, groupID string) ([]model.MemberNote, error)
+	FindMemberNote(clientID string, groupID string, id string) (*model.MemberNote, error)
+	InsertMemberNote(note model.MemberNote) error
+	UpdateMemberNote(note model.MemberNote) error
+	DeleteMemberNote(clientID string, groupID string, id string) error
+
+	UpdateGroupMemberLabels(clientID string, groupID string, labels []model.MemberLabel) error
+	UpdateMembershipLabels(clientID string, membershipID string, labelIDs []string) error
+
+	FindCrossPostCopies(context storage.TransactionContext, clientID string, originID string) ([]model.Post, error)
+	UpdateCrossPostCopy(context storage.TransactionContext, clientID string, postCopy model.Post) error
+
+	FindReactionsConfig(context storage.TransactionContext, clientID string) (*model.ReactionsConfig, error)
+	SaveReactionsConfig(context storage.TransactionContext, config model.ReactionsConfig) error
 	DeletePost(ctx storage.TransactionContext, clientID string, userID string, groupID string, postID string, force bool) error
 	DeletePostsByAccountsIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
 	PullMembersFromPostsByUserIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
@@ -463,6 +863,37 @@ type Storage interface {
 	FindScheduledPosts(context storage.TransactionContext) ([]model.Post, error)
 	UpdateDateNotifiedForPostIDs(context storage.TransactionContext, ids []string, dateNotified time.Time) error
 
+	FindPinnedPosts(context storage.TransactionContext, clientID string, userID *string, groupID string, filterByToMembers bool) ([]model.Post, error)
+	CountPinnedPosts(context storage.TransactionContext, clientID string, groupID string) (int64, error)
+	UpdatePostPinned(context storage.TransactionContext, clientID string, groupID string, postID string, pinned bool, pinnedBy *string,
+		isAnnouncement bool, dateAnnouncementExpires *time.Time) error
+
+	UpdateMembershipDateLastRead(clientID string, groupID string, userID string, dateLastRead time.Time) error
+	MarkPostSeen(clientID string, groupID string, postID string, userID string) error
+	FindPostSeenRecords(clientID string, groupID string, postID string, offset *int64, limit *int64) ([]model.PostSeen, error)
+	CountUnreadPosts(clientID string, userID string, memberships []model.GroupMembership) (map[string]int64, error)
+
+	FindPendingPosts(clientID string, groupID string, offset *int64, limit *int64) ([]model.Post, error)
+	UpdatePostStatus(context storage.TransactionContext, clientID string, groupID string, postID string, status string, reviewedBy *string, rejectReason string) error
+
+	InsertPostSeries(series model.PostSeries) error
+	FindPostSeries(context storage.TransactionContext, clientID string, groupID string, id string) (*model.PostSeries, error)
+	FindPostSeriesList(clientID string, groupID string, statuses []string) ([]model.PostSeries, error)
+	FindDuePostSeries(context storage.TransactionContext, now time.Time) ([]model.PostSeries, error)
+	ClaimPostSeriesOccurrence(context storage.TransactionContext, series model.PostSeries, occurrence time.Time, next *time.Time) (bool, error)
+	UpdatePostSeriesStatus(context storage.TransactionContext, clientID string, groupID string, id string, status string, next *time.Time) error
+
+	FindPostsToExpire(context storage.TransactionContext, now time.Time) ([]model.Post, error)
+	HideExpiredPost(context storage.TransactionContext, clientID string, groupID string, postID string, dateExpired time.Time) error
+	FindExpiredPosts(clientID string, groupID string, offset *int64, limit *int64) ([]model.Post, error)
+	FindPostsToPurge(context storage.TransactionContext, expiredBefore time.Time) ([]model.Post, error)
+	PurgeExpiredPost(context storage.TransactionContext, clientID string, groupID string, postID string) error
+
+	FindPostWithoutReplies(context storage.TransactionContext, clientID string, userID *string, groupID string, postID string, filterByToMembers bool) (*model.Post, error)
+	FindReplies(context storage.TransactionContext, clientID string, userID string, isAdmin bool, groupID string, parentID string, offset *int64, limit *int64, depth int, order *string) ([]model.Post, error)
+
+	DeletePostsSeenByAccountsIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
+
 	FindAuthmanGroups(clientID string) ([]model.Group, error)
 	FindAuthmanGroupByKey(clientID string, authmanGroupKey string) (*model.Group, error)
 
@@ -473,6 +904,13 @@ type Storage interface {
 	UpdateManagedGroupConfig(config model.ManagedGroupConfig) error
 	DeleteManagedGroupConfig(id string, clientID string) error
 
+	LoadContentFilters() ([]model.ContentFilter, error)
+	FindContentFilter(id string, clientID string) (*model.ContentFilter, error)
+	FindContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error)
+	InsertContentFilter(filter model.ContentFilter) error
+	UpdateContentFilter(filter model.ContentFilter) error
+	DeleteContentFilter(id string, clientID string) error
+
 	// V3
 	FindGroupsV3(context storage.TransactionContext, clientID string, filter model.GroupsFilter) ([]model.Group, error)
 	FindGroupMemberships(clientID string, filter model.MembershipFilter) (model.MembershipCollection, error)
@@ -483,19 +921,20 @@ type Storage interface {
 	FindGroupMembershipByID(clientID string, id string) (*model.GroupMembership, error)
 	FindUserGroupMemberships(clientID string, userID string) (model.MembershipCollection, error)
 	FindUserGroupMembershipsWithContext(ctx storage.TransactionContext, clientID string, userID string) (model.MembershipCollection, error)
-	BulkUpdateGroupMembershipsByExternalID(clientID string, groupID string, saveOperations []storage.SingleMembershipOperation, updateGroupStats bool) error
+	BulkUpdateGroupMembershipsByExternalID(context storage.TransactionContext, clientID string, groupID string, saveOperations []storage.SingleMembershipOperation, updateGroupStats bool) ([]model.GroupMembership, error)
 	SaveGroupMembershipByExternalID(clientID string, groupID string, externalID string, userID *string, status *string,
 		email *string, name *string, memberAnswers []model.MemberAnswer, syncID *string, updateGroupStats bool) (*model.GroupMembership, error)
 
-	CreateMembership(clientID string, current *model.User, group *model.Group, member *model.GroupMembership) error
+	CreateMembership(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, member *model.GroupMembership) error
 	CreateMemberships(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, memberships []model.GroupMembership) error
-	CreatePendingMembership(clientID string, current *model.User, group *model.Group, member *model.GroupMembership) error
-	ApplyMembershipApproval(clientID string, membershipID string, approve bool, rejectReason string) (*model.GroupMembership, error)
-	UpdateMembership(clientID string, _ *model.User, membershipID string, membership *model.GroupMembership) error
-	UpdateMemberships(clientID string, user *model.User, groupID string, operation model.MembershipMultiUpdate) error
+	CreatePendingMembership(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, member *model.GroupMembership) error
+	ApplyMembershipApproval(context storage.TransactionContext, clientID string, membershipID string, approve bool, rejectReason string, transition model.MembershipTransition) (*model.GroupMembership, error)
+	UpdateMembership(context storage.TransactionContext, clientID string, _ *model.User, membershipID string, membership *model.GroupMembership, transition *model.MembershipTransition) error
+	UpdateMemberships(context storage.TransactionContext, clientID string, user *model.User, groupID string, operation model.MembershipMultiUpdate, transitions map[string]model.MembershipTransition) error
 	DeleteMembership(clientID string, groupID string, userID string) error
-	DeleteMembershipByID(clientID string, current *model.User, membershipID string) error
-	DeleteUnsyncedGroupMemberships(clientID string, groupID string, syncID string) (int64, error)
+	DeleteMembershipWithContext(context storage.TransactionContext, clientID string, groupID string, userID string) error
+	DeleteMembershipByID(context storage.TransactionContext, clientID string, current *model.User, membershipID string) error
+	DeleteUnsyncedGroupMemberships(context storage.TransactionContext, clientID string, groupID string, syncID string) ([]model.GroupMembership, error)
 	DeleteGroupMembershipsByAccountsIDs(log *logs.Logger, context storage.TransactionContext, accountsIDs []string) error
 
 	GetGroupMembershipStats(context storage.TransactionContext, clientID string, groupID string) (*model.GroupStats, error)
@@ -522,6 +961,7 @@ func (a *storageListenerImpl) OnConfigsChanged() {
 // Notifications exposes Notifications BB APIs for the driver adapters
 type Notifications interface {
 	SendNotification(recipients []notifications.Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time) error
+	SendNotificationWithPriority(recipients []notifications.Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time, priority int) error
 	SendMail(toEmail string, subject string, body string) error
 	DeleteNotifications(appID string, orgID string, ids string) error
 	AddNotificationRecipients(appID string, orgID string, notificationID string, userIDs []string) error
@@ -563,3 +1003,8 @@ type Calendar interface {
 	AddPeopleToCalendarEvent(people []string, eventID string, orgID string, appID string) error
 	RemovePeopleFromCalendarEvent(people []string, eventID string, orgID string, appID string) error
 }
+
+// Webhooks exposes the delivery of the signed webhook payloads to the subscribed endpoints
+type Webhooks interface {
+	Deliver(url string, secret string, eventType string, deliveryID string, payload []byte) (int, error)
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	// AbuseReportTargetGroup the reported target is a group
+	AbuseReportTargetGroup = "group"
+	// AbuseReportTargetPost the reported target is a top level post
+	AbuseReportTargetPost = "post"
+	// AbuseReportTargetReply the reported target is a reply
+	AbuseReportTargetReply = "reply"
+)
+
+const (
+	// AbuseReportStatusOpen the case waits to be handled
+	AbuseReportStatusOpen = "open"
+	// AbuseReportStatusReviewing the case is being reviewed by its assignee
+	AbuseReportStatusReviewing = "reviewing"
+	// AbuseReportStatusActioned the case is closed and an action has been taken
+	AbuseReportStatusActioned = "actioned"
+	// AbuseReportStatusDismissed the case is closed without an action
+	AbuseReportStatusDismissed = "dismissed"
+)
+
+// AbuseReport represents an abuse case for a group, post or reply. The repeated reports of the same target are grouped within one case until it's closed.
+type AbuseReport struct {
+	ID                string             `json:"id" bson:"_id"`
+	ClientID          string             `json:"client_id" bson:"client_id"`
+	GroupID           string             `json:"group_id" bson:"group_id"`
+	TargetType        string             `json:"target_type" bson:"target_type"` // group, post or reply
+	TargetID          string             `json:"target_id" bson:"target_id"`
+	TargetTitle       string             `json:"target_title" bson:"target_title"`
+	SendToDean        bool               `json:"send_to_dean" bson:"send_to_dean"`
+	SendToGroupAdmins bool               `json:"send_to_group_admins" bson:"send_to_group_admins"`
+	Status            string             `json:"status" bson:"status"` // open, reviewing, actioned or dismissed
+	Active            bool               `json:"-" bson:"active"`      // true until the case is closed. Only one active case per target is allowed
+	AssigneeID        *string            `json:"assignee_id" bson:"assignee_id"`
+	ResolutionNotes   string             `json:"resolution_notes" bson:"resolution_notes"`
+	ResolvedBy        *string            `json:"resolved_by" bson:"resolved_by"`
+	Reports           []AbuseReportEntry `json:"reports" bson:"reports"`
+	ReportsCount      int                `json:"reports_count" bson:"reports_count"`
+	DateCreated       time.Time          `json:"date_created" bson:"date_created"`
+	DateUpdated       *time.Time         `json:"date_updated" bson:"date_updated"`
+	DateResolved      *time.Time         `json:"date_resolved" bson:"date_resolved"`
+} //@name AbuseReport
+
+// IsClosed checks if the case is already resolved
+func (r *AbuseReport) IsClosed() bool {
+	return r.Status == AbuseReportStatusActioned || r.Status == AbuseReportStatusDismissed
+}
+
+// AbuseReportEntry represents a single report of a user within an abuse case
+type AbuseReportEntry struct {
+	ReporterID         string    `json:"reporter_id" bson:"reporter_id"`
+	ReporterExternalID string    `json:"reporter_external_id" bson:"reporter_external_id"`
+	ReporterName       string    `json:"reporter_name" bson:"reporter_name"`
+	Comment            string    `json:"comment" bson:"comment"`
+	SendToDean         bool      `json:"send_to_dean" bson:"send_to_dean"`
+	SendToGroupAdmins  bool      `json:"send_to_group_admins" bson:"send_to_group_admins"`
+	DateCreated        time.Time `json:"date_created" bson:"date_created"`
+} //@name AbuseReportEntry
+
+// IsValidAbuseReportStatus checks if the provided status is a known abuse case status
+func IsValidAbuseReportStatus(status string) bool {
+	switch status {
+	case AbuseReportStatusOpen, AbuseReportStatusReviewing, AbuseReportStatusActioned, AbuseReportStatusDismissed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MaxAttendanceSessionTitleLength is the max length of an attendance session title
+const MaxAttendanceSessionTitleLength = 256
+
+const (
+	// AttendanceSourceAdmin the member has been checked in by a group admin
+	AttendanceSourceAdmin = "admin"
+	// AttendanceSourceSelf the member has checked in with a check-in code
+	AttendanceSourceSelf = "self"
+)
+
+const (
+	// DefaultAttendanceCodePeriod is the default number of seconds after which the check-in code rotates
+	DefaultAttendanceCodePeriod = 30
+	// MinAttendanceCodePeriod is the min number of seconds after which the check-in code rotates
+	MinAttendanceCodePeriod = 10
+	// MaxAttendanceCodePeriod is the max number of seconds after which the check-in code rotates
+	MaxAttendanceCodePeriod = 300
+	// MaxAttendanceCheckInWindow is the max duration of a check-in window
+	MaxAttendanceCheckInWindow = 24 * time.Hour
+	// MaxAttendanceCheckInFailures is the max number of wrong check-in codes a user may submit for a group within AttendanceCheckInFailuresPeriod
+	MaxAttendanceCheckInFailures = 5
+	// AttendanceCheckInFailuresPeriod is the period in which the wrong check-in codes are counted
+	AttendanceCheckInFailuresPeriod = 15 * time.Minute
+
+	attendanceCodeDigits = 6
+)
+
+var (
+	// ErrInvalidAttendanceCode is returned when the check-in code does not match an open check-in window
+	ErrInvalidAttendanceCode = errors.New("the check-in code is invalid or expired")
+	// ErrTooManyAttendanceCheckInFailures is returned when the user has submitted too many wrong check-in codes
+	ErrTooManyAttendanceCheckInFailures = errors.New("too many invalid check-in codes, try again later")
+)
+
+// AttendanceSession represents a meeting of an attendance group
+type AttendanceSession struct {
+	ID          string     `json:"id" bson:"_id"`
+	ClientID    string     `json:"client_id" bson:"client_id"`
+	GroupID     string     `json:"group_id" bson:"group_id"`
+	Title       string     `json:"title" bson:"title"`
+	Date        time.Time  `json:"date" bson:"date"`
+	EventID     *string    `json:"event_id" bson:"event_id"` // the linked group calendar event
+	CreatedBy   string     `json:"created_by" bson:"created_by"`
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+
+	CheckInWindow *AttendanceCheckInWindow `json:"check_in_window" bson:"check_in_window,omitempty"` // set while the members may check in themselves
+} //@name AttendanceSession
+
+// Validate checks the attendance session fields which are set by the admins
+func (s *AttendanceSession) Validate() error {
+	if s.Date.IsZero() {
+		return fmt.Errorf("the session date is required")
+	}
+	if len(s.Title) > MaxAttendanceSessionTitleLength {
+		return fmt.Errorf("the session title is longer than %d characters", MaxAttendanceSessionTitleLength)
+	}
+	if s.EventID != nil && len(*s.EventID) == 0 {
+		s.EventID = nil
+	}
+	return nil
+}
+
+// AttendanceCheckInWindow represents the period in which the members may check in themselves with a rotating code
+type AttendanceCheckInWindow struct {
+	Secret            string    `json:"-" bson:"secret"`
+	CodePeriod        int       `json:"code_period" bson:"code_period"` // in seconds
+	AutoAddNonMembers bool      `json:"auto_add_non_members" bson:"auto_add_non_members"`
+	DateOpens         time.Time `json:"date_opens" bson:"date_opens"`
+	DateCloses        time.Time `json:"date_closes" bson:"date_closes"`
+	OpenedBy          string    `json:"opened_by" bson:"opened_by"`
+} //@name AttendanceCheckInWindow
+
+// NewAttendanceCheckInWindow creates a check-in window with a new secret
+func NewAttendanceCheckInWindow(dateOpens time.Time, dateCloses time.Time, codePeriod int, autoAddNonMembers bool, openedBy string) (*AttendanceCheckInWindow, error) {
+	if codePeriod == 0 {
+		codePeriod = DefaultAttendanceCodePeriod
+	}
+	if codePeriod < MinAttendanceCodePeriod || codePeriod > MaxAttendanceCodePeriod {
+		return nil, fmt.Errorf("the code period must be between %d and %d seconds", MinAttendanceCodePeriod, MaxAttendanceCodePeriod)
+	}
+	if !dateCloses.After(dateOpens) {
+		return nil, fmt.Errorf("the check-in window must close after it opens")
+	}
+	if dateCloses.Sub(dateOpens) > MaxAttendanceCheckInWindow {
+		return nil, fmt.Errorf("the check-in window is longer than %s", MaxAttendanceCheckInWindow)
+	}
+
+	secret := make([]byte, 20)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error generating the check-in secret: %s", err)
+	}
+
+	return &AttendanceCheckInWindow{Secret: base64.StdEncoding.EncodeToString(secret), CodePeriod: codePeriod,
+		AutoAddNonMembers: autoAddNonMembers, DateOpens: dateOpens.UTC(), DateCloses: dateCloses.UTC(), OpenedBy: openedBy}, nil
+}
+
+// IsOpen says if the members may check in at the given time
+func (w *AttendanceCheckInWindow) IsOpen(now time.Time) bool {
+	return !now.Before(w.DateOpens) && now.Before(w.DateCloses)
+}
+
+// Code gives the check-in code valid at the given time and the time when it rotates. The code is generated as a TOTP (RFC 6238) code.
+func (w *AttendanceCheckInWindow) Code(now time.Time) (string, time.Time) {
+	step := now.Unix() / int64(w.CodePeriod)
+	return w.codeAt(step), time.Unix((step+1)*int64(w.CodePeriod), 0).UTC()
+}
+
+// VerifyCode checks the code against the one valid at the given time. The previous code is accepted too as it may have rotated while the member was typing it.
+func (w *AttendanceCheckInWindow) VerifyCode(code string, now time.Time) bool {
+	if !w.IsOpen(now) || len(code) != attendanceCodeDigits {
+		return false
+	}
+	step := now.Unix() / int64(w.CodePeriod)
+	for _, candidate := range []string{w.codeAt(step), w.codeAt(step - 1)} {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *AttendanceCheckInWindow) codeAt(step int64) string {
+	secret, _ := base64.StdEncoding.DecodeString(w.Secret)
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// AttendanceCheckInCode represents the current check-in code of a session which the admins display to the members
+type AttendanceCheckInCode struct {
+	SessionID   string    `json:"session_id"`
+	Code        string    `json:"code"`
+	QRPayload   string    `json:"qr_payload"` // the content of the QR code which the app scans
+	DateExpires time.Time `json:"date_expires"`
+	DateCloses  time.Time `json:"date_closes"` // when the check-in window closes
+} //@name AttendanceCheckInCode
+
+// NewAttendanceCheckInCode gives the check-in code of the session valid at the given time
+func NewAttendanceCheckInCode(session AttendanceSession, now time.Time) (*AttendanceCheckInCode, error) {
+	if session.CheckInWindow == nil || !session.CheckInWindow.IsOpen(now) {
+		return nil, fmt.Errorf("the check-in window of session %s is not open", session.ID)
+	}
+
+	code, dateExpires := session.CheckInWindow.Code(now)
+	if dateExpires.After(session.CheckInWindow.DateCloses) {
+		dateExpires = session.CheckInWindow.DateCloses
+	}
+	query := url.Values{}
+	query.Set("group_id", session.GroupID)
+	query.Set("session_id", session.ID)
+	query.Set("code", code)
+	qrPayload := "groups://attendance/check-in?" + query.Encode()
+
+	return &AttendanceCheckInCode{SessionID: session.ID, Code: code, QRPayload: qrPayload,
+		DateExpires: dateExpires, DateCloses: session.CheckInWindow.DateCloses}, nil
+}
+
+// AttendanceRecord represents the check-in of a member for an attendance session
+type AttendanceRecord struct {
+	ID             string     `json:"id" bson:"_id"`
+	ClientID       string     `json:"client_id" bson:"client_id"`
+	GroupID        string     `json:"group_id" bson:"group_id"`
+	SessionID      string     `json:"session_id" bson:"session_id"`
+	MembershipID   string     `json:"membership_id" bson:"membership_id"`
+	UserID         string     `json:"user_id" bson:"user_id"`
+	Name           string     `json:"name" bson:"name"`
+	Source         string     `json:"source" bson:"source"` // how the member has been checked in
+	CheckedInBy    string     `json:"checked_in_by" bson:"checked_in_by"`
+	DateCheckedIn  time.Time  `json:"date_checked_in" bson:"date_checked_in"`
+	CheckedOutBy   *string    `json:"checked_out_by" bson:"checked_out_by"`
+	DateCheckedOut *time.Time `json:"date_checked_out" bson:"date_checked_out"`
+} //@name AttendanceRecord
+
+// AttendanceSessionReport represents the attendance of a session
+type AttendanceSessionReport struct {
+	Session        AttendanceSession `json:"session"`
+	AttendeesCount int               `json:"attendees_count"`
+	MembersCount   int               `json:"members_count"` // the current members who have joined by the session date
+	Rate           float64           `json:"rate"`          // attendees count / members count
+} //@name AttendanceSessionReport
+
+// AttendanceMemberReport represents the attendance of a member
+type AttendanceMemberReport struct {
+	MembershipID     string     `json:"membership_id"`
+	UserID           string     `json:"user_id"`
+	Name             string     `json:"name"`
+	AttendedCount    int        `json:"attended_count"`
+	SessionsCount    int        `json:"sessions_count"` // the sessions since the member has joined
+	Rate             float64    `json:"rate"`           // attended count / sessions count
+	DateLastAttended *time.Time `json:"date_last_attended"`
+} //@name AttendanceMemberReport
+
+// AttendanceReport represents the attendance of a group per session and per member
+type AttendanceReport struct {
+	Sessions []AttendanceSessionReport `json:"sessions"`
+	Members  []AttendanceMemberReport  `json:"members"`
+} //@name AttendanceReport
+
+// NewAttendanceReport calculates the attendance report of the sessions for the current members
+func NewAttendanceReport(sessions []AttendanceSession, records []AttendanceRecord, memberships []GroupMembership) AttendanceReport {
+	attended := map[string]map[string]time.Time{} // membership id -> session id -> check-in date
+	for _, record := range records {
+		if attended[record.MembershipID] == nil {
+			attended[record.MembershipID] = map[string]time.Time{}
+		}
+		attended[record.MembershipID][record.SessionID] = record.DateCheckedIn
+	}
+
+	report := AttendanceReport{Sessions: make([]AttendanceSessionReport, len(sessions)), Members: make([]AttendanceMemberReport, len(memberships))}
+	for i, session := range sessions {
+		report.Sessions[i] = AttendanceSessionReport{Session: session}
+	}
+
+	for i, membership := range memberships {
+		memberReport := AttendanceMemberReport{MembershipID: membership.ID, UserID: membership.UserID, Name: membership.Name}
+		for j, session := range sessions {
+			checkIn, ok := attended[membership.ID][session.ID]
+			// the sessions before joining are not counted unless the member has attended them
+			if !ok && session.Date.Before(membership.DateCreated) {
+				continue
+			}
+			memberReport.SessionsCount++
+			report.Sessions[j].MembersCount++
+			if ok {
+				memberReport.AttendedCount++
+				report.Sessions[j].AttendeesCount++
+				if memberReport.DateLastAttended == nil || checkIn.After(*memberReport.DateLastAttended) {
+					date := checkIn
+					memberReport.DateLastAttended = &date
+				}
+			}
+		}
+		memberReport.Rate = attendanceRate(memberReport.AttendedCount, memberReport.SessionsCount)
+		report.Members[i] = memberReport
+	}
+
+	for i := range report.Sessions {
+		report.Sessions[i].Rate = attendanceRate(report.Sessions[i].AttendeesCount, report.Sessions[i].MembersCount)
+	}
+	return report
+}
+
+func attendanceRate(attended int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(attended) / float64(total)
+}
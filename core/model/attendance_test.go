@@ -0,0 +1,126 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// rfc6238Window is a window with the SHA1 secret of the RFC 6238 test vectors
+func rfc6238Window() *AttendanceCheckInWindow {
+	return &AttendanceCheckInWindow{
+		Secret:     base64.StdEncoding.EncodeToString([]byte("12345678901234567890")),
+		CodePeriod: 30,
+		DateOpens:  time.Unix(0, 0),
+		DateCloses: time.Unix(3000000000, 0),
+	}
+}
+
+func TestAttendanceCheckInWindowCode(t *testing.T) {
+	// the RFC 6238 SHA1 test vectors truncated to 6 digits
+	tests := []struct {
+		unix        int64
+		want        string
+		wantRotates int64
+	}{
+		{59, "287082", 60},
+		{1111111109, "081804", 1111111110},
+		{1111111111, "050471", 1111111140},
+		{1234567890, "005924", 1234567920},
+		{2000000000, "279037", 2000000010},
+	}
+
+	window := rfc6238Window()
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().String(), func(t *testing.T) {
+			code, rotates := window.Code(time.Unix(tt.unix, 0))
+			if code != tt.want {
+				t.Errorf("Code() = %s, want %s", code, tt.want)
+			}
+			if rotates.Unix() != tt.wantRotates {
+				t.Errorf("Code() rotates at %d, want %d", rotates.Unix(), tt.wantRotates)
+			}
+		})
+	}
+}
+
+func TestAttendanceCheckInWindowVerifyCode(t *testing.T) {
+	window := rfc6238Window()
+	window.DateOpens = time.Unix(1111111000, 0)
+	window.DateCloses = time.Unix(1111112000, 0)
+
+	tests := []struct {
+		name string
+		code string
+		unix int64
+		want bool
+	}{
+		{"current code", "050471", 1111111111, true},
+		{"previous code", "081804", 1111111111, true},
+		{"code of two periods ago", "081804", 1111111141, false},
+		{"future code", "050471", 1111111109, false},
+		{"wrong code", "123456", 1111111111, false},
+		{"short code", "05047", 1111111111, false},
+		{"before the window opens", "081804", 1111110999, false},
+		{"after the window closes", "050471", 1111112000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := window.VerifyCode(tt.code, time.Unix(tt.unix, 0)); got != tt.want {
+				t.Errorf("VerifyCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAttendanceCheckInWindow(t *testing.T) {
+	opens := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		closes     time.Time
+		codePeriod int
+		wantPeriod int
+		wantErr    bool
+	}{
+		{"default period", opens.Add(time.Hour), 0, DefaultAttendanceCodePeriod, false},
+		{"custom period", opens.Add(time.Hour), 60, 60, false},
+		{"short period", opens.Add(time.Hour), MinAttendanceCodePeriod - 1, 0, true},
+		{"long period", opens.Add(time.Hour), MaxAttendanceCodePeriod + 1, 0, true},
+		{"closes before it opens", opens, 30, 0, true},
+		{"long window", opens.Add(MaxAttendanceCheckInWindow + time.Second), 30, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := NewAttendanceCheckInWindow(opens, tt.closes, tt.codePeriod, false, "u1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAttendanceCheckInWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if window.CodePeriod != tt.wantPeriod {
+				t.Errorf("NewAttendanceCheckInWindow() code period = %d, want %d", window.CodePeriod, tt.wantPeriod)
+			}
+			code, _ := window.Code(opens)
+			if !window.VerifyCode(code, opens) {
+				t.Errorf("NewAttendanceCheckInWindow() the window does not verify its own code %s", code)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "groups/utils"
+
+const (
+	// BodyFormatPlain the text is shown as it is. This is the default format
+	BodyFormatPlain = "plain"
+	// BodyFormatMarkdown the text is markdown
+	BodyFormatMarkdown = "markdown"
+)
+
+// IsValidBodyFormat checks if the format is supported. Empty means plain.
+func IsValidBodyFormat(format string) bool {
+	return format == "" || format == BodyFormatPlain || format == BodyFormatMarkdown
+}
+
+// RenderBodyHTML renders the source in the provided format as safe HTML
+func RenderBodyHTML(format string, source string) string {
+	if format == BodyFormatMarkdown {
+		return utils.RenderMarkdown(source)
+	}
+	return utils.RenderPlainText(source)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Change feed entity types
+const (
+	ChangeEntityGroup      string = "group"
+	ChangeEntityMembership string = "membership"
+	ChangeEntityPost       string = "post"
+)
+
+// Change feed operations
+const (
+	ChangeOperationCreated string = "created"
+	ChangeOperationUpdated string = "updated"
+	ChangeOperationDeleted string = "deleted"
+)
+
+// ErrChangeTokenExpired is returned when the change feed cannot be resumed from the given token.
+// The consumer must resynchronize its data and start reading the feed without a token.
+var ErrChangeTokenExpired = errors.New("the change token is invalid or expired")
+
+// RedactedChangeFields contains the fields which are never exposed by the change feed for every entity type
+var RedactedChangeFields = map[string][]string{
+	ChangeEntityGroup:      {"members", "research_profile"},
+	ChangeEntityMembership: {"email", "net_id", "member_answers", "notifications_preferences", "status_history", "profile_values", "search_name", "search_net_id", "search_email"},
+	ChangeEntityPost:       {"reactions"},
+}
+
+// ChangeEvent represents a normalized change of a group, a membership or a post
+type ChangeEvent struct {
+	ID            string                 `json:"id"` // the token from which the feed continues after this change
+	ClientID      string                 `json:"client_id"`
+	EntityType    string                 `json:"entity_type"` // group, membership or post
+	EntityID      string                 `json:"entity_id"`
+	GroupID       string                 `json:"group_id"`
+	Operation     string                 `json:"operation"`                // created, updated or deleted
+	UpdatedFields []string               `json:"updated_fields,omitempty"` // the changed fields for the partial updates
+	Data          map[string]interface{} `json:"data,omitempty"`           // the current redacted document. Empty for the deleted entities
+	PreviousData  map[string]interface{} `json:"-"`                        // the redacted document before the change if it is available
+	Date          time.Time              `json:"date"`
+}
+
+// ChangeBatch represents a page of the change feed
+type ChangeBatch struct {
+	Changes   []ChangeEvent `json:"changes"`
+	NextToken string        `json:"next_token"` // pass it as "since" to continue reading the feed
+}
+
+// RedactChangeData removes the redacted fields of the entity type from the document
+func RedactChangeData(entityType string, data map[string]interface{}) {
+	for _, field := range RedactedChangeFields[entityType] {
+		delete(data, field)
+	}
+}
+
+// IsRedactedChangeField checks if the field or any of its parents is redacted for the entity type
+func IsRedactedChangeField(entityType string, field string) bool {
+	for _, redacted := range RedactedChangeFields[entityType] {
+		if field == redacted || strings.HasPrefix(field, redacted+".") {
+			return true
+		}
+	}
+	return false
+}
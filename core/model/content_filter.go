@@ -0,0 +1,45 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	// ContentFilterActionReject the post which matches a blocked word or pattern is not saved
+	ContentFilterActionReject = "reject"
+	// ContentFilterActionHold the post which matches a blocked word or pattern waits for a group admin review
+	ContentFilterActionHold = "hold"
+	// ContentFilterActionMask the matched words of the post are replaced with asterisks
+	ContentFilterActionMask = "mask"
+)
+
+// ContentFilter defines a blocklist of words and patterns which is applied on the posts subject and body.
+// The filter is applied for all groups of the client if the group id is missing.
+type ContentFilter struct {
+	ID          string     `json:"id" bson:"_id"`
+	ClientID    string     `json:"client_id" bson:"client_id"`
+	GroupID     *string    `json:"group_id" bson:"group_id"`
+	Name        string     `json:"name" bson:"name"`
+	Words       []string   `json:"words" bson:"words"`       // matched as whole words, case insensitive
+	Patterns    []string   `json:"patterns" bson:"patterns"` // regular expressions, case insensitive
+	CreatedBy   string     `json:"created_by" bson:"created_by"`
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name ContentFilter
+
+// AppliesToGroup checks if the filter is applied on the posts of the provided group
+func (f *ContentFilter) AppliesToGroup(groupID string) bool {
+	return f.GroupID == nil || *f.GroupID == groupID
+}
@@ -26,7 +26,8 @@ type Event struct {
 	GroupID       string     `json:"group_id" bson:"group_id"`
 	DateCreated   time.Time  `json:"date_created" bson:"date_created"`
 	Creator       *Creator   `json:"creator" bson:"creator"`
-	ToMembersList []ToMember `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToMembersList []ToMember `json:"to_members" bson:"to_members"`     // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToLabelIDs    []string   `json:"to_label_ids" bson:"to_label_ids"` // the member labels the event is addressed to in addition to to_members
 } // @name Event
 
 // AccountIdentifiers represents extended identfier which handles external id in addtion of the account id.
@@ -46,6 +47,16 @@ func (e Event) HasToMembersList() bool {
 	return len(e.ToMembersList) > 0
 }
 
+// IsTargeted Checks if the event is addressed to specific members or member labels instead of everyone
+func (e Event) IsTargeted() bool {
+	return len(e.ToMembersList) > 0 || len(e.ToLabelIDs) > 0
+}
+
+// HasToMemberLabel Checks if any of the member labels is within the ToLabelIDs ACL
+func (e Event) HasToMemberLabel(labelIDs []string) bool {
+	return HasAnyMemberLabel(labelIDs, e.ToLabelIDs)
+}
+
 // HasToMemberUser Checks if user with identifier exists whithin the ToMembers ACL
 func (e Event) HasToMemberUser(userID *string, externalID *string) bool {
 	for _, toMember := range e.ToMembersList {
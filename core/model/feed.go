@@ -0,0 +1,46 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// Real-time feed event types
+const (
+	FeedEventPostCreated        string = "post.created"
+	FeedEventPostUpdated        string = "post.updated"
+	FeedEventPostDeleted        string = "post.deleted"
+	FeedEventPostReactions      string = "post.reactions"
+	FeedEventMembershipApproved string = "membership.approved"
+)
+
+// FeedEvent represents an event pushed to the real-time group feed
+type FeedEvent struct {
+	Type           string         `json:"type"`
+	GroupID        string         `json:"group_id"`
+	PostID         *string        `json:"post_id,omitempty"`
+	Post           *Post          `json:"post,omitempty"`            // post.created and post.updated only
+	ReactionCounts map[string]int `json:"reaction_counts,omitempty"` // post.reactions only
+	Member         *FeedMember    `json:"member,omitempty"`          // membership.approved only
+	Date           time.Time      `json:"date"`
+} //@name FeedEvent
+
+// FeedMember represents the public member data within a real-time feed event
+type FeedMember struct {
+	MembershipID string `json:"membership_id"`
+	UserID       string `json:"user_id"`
+	Name         string `json:"name"`
+	PhotoURL     string `json:"photo_url"`
+	Status       string `json:"status"`
+} //@name FeedMember
@@ -2,17 +2,19 @@ package model
 
 // MembershipFilter Wraps all possible filters for getting group members call
 type MembershipFilter struct {
-	ID         *string  `json:"id"`          // membership id
-	GroupIDs   []string `json:"group_ids"`   // list of group ids
-	UserID     *string  `json:"user_id"`     // core user id
-	UserIDs    []string `json:"user_ids"`    // core user ids
-	ExternalID *string  `json:"external_id"` // core user external id
-	NetID      *string  `json:"net_id"`      // core user net id
-	NetIDs     []string `json:"net_ids"`     // core user net ids
-	Name       *string  `json:"name"`        // member's name
-	Statuses   []string `json:"statuses"`    // lest of membership statuses
-	Offset     *int64   `json:"offset"`      // result offset
-	Limit      *int64   `json:"limit"`       // result limit
+	ID            *string                `json:"id"`             // membership id
+	GroupIDs      []string               `json:"group_ids"`      // list of group ids
+	UserID        *string                `json:"user_id"`        // core user id
+	UserIDs       []string               `json:"user_ids"`       // core user ids
+	ExternalID    *string                `json:"external_id"`    // core user external id
+	NetID         *string                `json:"net_id"`         // core user net id
+	NetIDs        []string               `json:"net_ids"`        // core user net ids
+	Name          *string                `json:"name"`           // member's name
+	Statuses      []string               `json:"statuses"`       // lest of membership statuses
+	ProfileValues map[string]interface{} `json:"profile_values"` // profile field values by key. A list value matches any of its items
+	LabelIDs      []string               `json:"label_ids"`      // member label ids. Matches the members with any of the labels
+	Offset        *int64                 `json:"offset"`         // result offset
+	Limit         *int64                 `json:"limit"`          // result limit
 } // @name MembershipFilter
 
 // GroupsFilter Wraps all possible filters for getting a group
@@ -46,4 +48,16 @@ type PostsFilter struct {
 	Offset        *int64  `json:"offset"`
 	Limit         *int64  `json:"limit"`
 	Order         *string `json:"order"`
+	RepliesLimit  *int64  `json:"replies_limit"` // paging only - load only the latest direct replies of the top level posts
 } // @name PostsFilter
+
+// AbuseReportsFilter Wraps all possible filters for getting abuse reports call
+type AbuseReportsFilter struct {
+	GroupID    *string  `json:"group_id"`
+	TargetType *string  `json:"target_type"`
+	TargetID   *string  `json:"target_id"`
+	Statuses   []string `json:"statuses"`
+	AssigneeID *string  `json:"assignee_id"`
+	Offset     *int64   `json:"offset"`
+	Limit      *int64   `json:"limit"`
+} // @name AbuseReportsFilter
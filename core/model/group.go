@@ -15,24 +15,29 @@
 package model
 
 import (
+	"fmt"
 	"sort"
 	"time"
 )
 
 // Group represents group entity
 type Group struct {
-	ID                  string   `json:"id" bson:"_id"`
-	ClientID            string   `json:"client_id" bson:"client_id"`
-	Category            string   `json:"category" bson:"category"` //one of the enums categories list
-	Title               string   `json:"title" bson:"title"`
-	Privacy             string   `json:"privacy" bson:"privacy"` //public or private
-	HiddenForSearch     bool     `json:"hidden_for_search" bson:"hidden_for_search"`
-	Description         *string  `json:"description" bson:"description"`
-	ImageURL            *string  `json:"image_url" bson:"image_url"`
-	WebURL              *string  `json:"web_url" bson:"web_url"`
-	Tags                []string `json:"tags" bson:"tags"`
-	MembershipQuestions []string `json:"membership_questions" bson:"membership_questions"`
-	IsAbuse             *bool    `json:"is_abuse,omitempty" bson:"is_abuse,omitempty"`
+	ID                  string               `json:"id" bson:"_id"`
+	ClientID            string               `json:"client_id" bson:"client_id"`
+	Category            string               `json:"category" bson:"category"` //one of the enums categories list
+	Title               string               `json:"title" bson:"title"`
+	Privacy             string               `json:"privacy" bson:"privacy"` //public or private
+	HiddenForSearch     bool                 `json:"hidden_for_search" bson:"hidden_for_search"`
+	Description         *string              `json:"description" bson:"description"`
+	DescriptionFormat   string               `json:"description_format" bson:"description_format"` // plain or markdown. Empty means plain
+	DescriptionHTML     string               `json:"description_html" bson:"description_html"`     // the description rendered as safe HTML. This is constructed by the code
+	ImageURL            *string              `json:"image_url" bson:"image_url"`
+	WebURL              *string              `json:"web_url" bson:"web_url"`
+	Tags                []string             `json:"tags" bson:"tags"`
+	MembershipQuestions []string             `json:"membership_questions" bson:"membership_questions"`
+	ProfileFields       []MemberProfileField `json:"profile_fields" bson:"profile_fields"` // the custom member profile fields. They are updated separately from the group
+	MemberLabels        []MemberLabel        `json:"member_labels" bson:"member_labels"`   // the labels which the admins assign to the members. They are updated separately from the group
+	IsAbuse             *bool                `json:"is_abuse,omitempty" bson:"is_abuse,omitempty"`
 
 	Settings   *GroupSettings         `json:"settings" bson:"settings"` // TODO: Remove the pointer once the backward support is not needed any more!
 	Attributes map[string]interface{} `json:"attributes" bson:"attributes"`
@@ -41,6 +46,8 @@ type Group struct {
 	Members       []Member         `json:"members,omitempty" bson:"members,omitempty"`
 	Stats         GroupStats       `json:"stats" bson:"stats"`
 
+	UnreadPostsCount *int64 `json:"unread_posts_count,omitempty" bson:"-"` // this is indicative and it's constructed only for the user groups
+
 	DateCreated                  time.Time  `json:"date_created" bson:"date_created"`
 	DateUpdated                  *time.Time `json:"date_updated" bson:"date_updated"`
 	DateMembershipUpdated        *time.Time `json:"date_membership_updated" bson:"date_membership_updated"`
@@ -64,6 +71,21 @@ type Group struct {
 	SyncEndTime   *time.Time `json:"sync_end_time" bson:"sync_end_time"`
 } // @name Group
 
+// RenderDescription validates the description format and renders the description as safe HTML
+func (gr *Group) RenderDescription() error {
+	if !IsValidBodyFormat(gr.DescriptionFormat) {
+		return fmt.Errorf("invalid description format %s", gr.DescriptionFormat)
+	}
+	if gr.DescriptionFormat == "" {
+		gr.DescriptionFormat = BodyFormatPlain
+	}
+	gr.DescriptionHTML = ""
+	if gr.Description != nil {
+		gr.DescriptionHTML = RenderBodyHTML(gr.DescriptionFormat, *gr.Description)
+	}
+	return nil
+}
+
 // GetGroupMembershipsResponse response
 type GetGroupMembershipsResponse struct {
 	GroupID string `json:"group_id"`
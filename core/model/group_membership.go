@@ -10,13 +10,6 @@ type MembershipCollection struct {
 	Items []GroupMembership
 }
 
-// ApplyGroupSettings Applies group settings
-func (c *MembershipCollection) ApplyGroupSettings(settings *GroupSettings) {
-	for index := range c.Items {
-		c.Items[index].ApplyGroupSettings(settings)
-	}
-}
-
 // GetMembershipByAccountID Finds a membership by account ID
 func (c *MembershipCollection) GetMembershipByAccountID(accountID string) *GroupMembership {
 	if len(c.Items) > 0 {
@@ -59,6 +52,17 @@ func (c *MembershipCollection) GetMembersAsRecipients(predicate membershipCriter
 	return recipients
 }
 
+// GetNotificationLocales gets the notification locales of the members mapped by user id
+func (c *MembershipCollection) GetNotificationLocales() map[string]string {
+	locales := map[string]string{}
+	for _, membership := range c.Items {
+		if membership.NotificationsPreferences.Locale != "" {
+			locales[membership.UserID] = membership.NotificationsPreferences.Locale
+		}
+	}
+	return locales
+}
+
 // GetMembersByStatus gets members by status field
 func (c *MembershipCollection) GetMembersByStatus(status string) []GroupMembership {
 	var members []GroupMembership
@@ -114,11 +118,22 @@ type GroupMembership struct {
 	MemberAnswers []MemberAnswer `json:"member_answers" bson:"member_answers"`
 	SyncID        string         `json:"sync_id" bson:"sync_id"` //ID of sync that last updated this membership
 
+	ProfileValues map[string]interface{} `json:"profile_values" bson:"profile_values,omitempty"` // the values of the group profile fields by key
+	LabelIDs      []string               `json:"label_ids" bson:"label_ids,omitempty"`           // the group member labels assigned by the admins
+
 	NotificationsPreferences NotificationsPreferences `json:"notifications_preferences" bson:"notifications_preferences"`
 
+	StatusHistory []MembershipTransition `json:"-" bson:"status_history,omitempty"` // the status transitions. It is exposed to the admins only
+
+	SearchName  []string `json:"-" bson:"search_name"`   // the lower-cased name from every word start so that the member search matches the name prefixes with an index
+	SearchNetID string   `json:"-" bson:"search_net_id"` // the lower-cased NetID for the member search
+	SearchEmail string   `json:"-" bson:"search_email"`  // the lower-cased email for the member search
+
 	DateCreated  time.Time  `json:"date_created" bson:"date_created"`
 	DateUpdated  *time.Time `json:"date_updated" bson:"date_updated"`
 	DateAttended *time.Time `json:"date_attended" bson:"date_attended"`
+	DateLastRead *time.Time `json:"date_last_read" bson:"date_last_read"` // the last time when the member has read the group posts
+	DateDigested *time.Time `json:"date_digested" bson:"date_digested"`   // the last time when the group activity has been included in an email digest for the member
 } //@name GroupMembership
 
 // GetDisplayName Constructs a display name based on the current data state
@@ -169,6 +184,31 @@ func (m *GroupMembership) ApplyFromCoreAccountIfEmpty(user CoreAccount) {
 	}
 }
 
+// RecordCreation validates the status of a new membership and appends its creation to the status history
+func (m *GroupMembership) RecordCreation(source string, actor *User, reason string) error {
+	transition, err := NewMembershipTransition("", m.Status, source, actor, reason)
+	if err != nil {
+		return err
+	}
+	m.StatusHistory = append(m.StatusHistory, *transition)
+	return nil
+}
+
+// ChangeStatus validates the transition to the status, applies it and appends it to the status history.
+// Returns nil if the status does not change.
+func (m *GroupMembership) ChangeStatus(status string, source string, actor *User, reason string) (*MembershipTransition, error) {
+	if m.Status == status {
+		return nil, nil
+	}
+	transition, err := NewMembershipTransition(m.Status, status, source, actor, reason)
+	if err != nil {
+		return nil, err
+	}
+	m.Status = status
+	m.StatusHistory = append(m.StatusHistory, *transition)
+	return transition, nil
+}
+
 // IsAdmin says if the user is admin of the group
 func (m *GroupMembership) IsAdmin() bool {
 	return m.Status == "admin"
@@ -194,28 +234,6 @@ func (m *GroupMembership) IsRejected() bool {
 	return m.Status == "rejected"
 }
 
-// ApplyGroupSettings Applies group settings and removes forbidden fields
-func (m *GroupMembership) ApplyGroupSettings(settings *GroupSettings) {
-	if settings == nil {
-		val := DefaultGroupSettings()
-		settings = &val
-	}
-	if !settings.MemberInfoPreferences.CanViewMemberName {
-		m.Name = ""
-	}
-	if !settings.MemberInfoPreferences.CanViewMemberNetID {
-		m.NetID = ""
-	}
-	if !settings.MemberInfoPreferences.CanViewMemberEmail {
-		m.Email = ""
-	}
-	if !settings.MemberInfoPreferences.CanViewMemberPhone {
-		// Just a placeholder.
-	}
-	m.ExternalID = ""
-
-}
-
 // ToShortMemberRecord converts to ShortMemberRecord
 func (m *GroupMembership) ToShortMemberRecord() ShortMemberRecord {
 	return ShortMemberRecord{
@@ -265,8 +283,31 @@ type NotificationsPreferences struct {
 	PostsMuted          bool `json:"posts_mute" bson:"posts_mute"`
 	EventsMuted         bool `json:"events_mute" bson:"events_mute"`
 	PollsMuted          bool `json:"polls_mute" bson:"polls_mute"`
+
+	DigestFrequency string `json:"digest_frequency" bson:"digest_frequency"` // daily or weekly email digest of the group activity. Empty means no digest
+	Locale          string `json:"locale" bson:"locale"`                     // locale of the notification templates. Empty means the default locale
 } // @name NotificationsPreferences
 
+const (
+	// DigestFrequencyDaily the digest is sent once a day
+	DigestFrequencyDaily = "daily"
+	// DigestFrequencyWeekly the digest is sent once a week
+	DigestFrequencyWeekly = "weekly"
+)
+
+// IsValidDigestFrequency checks if the digest frequency is supported. Empty means no digest.
+func IsValidDigestFrequency(frequency string) bool {
+	return frequency == "" || frequency == DigestFrequencyDaily || frequency == DigestFrequencyWeekly
+}
+
+// GetDigestPeriod returns the period which the digest covers
+func (p NotificationsPreferences) GetDigestPeriod() time.Duration {
+	if p.DigestFrequency == DigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
 // MembershipStatuses list of membership statuses
 type MembershipStatuses []MembershipStatus
 
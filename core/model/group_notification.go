@@ -6,6 +6,7 @@ type GroupNotification struct {
 	Sender         *Sender           `json:"sender"`
 	Members        UserRefs          `json:"members"`
 	MemberStatuses []string          `json:"member_statuses"` // default: ["admin", "member"]
+	LabelIDs       []string          `json:"label_ids"`       // the member labels the notification is sent to in addition to members
 	Subject        string            `json:"subject"`
 	Topic          *string           `json:"topic"`
 	Body           string            `json:"body"`
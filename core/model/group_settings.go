@@ -2,8 +2,9 @@ package model
 
 // GroupSettings wraps group settings and flags as a separate unit
 type GroupSettings struct {
-	MemberInfoPreferences MemberInfoPreferences `json:"member_info_preferences" bson:"member_info_preferences"`
-	PostPreferences       PostPreferences       `json:"post_preferences" bson:"post_preferences"`
+	MemberInfoPreferences  MemberInfoPreferences  `json:"member_info_preferences" bson:"member_info_preferences"`
+	PostPreferences        PostPreferences        `json:"post_preferences" bson:"post_preferences"`
+	MemberNotesPreferences MemberNotesPreferences `json:"member_notes_preferences" bson:"member_notes_preferences"`
 } // @name GroupSettings
 
 // DefaultGroupSettings Returns default settings
@@ -23,6 +24,7 @@ func DefaultGroupSettings() GroupSettings {
 			CanSendPostToAll:             true,
 			CanSendPostReplies:           true,
 			CanSendPostReactions:         true,
+			MaxPinnedPosts:               DefaultMaxPinnedPosts,
 		},
 	}
 }
@@ -38,10 +40,32 @@ type MemberInfoPreferences struct {
 
 // PostPreferences wraps post preferences
 type PostPreferences struct {
-	AllowSendPost                bool `json:"allow_send_post" bson:"allow_send_post"`
-	CanSendPostToSpecificMembers bool `json:"can_send_post_to_specific_members" bson:"can_send_post_to_specific_members"`
-	CanSendPostToAdmins          bool `json:"can_send_post_to_admins" bson:"can_send_post_to_admins"`
-	CanSendPostToAll             bool `json:"can_send_post_to_all" bson:"can_send_post_to_all"`
-	CanSendPostReplies           bool `json:"can_send_post_replies" bson:"can_send_post_replies"`
-	CanSendPostReactions         bool `json:"can_send_post_reactions" bson:"can_send_post_reactions"`
+	AllowSendPost                bool     `json:"allow_send_post" bson:"allow_send_post"`
+	CanSendPostToSpecificMembers bool     `json:"can_send_post_to_specific_members" bson:"can_send_post_to_specific_members"`
+	CanSendPostToAdmins          bool     `json:"can_send_post_to_admins" bson:"can_send_post_to_admins"`
+	CanSendPostToAll             bool     `json:"can_send_post_to_all" bson:"can_send_post_to_all"`
+	CanSendPostReplies           bool     `json:"can_send_post_replies" bson:"can_send_post_replies"`
+	CanSendPostReactions         bool     `json:"can_send_post_reactions" bson:"can_send_post_reactions"`
+	MaxPinnedPosts               int      `json:"max_pinned_posts" bson:"max_pinned_posts"`           // 0 means DefaultMaxPinnedPosts
+	RequirePostApproval          bool     `json:"require_post_approval" bson:"require_post_approval"` // posts from non-admins wait for a group admin approval
+	ContentFilterAction          string   `json:"content_filter_action" bson:"content_filter_action"` // reject, hold or mask. Empty means reject
+	AllowedReactions             []string `json:"allowed_reactions" bson:"allowed_reactions"`         // empty means the reactions allowed by the client config
 } // @name PostPreferences
+
+// GetMaxPinnedPosts returns the max count of pinned posts for the group
+func (p PostPreferences) GetMaxPinnedPosts() int {
+	if p.MaxPinnedPosts > 0 {
+		return p.MaxPinnedPosts
+	}
+	return DefaultMaxPinnedPosts
+}
+
+// GetContentFilterAction returns what happens with a post which matches the content filters of the group
+func (p PostPreferences) GetContentFilterAction() string {
+	switch p.ContentFilterAction {
+	case ContentFilterActionHold, ContentFilterActionMask:
+		return p.ContentFilterAction
+	default:
+		return ContentFilterActionReject
+	}
+}
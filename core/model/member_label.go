@@ -0,0 +1,113 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxMemberLabels is the max number of member labels a group may define
+	MaxMemberLabels = 100
+	// MaxMemberLabelNameLength is the max length of a member label name
+	MaxMemberLabelNameLength = 64
+)
+
+// MemberLabel represents a label which the group admins assign to the members, for example "Team A" or "Officers"
+type MemberLabel struct {
+	ID   string `json:"id" bson:"id"` // empty for a new label. It is generated by the code
+	Name string `json:"name" bson:"name"`
+} //@name MemberLabel
+
+// ValidateMemberLabels validates the labels of a group and generates the ids of the new labels. The names must be unique ignoring the case.
+func ValidateMemberLabels(labels []MemberLabel, existing []MemberLabel) ([]MemberLabel, error) {
+	if len(labels) > MaxMemberLabels {
+		return nil, fmt.Errorf("a group may define up to %d member labels", MaxMemberLabels)
+	}
+
+	result := make([]MemberLabel, 0, len(labels))
+	names := map[string]bool{}
+	ids := map[string]bool{}
+	for _, label := range labels {
+		label.Name = strings.TrimSpace(label.Name)
+		if len(label.Name) == 0 {
+			return nil, fmt.Errorf("the member label name is required")
+		}
+		if len(label.Name) > MaxMemberLabelNameLength {
+			return nil, fmt.Errorf("the member label name %s is longer than %d characters", label.Name, MaxMemberLabelNameLength)
+		}
+		name := strings.ToLower(label.Name)
+		if names[name] {
+			return nil, fmt.Errorf("duplicate member label %s", label.Name)
+		}
+		names[name] = true
+
+		if len(label.ID) == 0 {
+			label.ID = uuid.NewString()
+		} else if FindMemberLabel(existing, label.ID) == nil {
+			return nil, fmt.Errorf("the member label %s does not exist", label.ID)
+		} else if ids[label.ID] {
+			return nil, fmt.Errorf("duplicate member label id %s", label.ID)
+		}
+		ids[label.ID] = true
+		result = append(result, label)
+	}
+	return result, nil
+}
+
+// FindMemberLabel finds a label by id. Returns nil if there is no such label.
+func FindMemberLabel(labels []MemberLabel, id string) *MemberLabel {
+	for i := range labels {
+		if labels[i].ID == id {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+// ValidateMemberLabelIDs checks that all the label ids are defined by the group and removes the duplicates
+func ValidateMemberLabelIDs(labelIDs []string, labels []MemberLabel) ([]string, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, 0, len(labelIDs))
+	added := map[string]bool{}
+	for _, id := range labelIDs {
+		if FindMemberLabel(labels, id) == nil {
+			return nil, fmt.Errorf("the member label %s does not exist", id)
+		}
+		if !added[id] {
+			added[id] = true
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// HasAnyMemberLabel checks if any of the member labels is one of the target labels
+func HasAnyMemberLabel(memberLabelIDs []string, targetLabelIDs []string) bool {
+	for _, memberLabelID := range memberLabelIDs {
+		for _, targetLabelID := range targetLabelIDs {
+			if memberLabelID == targetLabelID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateMemberLabels(t *testing.T) {
+	existing := []MemberLabel{{ID: "l1", Name: "Team A"}, {ID: "l2", Name: "Officers"}}
+	tooMany := make([]MemberLabel, MaxMemberLabels+1)
+	for i := range tooMany {
+		tooMany[i] = MemberLabel{Name: string(rune('a'+i%26)) + string(rune('a'+i/26))}
+	}
+	longName := make([]byte, MaxMemberLabelNameLength+1)
+	for i := range longName {
+		longName[i] = 'a'
+	}
+
+	tests := []struct {
+		name      string
+		labels    []MemberLabel
+		wantNames []string
+		wantIDs   []string // the expected ids of the existing labels. Empty means a generated id.
+		wantErr   bool
+	}{
+		{"empty", nil, []string{}, []string{}, false},
+		{"keep existing and add new", []MemberLabel{{ID: "l1", Name: " Team A "}, {Name: "Team B"}}, []string{"Team A", "Team B"}, []string{"l1", ""}, false},
+		{"rename existing", []MemberLabel{{ID: "l2", Name: "Board"}}, []string{"Board"}, []string{"l2"}, false},
+		{"missing name", []MemberLabel{{Name: "  "}}, nil, nil, true},
+		{"long name", []MemberLabel{{Name: string(longName)}}, nil, nil, true},
+		{"duplicate name ignoring case", []MemberLabel{{Name: "Team A"}, {Name: "team a"}}, nil, nil, true},
+		{"unknown id", []MemberLabel{{ID: "l3", Name: "Team C"}}, nil, nil, true},
+		{"duplicate id", []MemberLabel{{ID: "l1", Name: "Team A"}, {ID: "l1", Name: "Team B"}}, nil, nil, true},
+		{"too many", tooMany, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateMemberLabels(tt.labels, existing)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMemberLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			names := []string{}
+			for i, label := range got {
+				names = append(names, label.Name)
+				if tt.wantIDs[i] == "" && label.ID == "" {
+					t.Errorf("ValidateMemberLabels() missing generated id for %s", label.Name)
+				} else if tt.wantIDs[i] != "" && label.ID != tt.wantIDs[i] {
+					t.Errorf("ValidateMemberLabels() id = %s, want %s", label.ID, tt.wantIDs[i])
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("ValidateMemberLabels() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestValidateMemberLabelIDs(t *testing.T) {
+	labels := []MemberLabel{{ID: "l1", Name: "Team A"}, {ID: "l2", Name: "Officers"}}
+	tests := []struct {
+		name     string
+		labelIDs []string
+		want     []string
+		wantErr  bool
+	}{
+		{"empty", nil, nil, false},
+		{"valid", []string{"l2", "l1"}, []string{"l2", "l1"}, false},
+		{"duplicates", []string{"l1", "l2", "l1"}, []string{"l1", "l2"}, false},
+		{"unknown", []string{"l1", "l3"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateMemberLabelIDs(tt.labelIDs, labels)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMemberLabelIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateMemberLabelIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasAnyMemberLabel(t *testing.T) {
+	tests := []struct {
+		name           string
+		memberLabelIDs []string
+		targetLabelIDs []string
+		want           bool
+	}{
+		{"no member labels", nil, []string{"l1"}, false},
+		{"no target labels", []string{"l1"}, nil, false},
+		{"one match", []string{"l1", "l2"}, []string{"l3", "l2"}, true},
+		{"no match", []string{"l1", "l2"}, []string{"l3"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasAnyMemberLabel(tt.memberLabelIDs, tt.targetLabelIDs); got != tt.want {
+				t.Errorf("HasAnyMemberLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxMemberNoteLength is the max length of an admin note on a member
+const MaxMemberNoteLength = 4000
+
+const (
+	// MemberNotesRetentionDelete the notes are deleted together with the membership
+	MemberNotesRetentionDelete = "delete"
+	// MemberNotesRetentionRetain the notes are kept after the membership is deleted and are attached again if the user rejoins the group
+	MemberNotesRetentionRetain = "retain"
+)
+
+// MemberNotesPreferences wraps the settings for the admin notes on members
+type MemberNotesPreferences struct {
+	Retention string `json:"retention" bson:"retention"` // delete or retain. Empty means delete
+} // @name MemberNotesPreferences
+
+// GetRetention returns what happens with the notes when the membership is deleted
+func (p MemberNotesPreferences) GetRetention() string {
+	if p.Retention == MemberNotesRetentionRetain {
+		return MemberNotesRetentionRetain
+	}
+	return MemberNotesRetentionDelete
+}
+
+// MemberNote represents a private note of the group admins on a member. The notes are never returned to the members.
+type MemberNote struct {
+	ID           string     `json:"id" bson:"_id"`
+	ClientID     string     `json:"client_id" bson:"client_id"`
+	GroupID      string     `json:"group_id" bson:"group_id"`
+	MembershipID string     `json:"membership_id" bson:"membership_id"`
+	UserID       string     `json:"user_id" bson:"user_id"` // the member
+	Text         string     `json:"text" bson:"text"`
+	Author       Creator    `json:"author" bson:"author"`
+	DateCreated  time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated  *time.Time `json:"date_updated" bson:"date_updated"`
+	UpdatedBy    *Creator   `json:"updated_by" bson:"updated_by"`
+} //@name MemberNote
+
+// ValidateMemberNoteText trims the text of a note and checks its length
+func ValidateMemberNoteText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		return "", fmt.Errorf("the note text is required")
+	}
+	if len(text) > MaxMemberNoteLength {
+		return "", fmt.Errorf("the note text is longer than %d characters", MaxMemberNoteLength)
+	}
+	return text, nil
+}
+
+// MemberRosterEntry represents a member in the roster export of the group admins
+type MemberRosterEntry struct {
+	Membership GroupMembership `json:"membership"`
+	Notes      []MemberNote    `json:"notes"`
+} //@name MemberRosterEntry
@@ -0,0 +1,184 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	// MemberProfileFieldTypeText free text
+	MemberProfileFieldTypeText = "text"
+	// MemberProfileFieldTypeNumber a number
+	MemberProfileFieldTypeNumber = "number"
+	// MemberProfileFieldTypeBoolean yes or no
+	MemberProfileFieldTypeBoolean = "boolean"
+	// MemberProfileFieldTypeSelect one of the field options
+	MemberProfileFieldTypeSelect = "select"
+	// MemberProfileFieldTypeDate a date in the YYYY-MM-DD format
+	MemberProfileFieldTypeDate = "date"
+
+	// MemberProfileFieldVisibilityAdmins only the group admins see the value
+	MemberProfileFieldVisibilityAdmins = "admins"
+	// MemberProfileFieldVisibilityMembers the group members and admins see the value
+	MemberProfileFieldVisibilityMembers = "members"
+	// MemberProfileFieldVisibilityPublic everybody who may list the members sees the value
+	MemberProfileFieldVisibilityPublic = "public"
+
+	// MaxMemberProfileFields is the max number of profile fields a group may define
+	MaxMemberProfileFields = 30
+	// MaxMemberProfileFieldLabelLength is the max length of a profile field label
+	MaxMemberProfileFieldLabelLength = 128
+	// MaxMemberProfileFieldValueLength is the max length of a text profile field value
+	MaxMemberProfileFieldValueLength = 1024
+
+	memberProfileFieldDateLayout = "2006-01-02"
+)
+
+var memberProfileFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// MemberProfileField represents a custom member profile field defined by the group admins
+type MemberProfileField struct {
+	Key            string   `json:"key" bson:"key"` // lowercase letters, digits and underscores
+	Label          string   `json:"label" bson:"label"`
+	Type           string   `json:"type" bson:"type"`             // text, number, boolean, select or date
+	Options        []string `json:"options" bson:"options"`       // the allowed values of a select field
+	Visibility     string   `json:"visibility" bson:"visibility"` // admins, members or public
+	MemberEditable bool     `json:"member_editable" bson:"member_editable"`
+} //@name MemberProfileField
+
+// IsValidMemberProfileFieldKey checks if the key may be used as a profile field key
+func IsValidMemberProfileFieldKey(key string) bool {
+	return memberProfileFieldKeyPattern.MatchString(key)
+}
+
+// IsValidProfileFilterValue checks if the value may be matched by a profile values filter. Only strings, numbers and booleans are allowed.
+func IsValidProfileFilterValue(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, float64, int, int32, int64:
+		return true
+	}
+	return false
+}
+
+// ValidateProfileValuesFilter validates the profile values of a filter. Every value must be a string, number or boolean or a list of them.
+func ValidateProfileValuesFilter(values map[string]interface{}) error {
+	for key, value := range values {
+		if !IsValidMemberProfileFieldKey(key) {
+			return fmt.Errorf("invalid profile field key %s", key)
+		}
+		if list, ok := value.([]interface{}); ok {
+			for _, item := range list {
+				if !IsValidProfileFilterValue(item) {
+					return fmt.Errorf("invalid value of profile field %s", key)
+				}
+			}
+			continue
+		}
+		if !IsValidProfileFilterValue(value) {
+			return fmt.Errorf("invalid value of profile field %s", key)
+		}
+	}
+	return nil
+}
+
+// ValidateMemberProfileFields validates the profile fields of a group
+func ValidateMemberProfileFields(fields []MemberProfileField) error {
+	if len(fields) > MaxMemberProfileFields {
+		return fmt.Errorf("a group may define up to %d profile fields", MaxMemberProfileFields)
+	}
+
+	keys := map[string]bool{}
+	for _, field := range fields {
+		if !IsValidMemberProfileFieldKey(field.Key) {
+			return fmt.Errorf("invalid profile field key %s", field.Key)
+		}
+		if keys[field.Key] {
+			return fmt.Errorf("duplicate profile field key %s", field.Key)
+		}
+		keys[field.Key] = true
+
+		if len(field.Label) == 0 || len(field.Label) > MaxMemberProfileFieldLabelLength {
+			return fmt.Errorf("the label of profile field %s must be between 1 and %d characters", field.Key, MaxMemberProfileFieldLabelLength)
+		}
+		switch field.Type {
+		case MemberProfileFieldTypeText, MemberProfileFieldTypeNumber, MemberProfileFieldTypeBoolean, MemberProfileFieldTypeDate:
+			if len(field.Options) > 0 {
+				return fmt.Errorf("only the select profile fields may have options")
+			}
+		case MemberProfileFieldTypeSelect:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("the select profile field %s requires options", field.Key)
+			}
+		default:
+			return fmt.Errorf("invalid type %s of profile field %s", field.Type, field.Key)
+		}
+		switch field.Visibility {
+		case MemberProfileFieldVisibilityAdmins, MemberProfileFieldVisibilityMembers, MemberProfileFieldVisibilityPublic:
+		default:
+			return fmt.Errorf("invalid visibility %s of profile field %s", field.Visibility, field.Key)
+		}
+	}
+	return nil
+}
+
+// FindMemberProfileField finds a profile field by key. Returns nil if there is no such field.
+func FindMemberProfileField(fields []MemberProfileField, key string) *MemberProfileField {
+	for i := range fields {
+		if fields[i].Key == key {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// NormalizeValue checks the value against the field type. The value is as decoded from JSON. Nil means no value.
+func (f MemberProfileField) NormalizeValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch f.Type {
+	case MemberProfileFieldTypeText:
+		if text, ok := value.(string); ok && len(text) <= MaxMemberProfileFieldValueLength {
+			return text, nil
+		}
+	case MemberProfileFieldTypeNumber:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+	case MemberProfileFieldTypeBoolean:
+		if boolean, ok := value.(bool); ok {
+			return boolean, nil
+		}
+	case MemberProfileFieldTypeSelect:
+		if option, ok := value.(string); ok {
+			for _, allowed := range f.Options {
+				if option == allowed {
+					return option, nil
+				}
+			}
+		}
+	case MemberProfileFieldTypeDate:
+		if date, ok := value.(string); ok {
+			if _, err := time.Parse(memberProfileFieldDateLayout, date); err == nil {
+				return date, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid value of %s profile field %s", f.Type, f.Key)
+}
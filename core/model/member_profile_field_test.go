@@ -0,0 +1,111 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProfileValuesFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"scalars", map[string]interface{}{"major": "math", "year": 2.0, "alumni": true}, false},
+		{"list of scalars", map[string]interface{}{"major": []interface{}{"math", "physics"}}, false},
+		{"invalid key", map[string]interface{}{"$where": "1"}, true},
+		{"operator object", map[string]interface{}{"major": map[string]interface{}{"$ne": ""}}, true},
+		{"operator object in a list", map[string]interface{}{"major": []interface{}{map[string]interface{}{"$regex": ".*"}}}, true},
+		{"nested list", map[string]interface{}{"major": []interface{}{[]interface{}{"math"}}}, true},
+		{"null", map[string]interface{}{"major": nil}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateProfileValuesFilter(tt.values); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProfileValuesFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemberProfileFieldNormalizeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   MemberProfileField
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"no value", MemberProfileField{Type: MemberProfileFieldTypeText}, nil, nil, false},
+		{"text", MemberProfileField{Type: MemberProfileFieldTypeText}, "math", "math", false},
+		{"long text", MemberProfileField{Type: MemberProfileFieldTypeText}, strings.Repeat("a", MaxMemberProfileFieldValueLength+1), nil, true},
+		{"number", MemberProfileField{Type: MemberProfileFieldTypeNumber}, 2.5, 2.5, false},
+		{"number as text", MemberProfileField{Type: MemberProfileFieldTypeNumber}, "2", nil, true},
+		{"boolean", MemberProfileField{Type: MemberProfileFieldTypeBoolean}, true, true, false},
+		{"select option", MemberProfileField{Type: MemberProfileFieldTypeSelect, Options: []string{"a", "b"}}, "b", "b", false},
+		{"select other", MemberProfileField{Type: MemberProfileFieldTypeSelect, Options: []string{"a", "b"}}, "c", nil, true},
+		{"date", MemberProfileField{Type: MemberProfileFieldTypeDate}, "2024-03-10", "2024-03-10", false},
+		{"invalid date", MemberProfileField{Type: MemberProfileFieldTypeDate}, "03/10/2024", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.NormalizeValue(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMemberProfileFields(t *testing.T) {
+	valid := MemberProfileField{Key: "major", Label: "Major", Type: MemberProfileFieldTypeText, Visibility: MemberProfileFieldVisibilityMembers}
+	with := func(update func(field *MemberProfileField)) MemberProfileField {
+		field := valid
+		update(&field)
+		return field
+	}
+
+	tests := []struct {
+		name    string
+		fields  []MemberProfileField
+		wantErr bool
+	}{
+		{"valid", []MemberProfileField{valid}, false},
+		{"select with options", []MemberProfileField{with(func(f *MemberProfileField) { f.Type = MemberProfileFieldTypeSelect; f.Options = []string{"a"} })}, false},
+		{"invalid key", []MemberProfileField{with(func(f *MemberProfileField) { f.Key = "Major" })}, true},
+		{"duplicate key", []MemberProfileField{valid, valid}, true},
+		{"missing label", []MemberProfileField{with(func(f *MemberProfileField) { f.Label = "" })}, true},
+		{"select without options", []MemberProfileField{with(func(f *MemberProfileField) { f.Type = MemberProfileFieldTypeSelect })}, true},
+		{"text with options", []MemberProfileField{with(func(f *MemberProfileField) { f.Options = []string{"a"} })}, true},
+		{"invalid type", []MemberProfileField{with(func(f *MemberProfileField) { f.Type = "file" })}, true},
+		{"invalid visibility", []MemberProfileField{with(func(f *MemberProfileField) { f.Visibility = "everybody" })}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMemberProfileFields(tt.fields); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMemberProfileFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
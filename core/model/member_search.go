@@ -0,0 +1,250 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MemberSearchMatchPrefix matches the members whose name words, NetID or email start with the query
+	MemberSearchMatchPrefix = "prefix"
+	// MemberSearchMatchFuzzy matches the prefixes and tolerates typos in the query
+	MemberSearchMatchFuzzy = "fuzzy"
+
+	// MemberSearchSortName sorts by the member name
+	MemberSearchSortName = "name"
+	// MemberSearchSortDateJoined sorts by the membership creation date
+	MemberSearchSortDateJoined = "date_joined"
+	// MemberSearchSortLastActivity sorts by the last time the member has read the group posts. Only the group admins may use it.
+	MemberSearchSortLastActivity = "last_activity"
+
+	// MemberSearchFieldName the member name
+	MemberSearchFieldName = "name"
+	// MemberSearchFieldNetID the member NetID
+	MemberSearchFieldNetID = "net_id"
+	// MemberSearchFieldEmail the member email
+	MemberSearchFieldEmail = "email"
+
+	// DefaultMemberSearchLimit is the default page size of the member search
+	DefaultMemberSearchLimit = 50
+	// MaxMemberSearchLimit is the max page size of the member search
+	MaxMemberSearchLimit = 200
+	// MaxMemberSearchQueryLength is the max length of the member search query
+	MaxMemberSearchQueryLength = 100
+	// FuzzyMemberSearchBatchSize is the number of memberships which the fuzzy search loads at once. It pages through all the candidates.
+	FuzzyMemberSearchBatchSize = 5000
+)
+
+// MemberSearch represents a search of the members of a group
+type MemberSearch struct {
+	Query         string                 `json:"query"`
+	Match         string                 `json:"match"` // prefix or fuzzy. Prefix if not set
+	Statuses      []string               `json:"statuses"`
+	ProfileValues map[string]interface{} `json:"profile_values"` // profile field values by key. A list value matches any of its items
+	LabelIDs      []string               `json:"label_ids"`      // member label ids. Matches the members with any of the labels. Admins only
+	SortBy        string                 `json:"sort_by"`        // name, date_joined or last_activity. Name if not set
+	SortOrder     string                 `json:"sort_order"`     // asc or desc. Asc for the name and desc for the dates if not set
+	Offset        int64                  `json:"offset"`
+	Limit         int64                  `json:"limit"`
+
+	Fields []string `json:"-"` // the member fields which the query is matched against. It is set by the code according to the group settings
+	IDs    []string `json:"-"` // narrows the search to the memberships. It is set by the code
+
+	ExcludedUserIDs []string `json:"-"` // the members who must not match, e.g. because their searched data is protected by FERPA. It is set by the code
+} // @name MemberSearch
+
+// MemberSearchResult represents a page of the member search results
+type MemberSearchResult struct {
+	Items []GroupMembership `json:"items"`
+	Total int64             `json:"total"` // the number of all matching members
+} // @name MemberSearchResult
+
+// Validate checks the search and applies the defaults
+func (s *MemberSearch) Validate(isAdmin bool) error {
+	s.Query = strings.TrimSpace(s.Query)
+	if len(s.Query) > MaxMemberSearchQueryLength {
+		return fmt.Errorf("the query is longer than %d characters", MaxMemberSearchQueryLength)
+	}
+
+	err := ValidateProfileValuesFilter(s.ProfileValues)
+	if err != nil {
+		return err
+	}
+
+	switch s.Match {
+	case "":
+		s.Match = MemberSearchMatchPrefix
+	case MemberSearchMatchPrefix, MemberSearchMatchFuzzy:
+	default:
+		return fmt.Errorf("invalid match %s", s.Match)
+	}
+
+	switch s.SortBy {
+	case "":
+		s.SortBy = MemberSearchSortName
+	case MemberSearchSortName, MemberSearchSortDateJoined:
+	case MemberSearchSortLastActivity:
+		if !isAdmin {
+			return fmt.Errorf("only group admins can sort by the last activity")
+		}
+	default:
+		return fmt.Errorf("invalid sort %s", s.SortBy)
+	}
+
+	switch s.SortOrder {
+	case "":
+		if s.SortBy == MemberSearchSortName {
+			s.SortOrder = "asc"
+		} else {
+			s.SortOrder = "desc"
+		}
+	case "asc", "desc":
+	default:
+		return fmt.Errorf("invalid sort order %s", s.SortOrder)
+	}
+
+	for _, status := range s.Statuses {
+		if !IsValidMembershipStatus(status) {
+			return fmt.Errorf("invalid status %s", status)
+		}
+	}
+	if len(s.LabelIDs) > 0 && !isAdmin {
+		return fmt.Errorf("only group admins can search by the member labels")
+	}
+
+	if s.Offset < 0 {
+		s.Offset = 0
+	}
+	if s.Limit <= 0 {
+		s.Limit = DefaultMemberSearchLimit
+	}
+	s.Limit = min(s.Limit, MaxMemberSearchLimit)
+	return nil
+}
+
+// SetSearchFields sets the lower-cased copies of the fields which the member search query is matched against. It must be called whenever the name, the NetID or the email is stored.
+func (m *GroupMembership) SetSearchFields() {
+	m.SearchName = MemberSearchName(m.Name)
+	m.SearchNetID = strings.ToLower(m.NetID)
+	m.SearchEmail = strings.ToLower(m.Email)
+}
+
+// MemberSearchName gives the lower-cased name from the start of every word so that a query matches any word of the name as a prefix
+func MemberSearchName(name string) []string {
+	name = strings.ToLower(name)
+	var result []string
+	wordStart := true
+	for i, r := range name {
+		if isMemberNameSeparator(r) {
+			wordStart = true
+			continue
+		}
+		if wordStart {
+			result = append(result, name[i:])
+			wordStart = false
+		}
+	}
+	return result
+}
+
+func isMemberNameSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '-' || r == ','
+}
+
+// MemberSearchTokens gives the lowercase words of the member fields which the query is matched against
+func MemberSearchTokens(membership GroupMembership, fields []string) []string {
+	var tokens []string
+	for _, field := range fields {
+		switch field {
+		case MemberSearchFieldName:
+			tokens = append(tokens, strings.FieldsFunc(strings.ToLower(membership.Name), isMemberNameSeparator)...)
+		case MemberSearchFieldNetID:
+			if len(membership.NetID) > 0 {
+				tokens = append(tokens, strings.ToLower(membership.NetID))
+			}
+		case MemberSearchFieldEmail:
+			if len(membership.Email) > 0 {
+				email := strings.ToLower(membership.Email)
+				tokens = append(tokens, email)
+				if at := strings.Index(email, "@"); at > 0 {
+					tokens = append(tokens, email[:at])
+				}
+			}
+		}
+	}
+	return tokens
+}
+
+// MatchesMemberSearch says if any token starts with the query. The fuzzy match tolerates one typo in queries of 3 to 5 characters and two typos in longer ones.
+func MatchesMemberSearch(query string, tokens []string, fuzzy bool) bool {
+	query = strings.ToLower(query)
+	queryRunes := []rune(query)
+	allowedTypos := 0
+	if fuzzy {
+		if len(queryRunes) >= 6 {
+			allowedTypos = 2
+		} else if len(queryRunes) >= 3 {
+			allowedTypos = 1
+		}
+	}
+
+	for _, token := range tokens {
+		if strings.HasPrefix(token, query) {
+			return true
+		}
+		if allowedTypos == 0 {
+			continue
+		}
+		// compare with the token prefixes around the query length so that both the typos and the prefixes match
+		tokenRunes := []rune(token)
+		for length := len(queryRunes) - allowedTypos; length <= len(queryRunes)+allowedTypos; length++ {
+			if length <= 0 || length > len(tokenRunes) {
+				continue
+			}
+			if editDistance(queryRunes, tokenRunes[:length]) <= allowedTypos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// editDistance gives the optimal string alignment distance of the two strings. A swap of two adjacent characters counts as one typo.
+func editDistance(a []rune, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemberSearchName(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"John Smith", []string{"john smith", "smith"}},
+		{"Mary-Jane  O'Neil", []string{"mary-jane  o'neil", "jane  o'neil", "o'neil"}},
+		{"Smith, John", []string{"smith, john", "john"}},
+		{" Élodie", []string{"élodie"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MemberSearchName(tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MemberSearchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
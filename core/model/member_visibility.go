@@ -0,0 +1,286 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+const (
+	// MemberViewerRoleSelf the viewer is the member
+	MemberViewerRoleSelf = "self"
+	// MemberViewerRoleMember the viewer is a member of the group
+	MemberViewerRoleMember = "member"
+	// MemberViewerRoleAdmin the viewer is an admin of the group
+	MemberViewerRoleAdmin = "admin"
+	// MemberViewerRolePublic the viewer is not a member of the group. Pending and rejected users are not members.
+	MemberViewerRolePublic = "public"
+	// MemberViewerRoleSystemAdmin the viewer is a system admin using the admin APIs
+	MemberViewerRoleSystemAdmin = "system_admin"
+	// MemberViewerRoleService the viewer is the service itself or a service using the BBs or analytics APIs
+	MemberViewerRoleService = "service"
+	// MemberViewerRoleInternal the viewer is a service using the internal APIs. It gets the identity fields which the group settings allow and no personal fields.
+	MemberViewerRoleInternal = "internal"
+	// MemberViewerRoleUser the viewer is a user whose role is resolved for every group
+	MemberViewerRoleUser = "user"
+)
+
+const (
+	// MemberFieldSensitivityBasic the field is visible to everybody who may see the membership
+	MemberFieldSensitivityBasic = "basic"
+	// MemberFieldSensitivityIdentity the field identifies the member. The group settings say if the members may see it.
+	MemberFieldSensitivityIdentity = "identity"
+	// MemberFieldSensitivityModeration the field is visible to the member and the group admins
+	MemberFieldSensitivityModeration = "moderation"
+	// MemberFieldSensitivityPersonal the field is visible to the member only
+	MemberFieldSensitivityPersonal = "personal"
+	// MemberFieldSensitivityInternal the field is used by the system only
+	MemberFieldSensitivityInternal = "internal"
+)
+
+// MemberViewer represents who receives the member data
+type MemberViewer struct {
+	Role   string
+	UserID string
+}
+
+// NewUserMemberViewer creates a viewer whose role is resolved for every group by their membership
+func NewUserMemberViewer(user *User) MemberViewer {
+	if user == nil || user.IsAnonymous {
+		return MemberViewer{Role: MemberViewerRolePublic}
+	}
+	return MemberViewer{Role: MemberViewerRoleUser, UserID: user.ID}
+}
+
+// NewSystemAdminMemberViewer creates a system admin viewer
+func NewSystemAdminMemberViewer(user *User) MemberViewer {
+	viewer := MemberViewer{Role: MemberViewerRoleSystemAdmin}
+	if user != nil {
+		viewer.UserID = user.ID
+	}
+	return viewer
+}
+
+// NewServiceMemberViewer creates a service viewer
+func NewServiceMemberViewer() MemberViewer {
+	return MemberViewer{Role: MemberViewerRoleService}
+}
+
+// NewInternalMemberViewer creates an internal APIs viewer
+func NewInternalMemberViewer() MemberViewer {
+	return MemberViewer{Role: MemberViewerRoleInternal}
+}
+
+// ForGroup resolves the role of a user viewer by their membership in the group. Nil membership means the user is not a member.
+func (v MemberViewer) ForGroup(membership *GroupMembership) MemberViewer {
+	if v.Role != MemberViewerRoleUser {
+		return v
+	}
+	role := MemberViewerRolePublic
+	if membership != nil && membership.IsAdmin() {
+		role = MemberViewerRoleAdmin
+	} else if membership != nil && membership.IsMember() {
+		role = MemberViewerRoleMember
+	}
+	return MemberViewer{Role: role, UserID: v.UserID}
+}
+
+// memberField describes how a membership field is protected
+type memberField struct {
+	name        string
+	sensitivity string
+	setting     func(preferences MemberInfoPreferences) bool // the group setting which allows the members to see an identity field
+	clear       func(membership *GroupMembership)
+}
+
+var memberFields = []memberField{
+	{name: "id", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.ID = "" }},
+	{name: "client_id", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.ClientID = "" }},
+	{name: "group_id", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.GroupID = "" }},
+	{name: "user_id", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.UserID = "" }},
+	{name: "status", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.Status = "" }},
+	{name: "photo_url", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.PhotoURL = "" }},
+	{name: "date_created", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.DateCreated = time.Time{} }},
+	{name: "date_updated", sensitivity: MemberFieldSensitivityBasic, clear: func(m *GroupMembership) { m.DateUpdated = nil }},
+	{name: "name", sensitivity: MemberFieldSensitivityIdentity, clear: func(m *GroupMembership) { m.Name = "" },
+		setting: func(p MemberInfoPreferences) bool { return p.CanViewMemberName }},
+	{name: "net_id", sensitivity: MemberFieldSensitivityIdentity, clear: func(m *GroupMembership) { m.NetID = "" },
+		setting: func(p MemberInfoPreferences) bool { return p.CanViewMemberNetID }},
+	{name: "email", sensitivity: MemberFieldSensitivityIdentity, clear: func(m *GroupMembership) { m.Email = "" },
+		setting: func(p MemberInfoPreferences) bool { return p.CanViewMemberEmail }},
+	// the memberships do not keep the phone yet. The field is listed so that CanViewMemberPhone governs it once they do.
+	{name: "phone", sensitivity: MemberFieldSensitivityIdentity, clear: func(m *GroupMembership) {},
+		setting: func(p MemberInfoPreferences) bool { return p.CanViewMemberPhone }},
+	{name: "member_answers", sensitivity: MemberFieldSensitivityModeration, clear: func(m *GroupMembership) { m.MemberAnswers = nil }},
+	{name: "reject_reason", sensitivity: MemberFieldSensitivityModeration, clear: func(m *GroupMembership) { m.RejectReason = "" }},
+	{name: "date_attended", sensitivity: MemberFieldSensitivityModeration, clear: func(m *GroupMembership) { m.DateAttended = nil }},
+	{name: "label_ids", sensitivity: MemberFieldSensitivityModeration, clear: func(m *GroupMembership) { m.LabelIDs = nil }},
+	{name: "external_id", sensitivity: MemberFieldSensitivityPersonal, clear: func(m *GroupMembership) { m.ExternalID = "" }},
+	{name: "notifications_preferences", sensitivity: MemberFieldSensitivityPersonal, clear: func(m *GroupMembership) { m.NotificationsPreferences = NotificationsPreferences{} }},
+	{name: "date_last_read", sensitivity: MemberFieldSensitivityPersonal, clear: func(m *GroupMembership) { m.DateLastRead = nil }},
+	{name: "date_digested", sensitivity: MemberFieldSensitivityPersonal, clear: func(m *GroupMembership) { m.DateDigested = nil }},
+	{name: "sync_id", sensitivity: MemberFieldSensitivityInternal, clear: func(m *GroupMembership) { m.SyncID = "" }},
+}
+
+// MemberVisibilityPolicy decides which membership fields a viewer may see. It is the only place where the member data is redacted.
+type MemberVisibilityPolicy struct {
+	preferences   MemberInfoPreferences
+	profileFields []MemberProfileField
+	ferpaUserIDs  map[string]bool // the members whose data is protected by FERPA
+}
+
+// NewMemberVisibilityPolicy creates the policy of a group. Nil group means the default settings and no profile fields.
+func NewMemberVisibilityPolicy(group *Group, ferpaUserIDs []string) MemberVisibilityPolicy {
+	settings := DefaultGroupSettings()
+	var profileFields []MemberProfileField
+	if group != nil {
+		if group.Settings != nil {
+			settings = *group.Settings
+		}
+		profileFields = group.ProfileFields
+	}
+
+	ferpa := map[string]bool{}
+	for _, userID := range ferpaUserIDs {
+		ferpa[userID] = true
+	}
+	return MemberVisibilityPolicy{preferences: settings.MemberInfoPreferences, profileFields: profileFields, ferpaUserIDs: ferpa}
+}
+
+// roleFor gives the role of the viewer for the membership. The viewer must be resolved for the group of the membership.
+func (p MemberVisibilityPolicy) roleFor(viewer MemberViewer, membership *GroupMembership) string {
+	if len(viewer.UserID) > 0 && viewer.UserID == membership.UserID {
+		return MemberViewerRoleSelf
+	}
+	if viewer.Role == MemberViewerRoleUser {
+		return MemberViewerRolePublic
+	}
+	return viewer.Role
+}
+
+// canView says if the role may see a field of the given sensitivity
+func (p MemberVisibilityPolicy) canView(role string, field memberField, ferpa bool) bool {
+	if ferpa && role != MemberViewerRoleSelf {
+		// FERPA protected members are exposed to the users only by their user id
+		if role == MemberViewerRoleSystemAdmin || role == MemberViewerRoleService || role == MemberViewerRoleInternal {
+			return field.sensitivity == MemberFieldSensitivityBasic
+		}
+		return field.name == "user_id"
+	}
+
+	switch role {
+	case MemberViewerRoleSystemAdmin, MemberViewerRoleService:
+		return true
+	case MemberViewerRoleSelf:
+		return field.sensitivity != MemberFieldSensitivityInternal
+	case MemberViewerRoleAdmin:
+		return field.sensitivity == MemberFieldSensitivityBasic || field.sensitivity == MemberFieldSensitivityIdentity ||
+			field.sensitivity == MemberFieldSensitivityModeration
+	case MemberViewerRoleInternal:
+		if field.sensitivity == MemberFieldSensitivityBasic || field.sensitivity == MemberFieldSensitivityModeration {
+			return true
+		}
+		return field.sensitivity == MemberFieldSensitivityIdentity && field.setting(p.preferences)
+	default:
+		if field.sensitivity == MemberFieldSensitivityBasic {
+			return true
+		}
+		return field.sensitivity == MemberFieldSensitivityIdentity && field.setting(p.preferences)
+	}
+}
+
+// canViewProfileField says if the role may see the values of a profile field
+func (p MemberVisibilityPolicy) canViewProfileField(role string, field MemberProfileField) bool {
+	switch role {
+	case MemberViewerRoleSelf, MemberViewerRoleAdmin, MemberViewerRoleSystemAdmin, MemberViewerRoleService, MemberViewerRoleInternal:
+		return true
+	case MemberViewerRoleMember:
+		return field.Visibility == MemberProfileFieldVisibilityMembers || field.Visibility == MemberProfileFieldVisibilityPublic
+	default:
+		return field.Visibility == MemberProfileFieldVisibilityPublic
+	}
+}
+
+// Apply removes the fields of the membership which the viewer may not see. The viewer must be resolved for the group of the membership.
+func (p MemberVisibilityPolicy) Apply(viewer MemberViewer, membership *GroupMembership) {
+	role := p.roleFor(viewer, membership)
+	ferpa := p.ferpaUserIDs[membership.UserID]
+	for _, field := range memberFields {
+		if !p.canView(role, field, ferpa) {
+			field.clear(membership)
+		}
+	}
+
+	if len(membership.ProfileValues) == 0 {
+		return
+	}
+	values := map[string]interface{}{}
+	if !ferpa || role == MemberViewerRoleSelf {
+		for key, value := range membership.ProfileValues {
+			field := FindMemberProfileField(p.profileFields, key)
+			if field != nil && p.canViewProfileField(role, *field) {
+				values[key] = value
+			}
+		}
+	}
+	membership.ProfileValues = values
+}
+
+// ApplyToMemberships removes the fields of the memberships which the viewer may not see
+func (p MemberVisibilityPolicy) ApplyToMemberships(viewer MemberViewer, memberships []GroupMembership) {
+	for i := range memberships {
+		p.Apply(viewer, &memberships[i])
+	}
+}
+
+// SearchableFields gives the identity fields of the other members which the viewer may search by
+func (p MemberVisibilityPolicy) SearchableFields(viewer MemberViewer) []string {
+	role := viewer.Role
+	if role == MemberViewerRoleUser || role == MemberViewerRoleSelf {
+		role = MemberViewerRolePublic
+	}
+	fields := []string{}
+	for _, field := range memberFields {
+		if field.name == MemberSearchFieldName || field.name == MemberSearchFieldNetID || field.name == MemberSearchFieldEmail {
+			if p.canView(role, field, false) {
+				fields = append(fields, field.name)
+			}
+		}
+	}
+	return fields
+}
+
+// FilterProfileValues keeps only the profile values of the other members which the viewer may see. It is used to sanitize the filters.
+func (p MemberVisibilityPolicy) FilterProfileValues(viewer MemberViewer, values map[string]interface{}) map[string]interface{} {
+	if len(values) == 0 {
+		return values
+	}
+	role := viewer.Role
+	if role == MemberViewerRoleUser || role == MemberViewerRoleSelf {
+		role = MemberViewerRolePublic
+	}
+	result := map[string]interface{}{}
+	for key, value := range values {
+		field := FindMemberProfileField(p.profileFields, key)
+		if field != nil && p.canViewProfileField(role, *field) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// CanFilterByLabels says if the viewer may filter the members by their labels. The labels are visible to the admins only.
+func (p MemberVisibilityPolicy) CanFilterByLabels(viewer MemberViewer) bool {
+	return viewer.Role == MemberViewerRoleAdmin || viewer.Role == MemberViewerRoleSystemAdmin || viewer.Role == MemberViewerRoleService ||
+		viewer.Role == MemberViewerRoleInternal
+}
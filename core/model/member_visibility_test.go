@@ -0,0 +1,189 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testMembership() GroupMembership {
+	return GroupMembership{
+		ID:            "m1",
+		GroupID:       "g1",
+		UserID:        "u1",
+		ExternalID:    "123456789",
+		Name:          "Jane Doe",
+		NetID:         "jdoe",
+		Email:         "jdoe@example.com",
+		Status:        "member",
+		RejectReason:  "reason",
+		LabelIDs:      []string{"l1"},
+		SyncID:        "sync",
+		ProfileValues: map[string]interface{}{"major": "math", "year": 2, "phone": "555"},
+
+		NotificationsPreferences: NotificationsPreferences{OverridePreferences: true, AllMute: true},
+	}
+}
+
+func testPolicyGroup(preferences MemberInfoPreferences) *Group {
+	settings := DefaultGroupSettings()
+	settings.MemberInfoPreferences = preferences
+	return &Group{
+		ID:       "g1",
+		Settings: &settings,
+		ProfileFields: []MemberProfileField{
+			{Key: "major", Visibility: MemberProfileFieldVisibilityPublic},
+			{Key: "year", Visibility: MemberProfileFieldVisibilityMembers},
+			{Key: "phone", Visibility: MemberProfileFieldVisibilityAdmins},
+		},
+	}
+}
+
+func TestMemberVisibilityPolicyApply(t *testing.T) {
+	allowAll := DefaultGroupSettings().MemberInfoPreferences
+	namesOnly := MemberInfoPreferences{AllowMemberInfo: true, CanViewMemberName: true}
+
+	type visible struct {
+		name, netID, email, externalID, rejectReason, syncID bool
+		labels, notifications                                bool
+		profileKeys                                          []string
+	}
+	tests := []struct {
+		name        string
+		preferences MemberInfoPreferences
+		ferpa       bool
+		viewer      MemberViewer
+		want        visible
+	}{
+		{"self", allowAll, false, MemberViewer{Role: MemberViewerRoleMember, UserID: "u1"},
+			visible{name: true, netID: true, email: true, externalID: true, rejectReason: true, labels: true, notifications: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"admin", allowAll, false, MemberViewer{Role: MemberViewerRoleAdmin, UserID: "u2"},
+			visible{name: true, netID: true, email: true, rejectReason: true, labels: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"member with all allowed", allowAll, false, MemberViewer{Role: MemberViewerRoleMember, UserID: "u2"},
+			visible{name: true, netID: true, email: true, profileKeys: []string{"major", "year"}}},
+		{"member with names only", namesOnly, false, MemberViewer{Role: MemberViewerRoleMember, UserID: "u2"},
+			visible{name: true, profileKeys: []string{"major", "year"}}},
+		{"member with member info disabled follows the field settings", MemberInfoPreferences{CanViewMemberName: true}, false, MemberViewer{Role: MemberViewerRoleMember, UserID: "u2"},
+			visible{name: true, profileKeys: []string{"major", "year"}}},
+		{"public", allowAll, false, MemberViewer{Role: MemberViewerRolePublic},
+			visible{name: true, netID: true, email: true, profileKeys: []string{"major"}}},
+		{"unresolved user is public", allowAll, false, MemberViewer{Role: MemberViewerRoleUser, UserID: "u2"},
+			visible{name: true, netID: true, email: true, profileKeys: []string{"major"}}},
+		{"system admin", allowAll, false, MemberViewer{Role: MemberViewerRoleSystemAdmin, UserID: "u2"},
+			visible{name: true, netID: true, email: true, externalID: true, rejectReason: true, syncID: true, labels: true, notifications: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"service", namesOnly, false, NewServiceMemberViewer(),
+			visible{name: true, netID: true, email: true, externalID: true, rejectReason: true, syncID: true, labels: true, notifications: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"internal with all allowed", allowAll, false, NewInternalMemberViewer(),
+			visible{name: true, netID: true, email: true, rejectReason: true, labels: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"internal with names only", namesOnly, false, NewInternalMemberViewer(),
+			visible{name: true, rejectReason: true, labels: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"ferpa self", allowAll, true, MemberViewer{Role: MemberViewerRoleMember, UserID: "u1"},
+			visible{name: true, netID: true, email: true, externalID: true, rejectReason: true, labels: true, notifications: true, profileKeys: []string{"major", "phone", "year"}}},
+		{"ferpa admin", allowAll, true, MemberViewer{Role: MemberViewerRoleAdmin, UserID: "u2"},
+			visible{}},
+		{"ferpa member", allowAll, true, MemberViewer{Role: MemberViewerRoleMember, UserID: "u2"},
+			visible{}},
+		{"ferpa service", allowAll, true, NewServiceMemberViewer(),
+			visible{}},
+		{"ferpa internal", allowAll, true, NewInternalMemberViewer(),
+			visible{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ferpaUserIDs []string
+			if tt.ferpa {
+				ferpaUserIDs = []string{"u1"}
+			}
+			policy := NewMemberVisibilityPolicy(testPolicyGroup(tt.preferences), ferpaUserIDs)
+			membership := testMembership()
+			policy.Apply(tt.viewer, &membership)
+
+			if membership.UserID != "u1" {
+				t.Errorf("user_id must be always visible, got %q", membership.UserID)
+			}
+			got := visible{
+				name:         membership.Name != "",
+				netID:        membership.NetID != "",
+				email:        membership.Email != "",
+				externalID:   membership.ExternalID != "",
+				rejectReason: membership.RejectReason != "",
+				syncID:       membership.SyncID != "",
+				labels:       len(membership.LabelIDs) > 0,
+
+				notifications: membership.NotificationsPreferences != NotificationsPreferences{},
+			}
+			for _, key := range []string{"major", "phone", "year"} {
+				if _, ok := membership.ProfileValues[key]; ok {
+					got.profileKeys = append(got.profileKeys, key)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() visible = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberViewerForGroup(t *testing.T) {
+	user := NewUserMemberViewer(&User{ID: "u1"})
+	tests := []struct {
+		name       string
+		viewer     MemberViewer
+		membership *GroupMembership
+		want       string
+	}{
+		{"not a member", user, nil, MemberViewerRolePublic},
+		{"pending", user, &GroupMembership{Status: "pending"}, MemberViewerRolePublic},
+		{"rejected", user, &GroupMembership{Status: "rejected"}, MemberViewerRolePublic},
+		{"member", user, &GroupMembership{Status: "member"}, MemberViewerRoleMember},
+		{"admin", user, &GroupMembership{Status: "admin"}, MemberViewerRoleAdmin},
+		{"anonymous", NewUserMemberViewer(&User{ID: "u1", IsAnonymous: true}), &GroupMembership{Status: "admin"}, MemberViewerRolePublic},
+		{"service is kept", NewServiceMemberViewer(), nil, MemberViewerRoleService},
+		{"internal is kept", NewInternalMemberViewer(), &GroupMembership{Status: "admin"}, MemberViewerRoleInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.ForGroup(tt.membership).Role; got != tt.want {
+				t.Errorf("ForGroup() role = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberVisibilityPolicySearchableFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences MemberInfoPreferences
+		viewer      MemberViewer
+		want        []string
+	}{
+		{"admin", MemberInfoPreferences{}, MemberViewer{Role: MemberViewerRoleAdmin}, []string{"name", "net_id", "email"}},
+		{"member with all allowed", DefaultGroupSettings().MemberInfoPreferences, MemberViewer{Role: MemberViewerRoleMember}, []string{"name", "net_id", "email"}},
+		{"member with names only", MemberInfoPreferences{AllowMemberInfo: true, CanViewMemberName: true}, MemberViewer{Role: MemberViewerRoleMember}, []string{"name"}},
+		{"member with member info disabled follows the field settings", MemberInfoPreferences{CanViewMemberName: true}, MemberViewer{Role: MemberViewerRoleMember}, []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewMemberVisibilityPolicy(testPolicyGroup(tt.preferences), nil)
+			if got := policy.SearchableFields(tt.viewer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchableFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
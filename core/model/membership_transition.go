@@ -0,0 +1,98 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Membership statuses
+const (
+	MembershipStatusPending  string = "pending"
+	MembershipStatusMember   string = "member"
+	MembershipStatusAdmin    string = "admin"
+	MembershipStatusRejected string = "rejected"
+)
+
+// Membership status transition sources
+const (
+	MembershipTransitionSourceRequest string = "request" // the user requests to join the group
+	MembershipTransitionSourceAdmin   string = "admin"   // a group admin or a system admin changes the membership
+	MembershipTransitionSourceSync    string = "sync"    // the Authman synchronization of the managed groups
+)
+
+// allowedMembershipTransitions contains the allowed target statuses for every status. The empty status means a new membership.
+var allowedMembershipTransitions = map[string][]string{
+	"":                       {MembershipStatusPending, MembershipStatusMember, MembershipStatusAdmin},
+	MembershipStatusPending:  {MembershipStatusMember, MembershipStatusRejected},
+	MembershipStatusMember:   {MembershipStatusAdmin},
+	MembershipStatusAdmin:    {MembershipStatusMember},
+	MembershipStatusRejected: {MembershipStatusPending}, // on a new request only
+}
+
+// MembershipTransition represents a change of the membership status
+type MembershipTransition struct {
+	FromStatus string    `json:"from_status" bson:"from_status"` // empty for a new membership
+	ToStatus   string    `json:"to_status" bson:"to_status"`
+	Source     string    `json:"source" bson:"source"`     // request, admin or sync
+	ActorID    string    `json:"actor_id" bson:"actor_id"` // empty for the system
+	ActorName  string    `json:"actor_name" bson:"actor_name"`
+	Reason     string    `json:"reason" bson:"reason"`
+	Date       time.Time `json:"date" bson:"date"`
+} //@name MembershipTransition
+
+// IsValidMembershipStatus checks if the membership status is valid
+func IsValidMembershipStatus(status string) bool {
+	return status == MembershipStatusPending || status == MembershipStatusMember || status == MembershipStatusAdmin || status == MembershipStatusRejected
+}
+
+// ValidateMembershipTransition checks if the membership status may change from one status to another.
+// The Authman synchronization is the source of truth for the managed groups so it may make anybody a member or an admin.
+func ValidateMembershipTransition(fromStatus string, toStatus string, source string) error {
+	if !IsValidMembershipStatus(toStatus) {
+		return fmt.Errorf("invalid membership status %s", toStatus)
+	}
+	if source == MembershipTransitionSourceSync && (toStatus == MembershipStatusMember || toStatus == MembershipStatusAdmin) {
+		return nil
+	}
+	if fromStatus == MembershipStatusRejected && source != MembershipTransitionSourceRequest {
+		return fmt.Errorf("a rejected membership may become pending only on a new request")
+	}
+	for _, status := range allowedMembershipTransitions[fromStatus] {
+		if status == toStatus {
+			return nil
+		}
+	}
+	if len(fromStatus) == 0 {
+		return fmt.Errorf("a new membership cannot be %s", toStatus)
+	}
+	return fmt.Errorf("the membership status cannot change from %s to %s", fromStatus, toStatus)
+}
+
+// NewMembershipTransition validates and constructs a membership status transition. The actor is nil for the system.
+func NewMembershipTransition(fromStatus string, toStatus string, source string, actor *User, reason string) (*MembershipTransition, error) {
+	err := ValidateMembershipTransition(fromStatus, toStatus, source)
+	if err != nil {
+		return nil, err
+	}
+
+	transition := MembershipTransition{FromStatus: fromStatus, ToStatus: toStatus, Source: source, Reason: reason, Date: time.Now().UTC()}
+	if actor != nil {
+		transition.ActorID = actor.ID
+		transition.ActorName = actor.Name
+	}
+	return &transition, nil
+}
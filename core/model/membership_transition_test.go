@@ -0,0 +1,80 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "testing"
+
+func TestValidateMembershipTransition(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		source  string
+		wantErr bool
+	}{
+		{"", MembershipStatusPending, MembershipTransitionSourceRequest, false},
+		{"", MembershipStatusMember, MembershipTransitionSourceAdmin, false},
+		{"", MembershipStatusAdmin, MembershipTransitionSourceAdmin, false},
+		{"", MembershipStatusRejected, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusPending, MembershipStatusMember, MembershipTransitionSourceAdmin, false},
+		{MembershipStatusPending, MembershipStatusRejected, MembershipTransitionSourceAdmin, false},
+		{MembershipStatusPending, MembershipStatusAdmin, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusMember, MembershipStatusAdmin, MembershipTransitionSourceAdmin, false},
+		{MembershipStatusMember, MembershipStatusPending, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusMember, MembershipStatusRejected, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusAdmin, MembershipStatusMember, MembershipTransitionSourceAdmin, false},
+		{MembershipStatusRejected, MembershipStatusPending, MembershipTransitionSourceRequest, false},
+		{MembershipStatusRejected, MembershipStatusPending, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusRejected, MembershipStatusMember, MembershipTransitionSourceAdmin, true},
+		{MembershipStatusRejected, MembershipStatusMember, MembershipTransitionSourceSync, false},
+		{MembershipStatusPending, MembershipStatusAdmin, MembershipTransitionSourceSync, false},
+		{MembershipStatusMember, MembershipStatusRejected, MembershipTransitionSourceSync, true},
+		{MembershipStatusMember, "banned", MembershipTransitionSourceAdmin, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to+" by "+tt.source, func(t *testing.T) {
+			if err := ValidateMembershipTransition(tt.from, tt.to, tt.source); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMembershipTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewMembershipTransition(t *testing.T) {
+	tests := []struct {
+		name          string
+		actor         *User
+		wantActorID   string
+		wantActorName string
+	}{
+		{"user", &User{ID: "u1", Name: "Jane Doe"}, "u1", "Jane Doe"},
+		{"system", nil, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition, err := NewMembershipTransition(MembershipStatusPending, MembershipStatusMember, MembershipTransitionSourceAdmin, tt.actor, "welcome")
+			if err != nil {
+				t.Fatalf("NewMembershipTransition() error = %v", err)
+			}
+			if transition.ActorID != tt.wantActorID || transition.ActorName != tt.wantActorName {
+				t.Errorf("NewMembershipTransition() actor = %s %s, want %s %s", transition.ActorID, transition.ActorName, tt.wantActorID, tt.wantActorName)
+			}
+			if transition.Reason != "welcome" || transition.Date.IsZero() {
+				t.Errorf("NewMembershipTransition() reason = %s, date = %v", transition.Reason, transition.Date)
+			}
+		})
+	}
+}
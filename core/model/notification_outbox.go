@@ -0,0 +1,72 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"groups/driven/notifications"
+	"time"
+)
+
+const (
+	// OutboxStatusPending the notification waits for delivery or for a retry
+	OutboxStatusPending = "pending"
+	// OutboxStatusSent the notification has been delivered
+	OutboxStatusSent = "sent"
+	// OutboxStatusDead the notification delivery has failed too many times. It may be replayed by an admin
+	OutboxStatusDead = "dead"
+)
+
+// OutboxMaxAttempts is the count of the failed delivery attempts after which the notification is dead-lettered
+const OutboxMaxAttempts = 8
+
+// OutboxNotification represents a notification intent which is delivered by the notification outbox worker
+type OutboxNotification struct {
+	ID            string                    `json:"id" bson:"_id"`
+	ClientID      string                    `json:"client_id" bson:"client_id"`
+	Recipients    []notifications.Recipient `json:"recipients" bson:"recipients"`
+	Topic         *string                   `json:"topic" bson:"topic"`
+	Title         string                    `json:"title" bson:"title"`
+	Text          string                    `json:"text" bson:"text"`
+	Data          map[string]string         `json:"data" bson:"data"`
+	AppID         string                    `json:"app_id" bson:"app_id"`
+	OrgID         string                    `json:"org_id" bson:"org_id"`
+	DateScheduled *time.Time                `json:"date_scheduled" bson:"date_scheduled"`
+	Priority      int                       `json:"priority" bson:"priority"`
+
+	Status          string     `json:"status" bson:"status"` // pending, sent or dead
+	Attempts        int        `json:"attempts" bson:"attempts"`
+	LastError       string     `json:"last_error" bson:"last_error"`
+	DateNextAttempt time.Time  `json:"date_next_attempt" bson:"date_next_attempt"`
+	DateSent        *time.Time `json:"date_sent" bson:"date_sent"`
+	DateCreated     time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated     *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name OutboxNotification
+
+// GetRetryDelay returns the exponential backoff delay after the provided count of failed attempts
+func GetRetryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < 6*time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > 6*time.Hour {
+		delay = 6 * time.Hour
+	}
+	return delay
+}
+
+// IsValidOutboxStatus checks if the outbox status is supported
+func IsValidOutboxStatus(status string) bool {
+	return status == OutboxStatusPending || status == OutboxStatusSent || status == OutboxStatusDead
+}
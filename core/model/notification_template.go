@@ -0,0 +1,227 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// NotificationOperationResearchGroup a new research project matches the user profile
+	NotificationOperationResearchGroup = "research_group"
+	// NotificationOperationMembershipApprove the membership request has been approved
+	NotificationOperationMembershipApprove = "membership_approve"
+	// NotificationOperationMembershipReject the membership request has been rejected
+	NotificationOperationMembershipReject = "membership_reject"
+	// NotificationOperationPendingMember a user requested a membership or joined the group
+	NotificationOperationPendingMember = "pending_member"
+	// NotificationOperationPostCreated a new post or reply has been published
+	NotificationOperationPostCreated = "post_created"
+	// NotificationOperationPostAnnouncement a post has been announced
+	NotificationOperationPostAnnouncement = "post_announcement"
+	// NotificationOperationPostPendingReview a post waits for a review
+	NotificationOperationPostPendingReview = "post_pending_review"
+	// NotificationOperationPostApproved a post has been approved
+	NotificationOperationPostApproved = "post_approved"
+	// NotificationOperationPostRejected a post has been rejected
+	NotificationOperationPostRejected = "post_rejected"
+	// NotificationOperationReportAbusePost a post has been reported to the group admins
+	NotificationOperationReportAbusePost = "report_abuse_post"
+	// NotificationOperationEventCreated a new event has been published
+	NotificationOperationEventCreated = "event_created"
+)
+
+// MaxNotificationLocaleLength is the max length of a notification locale
+const MaxNotificationLocaleLength = 16
+
+const defaultNotificationTitle = "{{.GroupType}} - {{.GroupTitle}}"
+
+// DefaultNotificationTemplates contains the built-in templates which are used when neither the client nor the group has its own one
+var DefaultNotificationTemplates = map[string]NotificationTemplate{
+	NotificationOperationResearchGroup: {
+		Title: "A new research project is available",
+		Body:  "{{.GroupTitle}} by {{.UserName}}",
+	},
+	NotificationOperationMembershipApprove: {
+		Title: defaultNotificationTitle,
+		Body:  "Your membership in '{{.GroupTitle}}' {{.GroupTypeLower}} has been approved",
+	},
+	NotificationOperationMembershipReject: {
+		Title: defaultNotificationTitle,
+		Body:  "Your membership in '{{.GroupTitle}}' {{.GroupTypeLower}} has been rejected with a reason: {{.Reason}}",
+	},
+	NotificationOperationPendingMember: {
+		Title: defaultNotificationTitle,
+		Body: "{{if .Joined}}{{if .UserName}}{{.UserName}} joined{{else}}New membership joined{{end}} '{{.GroupTitle}}' {{.GroupTypeLower}}" +
+			"{{else}}New membership request for '{{.GroupTitle}}' {{.GroupTypeLower}} has been submitted{{end}}",
+	},
+	NotificationOperationPostCreated: {
+		Title: defaultNotificationTitle,
+		Body:  "{{.UserName}} {{.Action}} \"{{.PostBody}}\"",
+	},
+	NotificationOperationPostAnnouncement: {
+		Title: defaultNotificationTitle,
+		Body:  "Announcement: {{.PostSubject}}",
+	},
+	NotificationOperationPostPendingReview: {
+		Title: defaultNotificationTitle,
+		Body:  "{{.UserName}} submitted a post for review",
+	},
+	NotificationOperationPostApproved: {
+		Title: defaultNotificationTitle,
+		Body:  "Your post \"{{.PostSubject}}\" has been approved",
+	},
+	NotificationOperationPostRejected: {
+		Title: defaultNotificationTitle,
+		Body:  "Your post \"{{.PostSubject}}\" has been rejected{{if .Reason}}: {{.Reason}}{{end}}",
+	},
+	NotificationOperationReportAbusePost: {
+		Title: "{{.Subject}}",
+		Body: "\nViolation by: {{.UserExternalID}} {{.PostCreatorName}}\nGroup title: {{.GroupTitle}}\nPost Title: {{.PostSubject}}\n" +
+			"Post Body: {{.PostBody}}\nReported by: {{.UserExternalID}} {{.UserName}}\nReported comment: {{.Comment}}\n\t",
+	},
+	NotificationOperationEventCreated: {
+		Title: defaultNotificationTitle,
+		Body:  "New event has been published in '{{.GroupTitle}}' {{.GroupTypeLower}}",
+	},
+}
+
+// NotificationTemplate represents a client or group specific title and body of a notification operation.
+// Title and Body are Go text/template strings executed with NotificationTemplateData.
+type NotificationTemplate struct {
+	ID          string     `json:"id" bson:"_id"`
+	ClientID    string     `json:"client_id" bson:"client_id"`
+	GroupID     *string    `json:"group_id" bson:"group_id"` // nil means the template applies to all groups of the client
+	Operation   string     `json:"operation" bson:"operation"`
+	Locale      string     `json:"locale" bson:"locale"` // empty means the template applies to all locales
+	Title       string     `json:"title" bson:"title"`
+	Body        string     `json:"body" bson:"body"`
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name NotificationTemplate
+
+// NotificationTemplateData contains the variables available within the notification templates
+type NotificationTemplateData struct {
+	GroupTitle    string
+	GroupType     string // Group or Research Project
+	ResearchGroup bool
+
+	UserName       string // the user who caused the notification
+	UserExternalID string
+	Action         string // posted, replied or messaged you
+
+	PostSubject     string
+	PostBody        string
+	PostCreatorName string
+
+	Reason  string
+	Comment string
+	Subject string
+	Joined  bool
+}
+
+// NewNotificationTemplateData creates the template data for the group
+func NewNotificationTemplateData(group *Group) NotificationTemplateData {
+	data := NotificationTemplateData{GroupType: "Group"}
+	if group != nil {
+		data.GroupTitle = group.Title
+		data.ResearchGroup = group.ResearchGroup
+		if group.ResearchGroup {
+			data.GroupType = "Research Project"
+		}
+	}
+	return data
+}
+
+// GroupTypeLower returns the group type in lower case
+func (d NotificationTemplateData) GroupTypeLower() string {
+	return strings.ToLower(d.GroupType)
+}
+
+// Validate checks if the template is applicable and can be parsed
+func (t *NotificationTemplate) Validate() error {
+	if _, ok := DefaultNotificationTemplates[t.Operation]; !ok {
+		return fmt.Errorf("unsupported notification operation: %s", t.Operation)
+	}
+	if len(t.Locale) > MaxNotificationLocaleLength {
+		return fmt.Errorf("the locale is longer than %d characters", MaxNotificationLocaleLength)
+	}
+	if len(t.Title) == 0 || len(t.Body) == 0 {
+		return fmt.Errorf("the title and the body are required")
+	}
+	_, _, err := t.Render(NotificationTemplateData{})
+	return err
+}
+
+// Render executes the title and the body templates
+func (t *NotificationTemplate) Render(data NotificationTemplateData) (string, string, error) {
+	title, err := executeNotificationTemplate("title", t.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeNotificationTemplate("body", t.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+func executeNotificationTemplate(name string, text string, data NotificationTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing %s template: %s", name, err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, data)
+	if err != nil {
+		return "", fmt.Errorf("error executing %s template: %s", name, err)
+	}
+	return buffer.String(), nil
+}
+
+// SelectNotificationTemplate selects the most specific template for the group and the locale. The group templates take
+// precedence over the client ones and the exact locale takes precedence over the locale independent templates.
+// Falls back to the built-in default template.
+func SelectNotificationTemplate(templates []NotificationTemplate, operation string, groupID *string, locale string) NotificationTemplate {
+	var selected *NotificationTemplate
+	selectedScore := -1
+	for i, item := range templates {
+		if item.Operation != operation || (item.Locale != "" && item.Locale != locale) {
+			continue
+		}
+		score := 0
+		if item.GroupID != nil {
+			if groupID == nil || *item.GroupID != *groupID {
+				continue
+			}
+			score += 2
+		}
+		if item.Locale != "" {
+			score++
+		}
+		if score > selectedScore {
+			selected = &templates[i]
+			selectedScore = score
+		}
+	}
+	if selected != nil {
+		return *selected
+	}
+	return DefaultNotificationTemplates[operation]
+}
@@ -15,10 +15,27 @@
 package model
 
 import (
+	"fmt"
 	"groups/driven/notifications"
+	"sort"
 	"time"
 )
 
+// DefaultMaxPinnedPosts is the max count of pinned posts per group if the group settings don't define another value
+const DefaultMaxPinnedPosts = 3
+
+// ExpiredPostsGracePeriod is the period in which the expired posts are still available for the group admins before they are purged
+const ExpiredPostsGracePeriod = 30 * 24 * time.Hour
+
+const (
+	// PostStatusPendingReview the post waits for a group admin approval and it's visible only for its creator and the group admins
+	PostStatusPendingReview = "pending_review"
+	// PostStatusApproved the post is approved by a group admin
+	PostStatusApproved = "approved"
+	// PostStatusRejected the post is rejected by a group admin and it's visible only for its creator and the group admins
+	PostStatusRejected = "rejected"
+)
+
 // Post represents group posts
 type Post struct {
 	ID                string              `json:"id" bson:"_id"`
@@ -29,14 +46,38 @@ type Post struct {
 	Creator           Creator             `json:"member" bson:"member"`
 	Subject           string              `json:"subject" bson:"subject"`
 	Body              string              `json:"body" bson:"body"`
+	BodyFormat        string              `json:"body_format" bson:"body_format"` // plain or markdown. Empty means plain
+	BodyHTML          string              `json:"body_html" bson:"body_html"`     // the body rendered as safe HTML. This is constructed by the code
 	Private           bool                `json:"private" bson:"private"`
 	UseAsNotification bool                `json:"use_as_notification" bson:"use_as_notification"`
 	IsAbuse           bool                `json:"is_abuse" bson:"is_abuse"`
-	Replies           []Post              `json:"replies,omitempty"` // This is constructed by the code (ParentID)
-	Reactions         map[string][]string `json:"reactions,omitempty" bson:"reactions,omitempty"`
+	Replies           []Post              `json:"replies,omitempty"`                                          // This is constructed by the code (ParentID)
+	RepliesCount      int                 `json:"replies_count" bson:"replies_count"`                         // count of the direct replies
+	Reactions         map[string][]string `json:"-" bson:"reactions,omitempty"`                               // the reacting users for every reaction. It is internal, the clients get reaction_counts, my_reactions and the who-reacted API
+	ReactionCounts    map[string]int      `json:"reaction_counts,omitempty" bson:"reaction_counts,omitempty"` // denormalized count of the users for every reaction
+	MyReactions       []string            `json:"my_reactions,omitempty" bson:"-"`                            // the reactions of the current user. This is constructed by the code
 	ImageURL          *string             `json:"image_url" bson:"image_url"`
 
-	ToMembersList []ToMember `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToMembersList []ToMember `json:"to_members" bson:"to_members"`     // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToLabelIDs    []string   `json:"to_label_ids" bson:"to_label_ids"` // the member labels the post is addressed to in addition to to_members. Resolved whenever the visibility is checked
+
+	Pinned                  bool       `json:"pinned" bson:"pinned"`
+	PinnedBy                *string    `json:"pinned_by" bson:"pinned_by"`
+	DatePinned              *time.Time `json:"date_pinned" bson:"date_pinned"`
+	IsAnnouncement          bool       `json:"is_announcement" bson:"is_announcement"`
+	DateAnnouncementExpires *time.Time `json:"date_announcement_expires" bson:"date_announcement_expires"`
+
+	Status       string     `json:"status" bson:"status"` // empty means published without moderation; pending_review, approved or rejected
+	ReviewedBy   *string    `json:"reviewed_by" bson:"reviewed_by"`
+	RejectReason string     `json:"reject_reason" bson:"reject_reason"`
+	DateReviewed *time.Time `json:"date_reviewed" bson:"date_reviewed"`
+
+	SeriesID *string `json:"series_id" bson:"series_id"` // the recurring post series which has published the post
+
+	OriginID *string `json:"origin_id" bson:"origin_id"` // the cross-posted post from which this copy has been created. The copies follow the edits and the deletion of the origin
+
+	DateExpires *time.Time `json:"date_expires" bson:"date_expires"` // top level posts only. The post and its replies are hidden after this date
+	DateExpired *time.Time `json:"date_expired" bson:"date_expired"` // the date when the post and its replies have been hidden
 
 	DateCreated   time.Time  `json:"date_created" bson:"date_created"`
 	DateUpdated   *time.Time `json:"date_updated" bson:"date_updated"`
@@ -44,14 +85,72 @@ type Post struct {
 	DateNotified  *time.Time `json:"date_notified" bson:"date_notified"`
 }
 
-// UserCanSeePost checks if the user can see the current post or not
-func (p *Post) UserCanSeePost(userID string) bool {
-	if len(p.ToMembersList) > 0 {
+// IsPublished checks if the post is visible for all group members (it's not waiting for review or rejected)
+func (p *Post) IsPublished() bool {
+	return p.Status != PostStatusPendingReview && p.Status != PostStatusRejected
+}
+
+// RenderBody validates the body format and renders the body as safe HTML
+func (p *Post) RenderBody() error {
+	if !IsValidBodyFormat(p.BodyFormat) {
+		return fmt.Errorf("invalid body format %s", p.BodyFormat)
+	}
+	if p.BodyFormat == "" {
+		p.BodyFormat = BodyFormatPlain
+	}
+	p.BodyHTML = RenderBodyHTML(p.BodyFormat, p.Body)
+	return nil
+}
+
+// IsCrossPostCopy checks if the post is a copy of a post cross-posted to several groups
+func (p *Post) IsCrossPostCopy() bool {
+	return p.OriginID != nil
+}
+
+// IsExpired checks if the post has been hidden because of its expiration date
+func (p *Post) IsExpired() bool {
+	return p.DateExpired != nil
+}
+
+// ApplyMyReactions fills the reactions of the user for the post and all of its replies
+func (p *Post) ApplyMyReactions(userID string) {
+	p.MyReactions = nil
+	for reaction, userIDs := range p.Reactions {
+		for _, reactionUserID := range userIDs {
+			if reactionUserID == userID {
+				p.MyReactions = append(p.MyReactions, reaction)
+				break
+			}
+		}
+	}
+	sort.Strings(p.MyReactions)
+
+	for i := range p.Replies {
+		p.Replies[i].ApplyMyReactions(userID)
+	}
+}
+
+// IsActiveAnnouncement checks if the post is an announcement which is not expired yet
+func (p *Post) IsActiveAnnouncement() bool {
+	return p.IsAnnouncement && (p.DateAnnouncementExpires == nil || p.DateAnnouncementExpires.After(time.Now()))
+}
+
+// IsTargeted checks if the post is addressed to specific members or member labels instead of everyone
+func (p *Post) IsTargeted() bool {
+	return len(p.ToMembersList) > 0 || len(p.ToLabelIDs) > 0
+}
+
+// UserCanSeePost checks if the user can see the current post or not. labelIDs are the member labels of the user within the group of the post
+func (p *Post) UserCanSeePost(userID string, labelIDs []string) bool {
+	if p.IsTargeted() {
 		for _, member := range p.ToMembersList {
 			if member.UserID == userID {
 				return true
 			}
 		}
+		if HasAnyMemberLabel(labelIDs, p.ToLabelIDs) {
+			return true
+		}
 
 		return p.Creator.UserID == userID
 	}
@@ -87,3 +186,14 @@ func (p Post) GetMembersAsUserIDs(skipUserID *string) []string {
 	}
 	return recipients
 }
+
+// PostSeen represents a record that a group member has seen a post
+type PostSeen struct {
+	ID       string    `json:"id" bson:"_id"`
+	ClientID string    `json:"client_id" bson:"client_id"`
+	GroupID  string    `json:"group_id" bson:"group_id"`
+	PostID   string    `json:"post_id" bson:"post_id"`
+	UserID   string    `json:"user_id" bson:"user_id"`
+	Name     string    `json:"name,omitempty" bson:"-"` // this is constructed by the code according to the group settings
+	DateSeen time.Time `json:"date_seen" bson:"date_seen"`
+} // @name PostSeen
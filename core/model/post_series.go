@@ -0,0 +1,230 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RecurrenceDaily the post is published every interval days
+	RecurrenceDaily = "daily"
+	// RecurrenceWeekly the post is published on the given weekdays every interval weeks
+	RecurrenceWeekly = "weekly"
+	// RecurrenceMonthly the post is published on the day of month of the start date every interval months
+	RecurrenceMonthly = "monthly"
+)
+
+const (
+	// PostSeriesStatusActive the scheduler publishes the occurrences of the series
+	PostSeriesStatusActive = "active"
+	// PostSeriesStatusPaused the occurrences are skipped until the series is resumed
+	PostSeriesStatusPaused = "paused"
+	// PostSeriesStatusCanceled the series is stopped by an admin
+	PostSeriesStatusCanceled = "canceled"
+	// PostSeriesStatusCompleted the series has reached its end date or occurrences count
+	PostSeriesStatusCompleted = "completed"
+)
+
+// maxRecurrenceLookupDays limits the search for the next occurrence of a series
+const maxRecurrenceLookupDays = 3660
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// PostSeries represents a recurring post. The scheduler publishes each occurrence as a separate post which keeps the series id.
+type PostSeries struct {
+	ID                string         `json:"id" bson:"_id"`
+	ClientID          string         `json:"client_id" bson:"client_id"`
+	GroupID           string         `json:"group_id" bson:"group_id"`
+	Creator           Creator        `json:"member" bson:"member"`
+	Subject           string         `json:"subject" bson:"subject"`
+	Body              string         `json:"body" bson:"body"`
+	Private           bool           `json:"private" bson:"private"`
+	UseAsNotification bool           `json:"use_as_notification" bson:"use_as_notification"`
+	ImageURL          *string        `json:"image_url" bson:"image_url"`
+	ToMembersList     []ToMember     `json:"to_members" bson:"to_members"`
+	ToLabelIDs        []string       `json:"to_label_ids" bson:"to_label_ids"`
+	Recurrence        PostRecurrence `json:"recurrence" bson:"recurrence"`
+	TimeZone          string         `json:"time_zone" bson:"time_zone"` // IANA time zone of the occurrences. Empty means UTC
+
+	Status             string     `json:"status" bson:"status"` // active, paused, canceled or completed
+	OccurrencesCount   int        `json:"occurrences_count" bson:"occurrences_count"`
+	DateStart          time.Time  `json:"date_start" bson:"date_start"`
+	DateNextOccurrence *time.Time `json:"date_next_occurrence" bson:"date_next_occurrence"`
+	DateLastOccurrence *time.Time `json:"date_last_occurrence" bson:"date_last_occurrence"`
+	DateCreated        time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated        *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name PostSeries
+
+// PostRecurrence defines when the occurrences of a post series are published
+type PostRecurrence struct {
+	Frequency string     `json:"frequency" bson:"frequency"` // daily, weekly or monthly
+	Interval  int        `json:"interval" bson:"interval"`   // 0 means 1
+	Weekdays  []int      `json:"weekdays" bson:"weekdays"`   // weekly only - 0 (Sunday) to 6 (Saturday). Empty means the weekday of the start date
+	RRule     string     `json:"rrule" bson:"rrule"`         // optional RRULE with FREQ, INTERVAL, BYDAY, COUNT and UNTIL parts. It overrides the other fields
+	DateEnd   *time.Time `json:"date_end" bson:"date_end"`
+	Count     int        `json:"count" bson:"count"` // max count of occurrences. 0 means until the end date
+} //@name PostRecurrence
+
+// ApplyRRule parses the RRULE of the recurrence into its fields
+func (r *PostRecurrence) ApplyRRule() error {
+	rrule := strings.TrimPrefix(strings.TrimSpace(r.RRule), "RRULE:")
+	if rrule == "" {
+		return nil
+	}
+
+	for _, part := range strings.Split(rrule, ";") {
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return fmt.Errorf("invalid rrule part %s", part)
+		}
+		key, value := strings.ToUpper(pair[0]), strings.ToUpper(pair[1])
+		switch key {
+		case "FREQ":
+			r.Frequency = strings.ToLower(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid rrule interval %s", value)
+			}
+			r.Interval = interval
+		case "BYDAY":
+			r.Weekdays = nil
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return fmt.Errorf("unsupported rrule day %s", day)
+				}
+				r.Weekdays = append(r.Weekdays, int(weekday))
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid rrule count %s", value)
+			}
+			r.Count = count
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				until, err = time.Parse("20060102", value)
+				if err != nil {
+					return fmt.Errorf("invalid rrule until %s", value)
+				}
+			}
+			r.DateEnd = &until
+		default:
+			return fmt.Errorf("unsupported rrule part %s", key)
+		}
+	}
+	return nil
+}
+
+// Validate checks if the recurrence is supported
+func (r *PostRecurrence) Validate() error {
+	switch r.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	default:
+		return fmt.Errorf("unsupported recurrence frequency %s", r.Frequency)
+	}
+	if r.Interval < 0 || r.Count < 0 {
+		return fmt.Errorf("the recurrence interval and count must not be negative")
+	}
+	if r.DateEnd == nil && r.Count == 0 {
+		return fmt.Errorf("the recurrence requires an end date or an occurrences count")
+	}
+	for _, weekday := range r.Weekdays {
+		if weekday < 0 || weekday > 6 {
+			return fmt.Errorf("invalid recurrence weekday %d", weekday)
+		}
+	}
+	return nil
+}
+
+// GetLocation returns the time zone of the occurrences
+func (s *PostSeries) GetLocation() *time.Location {
+	if s.TimeZone != "" {
+		if location, err := time.LoadLocation(s.TimeZone); err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// NextOccurrence returns the first occurrence of the series after the provided time. It returns nil if the series has no more occurrences.
+func (s *PostSeries) NextOccurrence(after time.Time) *time.Time {
+	r := s.Recurrence
+	if r.Count > 0 && s.OccurrencesCount >= r.Count {
+		return nil
+	}
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	location := s.GetLocation()
+	start := s.DateStart.In(location)
+	if after.Before(start) {
+		after = start.Add(-time.Nanosecond)
+	}
+
+	weekdays := map[time.Weekday]bool{}
+	for _, weekday := range r.Weekdays {
+		weekdays[time.Weekday(weekday)] = true
+	}
+	if len(weekdays) == 0 {
+		weekdays[start.Weekday()] = true
+	}
+
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	startWeek := startDay.AddDate(0, 0, -int(startDay.Weekday()))
+	from := after.In(location)
+	for i := 0; i <= maxRecurrenceLookupDays*interval; i++ {
+		day := time.Date(from.Year(), from.Month(), from.Day()+i, 0, 0, 0, 0, time.UTC)
+		days := int(day.Sub(startDay).Hours() / 24)
+
+		var matches bool
+		switch r.Frequency {
+		case RecurrenceDaily:
+			matches = days%interval == 0
+		case RecurrenceWeekly:
+			weeks := int(day.AddDate(0, 0, -int(day.Weekday())).Sub(startWeek).Hours() / (24 * 7))
+			matches = weekdays[day.Weekday()] && weeks%interval == 0
+		case RecurrenceMonthly:
+			months := (day.Year()-startDay.Year())*12 + int(day.Month()) - int(startDay.Month())
+			matches = day.Day() == startDay.Day() && months%interval == 0
+		}
+		if !matches {
+			continue
+		}
+
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, location)
+		if !occurrence.After(after) {
+			continue
+		}
+		if r.DateEnd != nil && occurrence.After(*r.DateEnd) {
+			return nil
+		}
+		occurrence = occurrence.UTC()
+		return &occurrence
+	}
+	return nil
+}
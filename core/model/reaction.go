@@ -0,0 +1,66 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxReactionLength is the max length of a reaction string
+const MaxReactionLength = 64
+
+// ReactionsConfig defines the reactions which the users of a client are allowed to use.
+// The groups may narrow it with their own post preferences.
+type ReactionsConfig struct {
+	Type             string   `json:"type" bson:"type"`
+	ClientID         string   `json:"client_id" bson:"client_id"`
+	AllowedReactions []string `json:"allowed_reactions" bson:"allowed_reactions"` // empty means any reaction is allowed
+} //@name ReactionsConfig
+
+// PostReaction represents a reaction of a user to a post
+type PostReaction struct {
+	UserID   string `json:"user_id" bson:"user_id"`
+	Reaction string `json:"reaction" bson:"reaction"`
+} //@name PostReaction
+
+// ValidateReaction checks if the reaction is allowed. The group allowed reactions take precedence over the client ones.
+// Empty lists mean that any reaction is allowed.
+func ValidateReaction(reaction string, clientAllowedReactions []string, groupAllowedReactions []string) error {
+	if len(reaction) == 0 {
+		return fmt.Errorf("the reaction is required")
+	}
+	if len(reaction) > MaxReactionLength {
+		return fmt.Errorf("the reaction is longer than %d characters", MaxReactionLength)
+	}
+	// the reaction is used as a field name within the post document
+	if strings.ContainsAny(reaction, ".$") {
+		return fmt.Errorf("the reaction contains forbidden characters")
+	}
+
+	allowedReactions := groupAllowedReactions
+	if len(allowedReactions) == 0 {
+		allowedReactions = clientAllowedReactions
+	}
+	if len(allowedReactions) == 0 {
+		return nil
+	}
+	for _, allowedReaction := range allowedReactions {
+		if allowedReaction == reaction {
+			return nil
+		}
+	}
+	return fmt.Errorf("the reaction %s is not allowed", reaction)
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// WebhookEventGroupCreated a group has been created
+	WebhookEventGroupCreated = "group.created"
+	// WebhookEventGroupUpdated a group has been updated
+	WebhookEventGroupUpdated = "group.updated"
+	// WebhookEventGroupDeleted a group has been deleted
+	WebhookEventGroupDeleted = "group.deleted"
+	// WebhookEventMembershipCreated a membership or a membership request has been created
+	WebhookEventMembershipCreated = "membership.created"
+	// WebhookEventMembershipStatusChanged the status of a membership has been changed
+	WebhookEventMembershipStatusChanged = "membership.status_changed"
+	// WebhookEventMembershipRemoved a membership has been removed
+	WebhookEventMembershipRemoved = "membership.removed"
+	// WebhookEventPostCreated a post or a reply has been created
+	WebhookEventPostCreated = "post.created"
+	// WebhookEventSyncCompleted an Authman synchronization has been completed
+	WebhookEventSyncCompleted = "sync.completed"
+)
+
+// WebhookEventTypes contains all supported webhook event types
+var WebhookEventTypes = []string{
+	WebhookEventGroupCreated,
+	WebhookEventGroupUpdated,
+	WebhookEventGroupDeleted,
+	WebhookEventMembershipCreated,
+	WebhookEventMembershipStatusChanged,
+	WebhookEventMembershipRemoved,
+	WebhookEventPostCreated,
+	WebhookEventSyncCompleted,
+}
+
+const (
+	// WebhookDeliveryStatusPending the delivery waits for sending or for a retry
+	WebhookDeliveryStatusPending = "pending"
+	// WebhookDeliveryStatusDelivered the receiver has accepted the delivery
+	WebhookDeliveryStatusDelivered = "delivered"
+	// WebhookDeliveryStatusDead the delivery has failed too many times. It may be replayed by an admin
+	WebhookDeliveryStatusDead = "dead"
+)
+
+// WebhookSubscription represents an endpoint of a client which receives the selected group lifecycle events.
+// The payloads are signed with HMAC-SHA256 using the subscription secret.
+type WebhookSubscription struct {
+	ID          string     `json:"id" bson:"_id"`
+	ClientID    string     `json:"client_id" bson:"client_id"`
+	Name        string     `json:"name" bson:"name"`
+	URL         string     `json:"url" bson:"url"`
+	Secret      string     `json:"secret,omitempty" bson:"secret"` // returned only when the subscription is created
+	EventTypes  []string   `json:"event_types" bson:"event_types"` // empty means all event types
+	Active      bool       `json:"active" bson:"active"`
+	CreatedBy   string     `json:"created_by" bson:"created_by"`
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name WebhookSubscription
+
+// Validate checks the url and the event types of the subscription. Only https urls are accepted so that the payloads are never sent in plain text.
+func (s *WebhookSubscription) Validate() error {
+	parsedURL, err := url.Parse(s.URL)
+	if err != nil || parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return fmt.Errorf("invalid webhook url: %s", s.URL)
+	}
+	for _, eventType := range s.EventTypes {
+		if !IsValidWebhookEventType(eventType) {
+			return fmt.Errorf("unsupported webhook event type: %s", eventType)
+		}
+	}
+	return nil
+}
+
+// IsSubscribedTo checks if the subscription receives the event type
+func (s *WebhookSubscription) IsSubscribedTo(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, item := range s.EventTypes {
+		if item == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidWebhookEventType checks if the event type is supported
+func IsValidWebhookEventType(eventType string) bool {
+	for _, item := range WebhookEventTypes {
+		if item == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent is the JSON payload sent to the webhook subscriptions
+type WebhookEvent struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	ClientID    string      `json:"client_id"`
+	DateCreated time.Time   `json:"date_created"`
+	Data        interface{} `json:"data"`
+}
+
+// WebhookGroupDeletedData is the data of the group.deleted event
+type WebhookGroupDeletedData struct {
+	GroupID string `json:"group_id"`
+}
+
+// WebhookMembershipStatusChangedData is the data of the membership.status_changed event
+type WebhookMembershipStatusChangedData struct {
+	Membership     GroupMembership `json:"membership"`
+	PreviousStatus string          `json:"previous_status"`
+}
+
+// WebhookSyncCompletedData is the data of the sync.completed event. GroupID is nil for the global synchronization of the client.
+type WebhookSyncCompletedData struct {
+	GroupID      *string    `json:"group_id"`
+	AuthmanGroup *string    `json:"authman_group"`
+	StartTime    *time.Time `json:"start_time"`
+	EndTime      *time.Time `json:"end_time"`
+}
+
+// WebhookDelivery represents a delivery of an event to a webhook subscription
+type WebhookDelivery struct {
+	ID             string `json:"id" bson:"_id"`
+	ClientID       string `json:"client_id" bson:"client_id"`
+	SubscriptionID string `json:"subscription_id" bson:"subscription_id"`
+	EventID        string `json:"event_id" bson:"event_id"`
+	EventType      string `json:"event_type" bson:"event_type"`
+	Payload        string `json:"payload" bson:"payload"`
+
+	Status          string     `json:"status" bson:"status"` // pending, delivered or dead
+	Attempts        int        `json:"attempts" bson:"attempts"`
+	LastError       string     `json:"last_error" bson:"last_error"`
+	LastStatusCode  int        `json:"last_status_code" bson:"last_status_code"`
+	DateNextAttempt time.Time  `json:"date_next_attempt" bson:"date_next_attempt"`
+	DateDelivered   *time.Time `json:"date_delivered" bson:"date_delivered"`
+	DateCreated     time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated     *time.Time `json:"date_updated" bson:"date_updated"`
+} //@name WebhookDelivery
+
+// IsValidWebhookDeliveryStatus checks if the delivery status is supported
+func IsValidWebhookDeliveryStatus(status string) bool {
+	return status == WebhookDeliveryStatusPending || status == WebhookDeliveryStatusDelivered || status == WebhookDeliveryStatusDead
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "testing"
+
+func TestWebhookSubscriptionValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []string
+		wantErr    bool
+	}{
+		{"https", "https://example.com/hooks", nil, false},
+		{"https with events", "https://example.com/hooks", []string{WebhookEventGroupCreated, WebhookEventMembershipCreated}, false},
+		{"http", "http://example.com/hooks", nil, true},
+		{"no host", "https:///hooks", nil, true},
+		{"not an url", "example.com/hooks", nil, true},
+		{"unknown event", "https://example.com/hooks", []string{"group.archived"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := WebhookSubscription{URL: tt.url, EventTypes: tt.eventTypes}
+			if err := subscription.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscriptionIsSubscribedTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		active     bool
+		eventTypes []string
+		eventType  string
+		want       bool
+	}{
+		{"all events", true, nil, WebhookEventGroupDeleted, true},
+		{"subscribed event", true, []string{WebhookEventGroupCreated}, WebhookEventGroupCreated, true},
+		{"other event", true, []string{WebhookEventGroupCreated}, WebhookEventGroupDeleted, false},
+		{"inactive", false, nil, WebhookEventGroupCreated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := WebhookSubscription{Active: tt.active, EventTypes: tt.eventTypes}
+			if got := subscription.IsSubscribedTo(tt.eventType); got != tt.want {
+				t.Errorf("IsSubscribedTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
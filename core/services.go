@@ -19,6 +19,7 @@ import (
 	"groups/driven/rewards"
 	"groups/driven/storage"
 	"groups/utils"
+	"html"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,21 +37,6 @@ const (
 	authmanUserBatchSize       = 5000
 )
 
-/*
-func (app *Application) applyDataProtection(current *model.User, group model.Group) model.Group {
-	//1 apply data protection for "anonymous"
-	if current == nil || current.IsAnonymous {
-		group.Members = []model.Member{}
-	} else {
-		member := group.GetMemberByUserID(current.ID)
-		if member != nil && (member.IsRejected() || member.IsPendingMember()) {
-			group.Members = []model.Member{}
-			group.Members = append(group.Members, *member)
-		}
-	}
-	return group
-}*/
-
 func (app *Application) getVersion() string {
 	return app.version
 }
@@ -85,9 +71,22 @@ func (app *Application) isGroupAdmin(clientID string, groupID string, userID str
 
 func (app *Application) createGroup(clientID string, current *model.User, group *model.Group, membersConfig *model.DefaultMembershipConfig) (*string, *utils.GroupError) {
 
+	err := group.RenderDescription()
+	if err != nil {
+		return nil, utils.NewValidationError(err)
+	}
+	err = model.ValidateMemberProfileFields(group.ProfileFields)
+	if err != nil {
+		return nil, utils.NewValidationError(err)
+	}
+	group.MemberLabels, err = model.ValidateMemberLabels(group.MemberLabels, nil)
+	if err != nil {
+		return nil, utils.NewValidationError(err)
+	}
+
 	var groupError *utils.GroupError
 	var groupID *string
-	err := app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+	err = app.storage.PerformTransaction(func(context storage.TransactionContext) error {
 		var err error
 
 		// Create intitial members if need
@@ -117,6 +116,12 @@ func (app *Application) createGroup(clientID string, current *model.User, group
 					})
 				}
 			}
+			for i := range members {
+				err = members[i].RecordCreation(model.MembershipTransitionSourceAdmin, current, "")
+				if err != nil {
+					return err
+				}
+			}
 		}
 
 		groupID, groupError = app.storage.CreateGroup(context, clientID, current, group, members)
@@ -124,6 +129,11 @@ func (app *Application) createGroup(clientID string, current *model.User, group
 			return err
 		}
 
+		err = app.publishWebhookEvent(context, clientID, model.WebhookEventGroupCreated, group)
+		if err != nil {
+			return err
+		}
+
 		if group.ResearchGroup {
 			searchParams := app.formatCoreAccountSearchParams(group.ResearchProfile)
 
@@ -139,19 +149,23 @@ func (app *Application) createGroup(clientID string, current *model.User, group
 				list = append(list, ne)
 			}
 
-			app.notifications.SendNotification(list, nil, "A new research project is available", fmt.Sprintf("%s by %s", group.Title, current.Name),
+			data := model.NewNotificationTemplateData(group)
+			data.UserName = current.Name
+			err = app.queueTemplatedNotification(context, clientID, nil, model.NotificationOperationResearchGroup, data, nil, list, nil,
 				map[string]string{
 					"type":        "group",
-					"operation":   "research_group",
+					"operation":   model.NotificationOperationResearchGroup,
 					"entity_type": "group",
 					"entity_id":   group.ID,
 					"entity_name": group.Title,
 				},
 				current.AppID,
 				current.OrgID,
-				nil,
+				notifications.DefaultPriority,
 			)
-
+			if err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -181,10 +195,25 @@ func (app *Application) createGroup(clientID string, current *model.User, group
 }
 
 func (app *Application) updateGroup(clientID string, current *model.User, group *model.Group) *utils.GroupError {
+	renderErr := group.RenderDescription()
+	if renderErr != nil {
+		return utils.NewValidationError(renderErr)
+	}
 
-	err := app.storage.UpdateGroup(nil, clientID, current, group)
+	var groupError *utils.GroupError
+	err := app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		groupError = app.storage.UpdateGroup(context, clientID, current, group)
+		if groupError != nil {
+			return groupError
+		}
+		return app.publishWebhookEvent(context, clientID, model.WebhookEventGroupUpdated, group)
+	})
+	if groupError != nil {
+		return groupError
+	}
 	if err != nil {
-		return err
+		log.Printf("app.updateGroup() error %s", err)
+		return utils.NewServerError()
 	}
 	return nil
 }
@@ -198,11 +227,13 @@ func (app *Application) updateGroupDateUpdated(clientID string, groupID string)
 }
 
 func (app *Application) deleteGroup(clientID string, current *model.User, id string) error {
-	err := app.storage.DeleteGroup(nil, clientID, id)
-	if err != nil {
-		return err
-	}
-	return nil
+	return app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		err := app.storage.DeleteGroup(context, clientID, id)
+		if err != nil {
+			return err
+		}
+		return app.publishWebhookEvent(context, clientID, model.WebhookEventGroupDeleted, model.WebhookGroupDeletedData{GroupID: id})
+	})
 }
 
 func (app *Application) getGroups(clientID string, current *model.User, filter model.GroupsFilter) ([]model.Group, error) {
@@ -236,6 +267,8 @@ func (app *Application) getUserGroups(clientID string, current *model.User, filt
 		return nil, err
 	}
 
+	app.applyUnreadPostsCount(clientID, current, groups)
+
 	return groups, nil
 }
 
@@ -259,78 +292,109 @@ func (app *Application) getGroup(clientID string, current *model.User, id string
 }
 
 func (app *Application) applyMembershipApproval(clientID string, current *model.User, membershipID string, approve bool, rejectReason string) error {
-	membership, err := app.storage.ApplyMembershipApproval(clientID, membershipID, approve, rejectReason)
+	storedMembership, err := app.storage.FindGroupMembershipByID(clientID, membershipID)
 	if err != nil {
-		return fmt.Errorf("error applying membership approval: %s", err)
+		return fmt.Errorf("error finding membership %s: %s", membershipID, err)
 	}
-	if err == nil && membership != nil {
-		group, _ := app.storage.FindGroup(nil, clientID, membership.GroupID, nil)
-		topic := "group.invitations"
-		groupStr := "Group"
-		if group.ResearchGroup {
-			groupStr = "Research Project"
+	if storedMembership == nil {
+		return fmt.Errorf("membership %s not found", membershipID)
+	}
+	status := model.MembershipStatusRejected
+	if approve {
+		status = model.MembershipStatusMember
+	}
+	transition, err := model.NewMembershipTransition(storedMembership.Status, status, model.MembershipTransitionSourceAdmin, current, rejectReason)
+	if err != nil {
+		return err
+	}
+
+	var membership *model.GroupMembership
+	var group *model.Group
+	transaction := func(context storage.TransactionContext) error {
+		membership, err = app.storage.ApplyMembershipApproval(context, clientID, membershipID, approve, rejectReason, *transition)
+		if err != nil {
+			return fmt.Errorf("error applying membership approval: %s", err)
 		}
-		if approve {
-			app.notifications.SendNotification(
-				[]notifications.Recipient{
-					membership.ToNotificationRecipient(membership.NotificationsPreferences.OverridePreferences &&
-						(membership.NotificationsPreferences.InvitationsMuted || membership.NotificationsPreferences.AllMute)),
-				},
-				&topic,
-				fmt.Sprintf("%s - %s", groupStr, group.Title),
-				fmt.Sprintf("Your membership in '%s' %s has been approved", group.Title, strings.ToLower(groupStr)),
-				map[string]string{
-					"type":        "group",
-					"operation":   "membership_approve",
-					"entity_type": "group",
-					"entity_id":   group.ID,
-					"entity_name": group.Title,
-				},
-				current.AppID,
-				current.OrgID,
-				nil,
-			)
-		} else {
-			app.notifications.SendNotification(
-				[]notifications.Recipient{
-					membership.ToNotificationRecipient(membership.NotificationsPreferences.OverridePreferences &&
-						(membership.NotificationsPreferences.InvitationsMuted || membership.NotificationsPreferences.AllMute)),
-				},
-				&topic,
-				fmt.Sprintf("%s - %s", groupStr, group.Title),
-				fmt.Sprintf("Your membership in '%s' %s has been rejected with a reason: %s", group.Title, strings.ToLower(groupStr), rejectReason),
-				map[string]string{
-					"type":        "group",
-					"operation":   "membership_reject",
-					"entity_type": "group",
-					"entity_id":   group.ID,
-					"entity_name": group.Title,
-				},
-				current.AppID,
-				current.OrgID,
-				nil,
-			)
+
+		err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipStatusChanged,
+			model.WebhookMembershipStatusChangedData{Membership: *membership, PreviousStatus: transition.FromStatus})
+		if err != nil {
+			return err
 		}
 
-		if approve && group.CanJoinAutomatically && group.AuthmanEnabled && membership.ExternalID != "" {
-			err := app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, membership.ExternalID)
-			if err != nil {
-				log.Printf("err app.applyMembershipApproval() - error storing member in Authman: %s", err)
-			}
+		group, err = app.storage.FindGroup(context, clientID, membership.GroupID, nil)
+		if err != nil {
+			return fmt.Errorf("error finding group %s: %s", membership.GroupID, err)
+		}
+		if group == nil {
+			return fmt.Errorf("missing group for id %s", membership.GroupID)
+		}
+
+		topic := "group.invitations"
+		operation := model.NotificationOperationMembershipApprove
+		if !approve {
+			operation = model.NotificationOperationMembershipReject
+		}
+		data := model.NewNotificationTemplateData(group)
+		data.Reason = rejectReason
+		return app.queueTemplatedNotification(
+			context,
+			clientID,
+			&group.ID,
+			operation,
+			data,
+			map[string]string{membership.UserID: membership.NotificationsPreferences.Locale},
+			[]notifications.Recipient{
+				membership.ToNotificationRecipient(membership.NotificationsPreferences.OverridePreferences &&
+					(membership.NotificationsPreferences.InvitationsMuted || membership.NotificationsPreferences.AllMute)),
+			},
+			&topic,
+			map[string]string{
+				"type":        "group",
+				"operation":   operation,
+				"entity_type": "group",
+				"entity_id":   group.ID,
+				"entity_name": group.Title,
+			},
+			current.AppID,
+			current.OrgID,
+			notifications.DefaultPriority,
+		)
+	}
+
+	err = app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return err
+	}
+
+	if approve && group.CanJoinAutomatically && group.AuthmanEnabled && membership.ExternalID != "" {
+		err := app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, membership.ExternalID)
+		if err != nil {
+			log.Printf("err app.applyMembershipApproval() - error storing member in Authman: %s", err)
 		}
-	} else {
-		log.Printf("Unable to retrieve group by membership id: %s\n", err)
-		// return err // No reason to fail if the main part succeeds
 	}
 
 	return nil
 }
 
 func (app *Application) updateMembership(clientID string, current *model.User, membershipID string, status *string, dateAttended *time.Time, notificationsPreferences *model.NotificationsPreferences) error {
+	if notificationsPreferences != nil && !model.IsValidDigestFrequency(notificationsPreferences.DigestFrequency) {
+		return fmt.Errorf("invalid digest frequency %s", notificationsPreferences.DigestFrequency)
+	}
+	if notificationsPreferences != nil && len(notificationsPreferences.Locale) > model.MaxNotificationLocaleLength {
+		return fmt.Errorf("the locale is longer than %d characters", model.MaxNotificationLocaleLength)
+	}
+
 	membership, _ := app.storage.FindGroupMembershipByID(clientID, membershipID)
 	if membership != nil {
-		if status != nil && membership.Status != *status {
-			membership.Status = *status
+		previousStatus := membership.Status
+		var transition *model.MembershipTransition
+		if status != nil {
+			var err error
+			transition, err = membership.ChangeStatus(*status, model.MembershipTransitionSourceAdmin, current, "")
+			if err != nil {
+				return err
+			}
 		}
 		if dateAttended != nil && membership.DateAttended == nil {
 			membership.DateAttended = dateAttended
@@ -339,10 +403,17 @@ func (app *Application) updateMembership(clientID string, current *model.User, m
 			membership.NotificationsPreferences = *notificationsPreferences
 		}
 
-		err := app.storage.UpdateMembership(clientID, current, membershipID, membership)
-		if err != nil {
-			return err
-		}
+		return app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+			err := app.storage.UpdateMembership(context, clientID, current, membershipID, membership, transition)
+			if err != nil {
+				return err
+			}
+			if membership.Status == previousStatus {
+				return nil
+			}
+			return app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipStatusChanged,
+				model.WebhookMembershipStatusChangedData{Membership: *membership, PreviousStatus: previousStatus})
+		})
 	}
 
 	return nil
@@ -350,10 +421,56 @@ func (app *Application) updateMembership(clientID string, current *model.User, m
 
 func (app *Application) updateMemberships(clientID string, user *model.User, group *model.Group, operation model.MembershipMultiUpdate) error {
 	if group != nil && group.CurrentMember != nil && group.CurrentMember.IsAdmin() {
-		err := app.storage.UpdateMemberships(clientID, user, group.ID, operation)
-		if err != nil {
-			return err
-		}
+		// the memberships are read and their transitions are validated within the transaction which updates them
+		return app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+			var previous model.MembershipCollection
+			transitions := map[string]model.MembershipTransition{}
+			if operation.Status != nil && len(operation.UserIDs) > 0 {
+				var err error
+				previous, err = app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
+					GroupIDs: []string{group.ID},
+					UserIDs:  operation.UserIDs,
+				})
+				if err != nil {
+					return err
+				}
+
+				// all transitions must be allowed before anything is changed
+				reason := ""
+				if operation.Reason != nil {
+					reason = *operation.Reason
+				}
+				for _, membership := range previous.Items {
+					if membership.Status == *operation.Status {
+						continue
+					}
+					transition, err := model.NewMembershipTransition(membership.Status, *operation.Status, model.MembershipTransitionSourceAdmin, user, reason)
+					if err != nil {
+						return fmt.Errorf("error updating membership of %s: %s", membership.UserID, err)
+					}
+					transitions[membership.UserID] = *transition
+				}
+			}
+
+			err := app.storage.UpdateMemberships(context, clientID, user, group.ID, operation, transitions)
+			if err != nil {
+				return err
+			}
+
+			for _, membership := range previous.Items {
+				if membership.Status == *operation.Status {
+					continue
+				}
+				previousStatus := membership.Status
+				membership.Status = *operation.Status
+				err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipStatusChanged,
+					model.WebhookMembershipStatusChangedData{Membership: membership, PreviousStatus: previousStatus})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	}
 	return nil
 }
@@ -366,23 +483,50 @@ func (app *Application) reportGroupAsAbuse(clientID string, current *model.User,
 		return fmt.Errorf("error while reporting an abuse group: %s", err)
 	}
 
+	_, err = app.recordAbuseReport(nil, clientID, current, group.ID, model.AbuseReportTargetGroup, group.ID, group.Title, comment, true, false)
+	if err != nil {
+		return err
+	}
+
 	subject := fmt.Sprintf("Report violation of Student Code to Dean of Students for group: %s", group.Title)
 
 	body := fmt.Sprintf(`
 <div>Group title: %s\n</div>
 <div>Reported by: %s %s\n</div>
 <div>Reported comment: %s\n</div>
-	`, group.Title, current.ExternalID, current.Name, comment)
+	`, html.EscapeString(group.Title), html.EscapeString(current.ExternalID), html.EscapeString(current.Name), html.EscapeString(comment))
 	body = strings.ReplaceAll(body, `\n`, "\n")
 	return app.notifications.SendMail(app.config.ReportAbuseRecipientEmail, subject, body)
 }
 
 func (app *Application) getPosts(clientID string, current *model.User, filter model.PostsFilter, filterPrivatePostsValue *bool, filterByToMembers bool) ([]model.Post, error) {
-	return app.storage.FindPosts(clientID, current, filter, filterPrivatePostsValue, filterByToMembers)
+	if filter.RepliesLimit != nil {
+		filter.RepliesLimit = normalizeRepliesLimit(filter.RepliesLimit)
+	}
+	posts, err := app.storage.FindPosts(clientID, current, filter, filterPrivatePostsValue, filterByToMembers)
+	if err != nil || current == nil {
+		return posts, err
+	}
+	for i := range posts {
+		posts[i].ApplyMyReactions(current.ID)
+	}
+	return posts, nil
 }
 
 func (app *Application) getPost(clientID string, userID *string, groupID string, postID string, skipMembershipCheck bool, filterByToMembers bool) (*model.Post, error) {
-	return app.storage.FindPost(nil, clientID, userID, groupID, postID, skipMembershipCheck, filterByToMembers)
+	post, err := app.storage.FindPost(nil, clientID, userID, groupID, postID, skipMembershipCheck, filterByToMembers)
+	if err != nil || post == nil || userID == nil {
+		return post, err
+	}
+
+	membership, _ := app.storage.FindGroupMembership(clientID, groupID, *userID)
+	isAdmin := membership != nil && membership.IsAdmin()
+	post = applyPostModerationVisibility(post, *userID, isAdmin)
+	if post == nil || (post.IsExpired() && !isAdmin) {
+		return nil, fmt.Errorf("the post %s is not available", postID)
+	}
+	post.ApplyMyReactions(*userID)
+	return post, nil
 }
 
 func (app *Application) getUserPostCount(clientID string, userID string) (*int64, error) {
@@ -390,36 +534,113 @@ func (app *Application) getUserPostCount(clientID string, userID string) (*int64
 }
 
 func (app *Application) createPost(clientID string, current *model.User, post *model.Post, group *model.Group) (*model.Post, error) {
+	// only the cross-posting links the copies to their origin and only the post series link the posts they publish
+	post.OriginID = nil
+	post.SeriesID = nil
+
+	return app.createGroupPost(clientID, current, post, group)
+}
+
+// createGroupPost stores the post, keeping its origin and series links, and queues its notifications
+func (app *Application) createGroupPost(clientID string, current *model.User, post *model.Post, group *model.Group) (*model.Post, error) {
+	transaction := func(context storage.TransactionContext) error {
+		var err error
+		post, err = app.insertPost(context, clientID, current, post, group)
+		if err != nil {
+			return err
+		}
+
+		err = app.publishWebhookEvent(context, clientID, model.WebhookEventPostCreated, post)
+		if err != nil {
+			return err
+		}
 
-	post, err := app.storage.CreatePost(clientID, current, post)
+		if post.IsPublished() {
+			return app.sendGroupNotificationForNewPost(context, clientID, &current.ID, &current.Name, group, post)
+		}
+		return app.sendGroupNotificationForPendingPost(context, clientID, current, group, post)
+	}
+
+	err := app.storage.PerformTransaction(transaction)
 	if err != nil {
 		return nil, err
 	}
 
-	handleRewardsAsync := func(clientID, userID string) {
-		count, grErr := app.storage.GetUserPostCount(clientID, current.ID)
-		if grErr != nil {
-			log.Printf("Error createPost(): %s", grErr)
-		} else if count != nil {
-			if *count > 1 {
-				app.rewards.CreateUserReward(current.ID, rewards.GroupsUserSubmittedPost, "")
-			} else if *count == 1 {
-				app.rewards.CreateUserReward(current.ID, rewards.GroupsUserSubmittedFirstPost, "")
-			}
-		}
+	go app.createPostRewards(clientID, current)
+
+	return post, nil
+}
+
+// insertPost validates and stores a new post within the group without sending notifications
+func (app *Application) insertPost(context storage.TransactionContext, clientID string, current *model.User, post *model.Post, group *model.Group) (*model.Post, error) {
+	err := app.preparePinnedPostForCreate(context, clientID, current, group, post)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePostLabels(group, post)
+	if err != nil {
+		return nil, err
 	}
-	go handleRewardsAsync(clientID, current.ID)
 
-	go app.sendGroupNotificationForNewPost(clientID, &current.ID, &current.Name, group, post)
+	post.Status = ""
+	post.ReviewedBy = nil
+	post.RejectReason = ""
+	post.DateReviewed = nil
+	if postRequiresApproval(group) {
+		post.Status = model.PostStatusPendingReview
+	}
 
-	return post, nil
+	err = app.applyContentFilters(clientID, group, post)
+	if err != nil {
+		return nil, err
+	}
+	if post.Pinned && !post.IsPublished() {
+		return nil, fmt.Errorf("only published posts can be pinned")
+	}
+
+	err = preparePostExpiration(post)
+	if err != nil {
+		return nil, err
+	}
+
+	err = post.RenderBody()
+	if err != nil {
+		return nil, err
+	}
+
+	return app.storage.CreatePost(context, clientID, current, post)
+}
+
+func (app *Application) createPostRewards(clientID string, current *model.User) {
+	count, grErr := app.storage.GetUserPostCount(clientID, current.ID)
+	if grErr != nil {
+		log.Printf("Error createPost(): %s", grErr)
+	} else if count != nil {
+		if *count > 1 {
+			app.rewards.CreateUserReward(current.ID, rewards.GroupsUserSubmittedPost, "")
+		} else if *count == 1 {
+			app.rewards.CreateUserReward(current.ID, rewards.GroupsUserSubmittedFirstPost, "")
+		}
+	}
 }
 
-func (app *Application) sendGroupNotificationForNewPost(clientID string, currentUserID *string, currentUserName *string, group *model.Group, post *model.Post) error {
+func (app *Application) sendGroupNotificationForNewPost(context storage.TransactionContext, clientID string, currentUserID *string, currentUserName *string, group *model.Group, post *model.Post) error {
+	return app.sendGroupNotificationForNewPostSkipping(context, clientID, currentUserID, currentUserName, group, post, nil)
+}
+
+// sendGroupNotificationForNewPostSkipping queues the new post notification to the recipients which are not in notifiedUserIDs yet and adds them there
+func (app *Application) sendGroupNotificationForNewPostSkipping(context storage.TransactionContext, clientID string, currentUserID *string, currentUserName *string, group *model.Group, post *model.Post, notifiedUserIDs map[string]bool) error {
 	now := time.Now()
 	if post.DateScheduled == nil || now.After(*post.DateScheduled) {
 
-		recipientsUserIDs, _ := app.getPostNotificationRecipientsAsUserIDs(clientID, post, currentUserID)
+		recipientsUserIDs, err := app.getPostNotificationRecipientsAsUserIDs(clientID, post, currentUserID)
+		if err != nil {
+			return err
+		}
+		if post.ParentID == nil && recipientsUserIDs != nil && len(recipientsUserIDs) == 0 {
+			// the post is addressed to nobody else
+			return nil
+		}
 
 		result, _ := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
 			GroupIDs: []string{group.ID},
@@ -446,18 +667,28 @@ func (app *Application) sendGroupNotificationForNewPost(clientID string, current
 			}
 		}
 
-		if len(recipients) > 0 {
-			groupStr := "Group"
-			if group.ResearchGroup {
-				groupStr = "Research Project"
+		if notifiedUserIDs != nil {
+			notNotifiedRecipients := make([]notifications.Recipient, 0, len(recipients))
+			for _, recipient := range recipients {
+				if notifiedUserIDs[recipient.UserID] {
+					continue
+				}
+				// the users who have muted this group may still be notified from the other groups
+				if !recipient.Mute {
+					notifiedUserIDs[recipient.UserID] = true
+				}
+				notNotifiedRecipients = append(notNotifiedRecipients, recipient)
 			}
+			recipients = notNotifiedRecipients
+		}
 
-			title := fmt.Sprintf("%s - %s", groupStr, group.Title)
-			operation := "messaged you"
-			if len(post.ToMembersList) == 0 {
-				operation = "posted"
+		if len(recipients) > 0 {
+			data := model.NewNotificationTemplateData(group)
+			data.Action = "messaged you"
+			if !post.IsTargeted() {
+				data.Action = "posted"
 				if post.ParentID != nil {
-					operation = "replied"
+					data.Action = "replied"
 				}
 			}
 			if currentUserName == nil && currentUserID != nil {
@@ -474,37 +705,35 @@ func (app *Application) sendGroupNotificationForNewPost(clientID string, current
 				val := "User"
 				currentUserName = &val
 			}
-
-			notificationBody := post.Body
-			if len(notificationBody) > 250 {
-				notificationBody = notificationBody[:250] + "..."
+			data.UserName = *currentUserName
+			data.PostSubject = post.Subject
+			data.PostBody = post.Body
+			if len(data.PostBody) > 250 {
+				data.PostBody = data.PostBody[:250] + "..."
 			}
-			body := fmt.Sprintf("%s %s \"%s\"", *currentUserName, operation, notificationBody)
-			if post.UseAsNotification {
-				title = post.Subject
-				body = post.Body
+
+			priority := notifications.DefaultPriority
+			if post.ParentID == nil && post.IsActiveAnnouncement() {
+				priority = notifications.AnnouncementPriority
 			}
 
 			topic := "group.posts"
-			return app.notifications.SendNotification(
-				recipients,
-				&topic,
-				title,
-				body,
-				map[string]string{
-					"type":         "group",
-					"operation":    "post_created",
-					"entity_type":  "group",
-					"entity_id":    group.ID,
-					"entity_name":  group.Title,
-					"post_id":      post.ID,
-					"post_subject": post.Subject,
-					"post_body":    post.Body,
-				},
-				app.config.AppID,
-				app.config.OrgID,
-				nil,
-			)
+			payload := map[string]string{
+				"type":         "group",
+				"operation":    model.NotificationOperationPostCreated,
+				"entity_type":  "group",
+				"entity_id":    group.ID,
+				"entity_name":  group.Title,
+				"post_id":      post.ID,
+				"post_subject": post.Subject,
+				"post_body":    post.Body,
+			}
+			if post.UseAsNotification {
+				return app.queueNotificationWithPriority(context, clientID, recipients, &topic, post.Subject, post.Body, payload,
+					app.config.AppID, app.config.OrgID, nil, priority)
+			}
+			return app.queueTemplatedNotification(context, clientID, &group.ID, model.NotificationOperationPostCreated, data,
+				result.GetNotificationLocales(), recipients, &topic, payload, app.config.AppID, app.config.OrgID, priority)
 		}
 	}
 	return nil
@@ -515,8 +744,8 @@ func (app *Application) getPostNotificationRecipientsAsUserIDs(clientID string,
 		return nil, nil
 	}
 
-	if len(post.ToMembersList) > 0 {
-		return post.GetMembersAsUserIDs(skipUserID), nil
+	if post.IsTargeted() {
+		return app.findAudienceUserIDs(nil, clientID, post.GroupID, post.GetMembersAsUserIDs(skipUserID), post.ToLabelIDs, skipUserID)
 	}
 
 	var err error
@@ -531,8 +760,8 @@ func (app *Application) getPostNotificationRecipientsAsUserIDs(clientID string,
 			return nil, fmt.Errorf("error app.getPostToMemberList() - %s", err)
 		}
 
-		if post != nil && len(post.ToMembersList) > 0 {
-			return post.GetMembersAsUserIDs(skipUserID), nil
+		if post != nil && post.IsTargeted() {
+			return app.findAudienceUserIDs(nil, clientID, post.GroupID, post.GetMembersAsUserIDs(skipUserID), post.ToLabelIDs, skipUserID)
 		}
 	}
 
@@ -540,7 +769,69 @@ func (app *Application) getPostNotificationRecipientsAsUserIDs(clientID string,
 }
 
 func (app *Application) updatePost(clientID string, current *model.User, group *model.Group, post *model.Post) (*model.Post, error) {
-	return app.storage.UpdatePost(clientID, current.ID, post)
+	if post.DateExpires != nil && post.DateExpires.Before(time.Now()) {
+		return nil, fmt.Errorf("the expiration date is in the past")
+	}
+
+	originalPost, err := app.storage.FindPostWithoutReplies(nil, clientID, &current.ID, post.GroupID, post.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("error finding post: %s", err)
+	}
+	if originalPost != nil && originalPost.IsCrossPostCopy() {
+		return nil, fmt.Errorf("the post %s is a cross-posted copy. Update the origin post %s instead", post.ID, *originalPost.OriginID)
+	}
+	err = validatePostLabels(group, post)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Status = ""
+	err = app.applyContentFilters(clientID, group, post)
+	if err != nil {
+		return nil, err
+	}
+	hold := post.Status == model.PostStatusPendingReview
+
+	err = post.RenderBody()
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := app.prepareCrossPostCopies(clientID, post)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := func(context storage.TransactionContext) error {
+		post, err = app.storage.UpdatePost(context, clientID, current.ID, post)
+		if err != nil {
+			return err
+		}
+
+		err = app.updateCrossPostCopies(context, clientID, current, copies)
+		if err != nil {
+			return fmt.Errorf("error updating cross-posted copies: %s", err)
+		}
+
+		if hold && post != nil {
+			err = app.storage.UpdatePostStatus(context, clientID, post.GroupID, post.ID, model.PostStatusPendingReview, nil, "")
+			if err != nil {
+				return fmt.Errorf("error holding post for review: %s", err)
+			}
+			post.Status = model.PostStatusPendingReview
+			post.ReviewedBy = nil
+			post.RejectReason = ""
+			return app.sendGroupNotificationForPendingPost(context, clientID, current, group, post)
+		}
+		return nil
+	}
+
+	err = app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
 }
 
 func (app *Application) reactToPost(clientID string, current *model.User, groupID string, postID string, reaction string) error {
@@ -564,6 +855,12 @@ func (app *Application) reactToPost(clientID string, current *model.User, groupI
 			}
 		}
 
+		// the reactions removed from the allowed sets may still be taken back
+		err = app.validateReaction(clientID, groupID, reaction)
+		if err != nil {
+			return err
+		}
+
 		err = app.storage.ReactToPost(context, current.ID, postID, reaction, true)
 		if err != nil {
 			return fmt.Errorf("error adding reaction: %v", err)
@@ -581,12 +878,6 @@ func (app *Application) reportPostAsAbuse(clientID string, current *model.User,
 		sendToDean = true
 	}
 
-	err := app.storage.ReportPostAsAbuse(clientID, current.ID, group, post)
-	if err != nil {
-		log.Printf("error while reporting an abuse post: %s", err)
-		return fmt.Errorf("error while reporting an abuse post: %s", err)
-	}
-
 	subject := ""
 	if sendToDean && !sendToGroupAdmins {
 		subject = "Report violation of Student Code to Dean of Students"
@@ -598,59 +889,108 @@ func (app *Application) reportPostAsAbuse(clientID string, current *model.User,
 
 	subject = fmt.Sprintf("%s %s", subject, post.DateCreated.Format(time.RFC850))
 
-	if sendToDean {
-		body := fmt.Sprintf(`
-<div>Violation by: %s %s\n</div>
-<div>Group title: %s\n</div>
-<div>Post Title: %s\n</div>
-<div>Post Body: %s\n</div>
-<div>Reported by: %s %s\n</div>
-<div>Reported comment: %s\n</div>
-	`, current.ExternalID, post.Creator.Name, group.Title, post.Subject, post.Body,
-			current.ExternalID, current.Name, comment)
-		body = strings.ReplaceAll(body, `\n`, "\n")
-		app.notifications.SendMail(app.config.ReportAbuseRecipientEmail, subject, body)
-	}
-	if sendToGroupAdmins {
-		result, _ := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
+	transaction := func(context storage.TransactionContext) error {
+		err := app.storage.ReportPostAsAbuse(context, clientID, current.ID, group, post)
+		if err != nil {
+			log.Printf("error while reporting an abuse post: %s", err)
+			return fmt.Errorf("error while reporting an abuse post: %s", err)
+		}
+
+		targetType := model.AbuseReportTargetPost
+		if post.ParentID != nil {
+			targetType = model.AbuseReportTargetReply
+		}
+		_, err = app.recordAbuseReport(context, clientID, current, group.ID, targetType, post.ID, post.Subject, comment, sendToDean, sendToGroupAdmins)
+		if err != nil {
+			return err
+		}
+
+		if !sendToGroupAdmins {
+			return nil
+		}
+
+		result, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
 			GroupIDs: []string{group.ID},
 			Statuses: []string{"admin"},
 		})
+		if err != nil {
+			return fmt.Errorf("error finding group admins: %s", err)
+		}
 		toMembers := result.GetMembersAsRecipients(func(membership model.GroupMembership) (bool, bool) {
 			return membership.UserID != current.ID, false
 		})
 
-		body := fmt.Sprintf(`
-Violation by: %s %s
-Group title: %s
-Post Title: %s
-Post Body: %s
-Reported by: %s %s
-Reported comment: %s
-	`, current.ExternalID, post.Creator.Name, group.Title, post.Subject, post.Body,
-			current.ExternalID, current.Name, comment)
-
-		return app.notifications.SendNotification(toMembers, nil, subject, body, map[string]string{
-			"type":         "group",
-			"operation":    "report_abuse_post",
-			"entity_type":  "group",
-			"entity_id":    group.ID,
-			"entity_name":  group.Title,
-			"post_id":      post.ID,
-			"post_subject": post.Subject,
-			"post_body":    post.Body,
-		},
+		data := model.NewNotificationTemplateData(group)
+		data.Subject = subject
+		data.UserName = current.Name
+		data.UserExternalID = current.ExternalID
+		data.PostCreatorName = post.Creator.Name
+		data.PostSubject = post.Subject
+		data.PostBody = post.Body
+		data.Comment = comment
+
+		return app.queueTemplatedNotification(context, clientID, &group.ID, model.NotificationOperationReportAbusePost, data,
+			result.GetNotificationLocales(), toMembers, nil, map[string]string{
+				"type":         "group",
+				"operation":    model.NotificationOperationReportAbusePost,
+				"entity_type":  "group",
+				"entity_id":    group.ID,
+				"entity_name":  group.Title,
+				"post_id":      post.ID,
+				"post_subject": post.Subject,
+				"post_body":    post.Body,
+			},
 			current.AppID,
 			current.OrgID,
-			nil,
+			notifications.DefaultPriority,
 		)
 	}
 
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return err
+	}
+
+	if sendToDean {
+		body := fmt.Sprintf(`
+<div>Violation by: %s %s\n</div>
+<div>Group title: %s\n</div>
+<div>Post Title: %s\n</div>
+<div>Post Body: %s\n</div>
+<div>Reported by: %s %s\n</div>
+<div>Reported comment: %s\n</div>
+	`, html.EscapeString(current.ExternalID), html.EscapeString(post.Creator.Name), html.EscapeString(group.Title),
+			html.EscapeString(post.Subject), html.EscapeString(post.Body),
+			html.EscapeString(current.ExternalID), html.EscapeString(current.Name), html.EscapeString(comment))
+		body = strings.ReplaceAll(body, `\n`, "\n")
+		app.notifications.SendMail(app.config.ReportAbuseRecipientEmail, subject, body)
+	}
+
 	return nil
 }
 
 func (app *Application) deletePost(clientID string, userID string, groupID string, postID string, force bool) error {
-	return app.storage.DeletePost(nil, clientID, userID, groupID, postID, force)
+	transaction := func(context storage.TransactionContext) error {
+		err := app.storage.DeletePost(context, clientID, userID, groupID, postID, force)
+		if err != nil {
+			return err
+		}
+
+		// the copies of a cross-posted post follow the origin
+		copies, err := app.storage.FindCrossPostCopies(context, clientID, postID)
+		if err != nil {
+			return fmt.Errorf("error finding cross-posted copies: %s", err)
+		}
+		for _, postCopy := range copies {
+			err = app.storage.DeletePost(context, clientID, userID, postCopy.GroupID, postCopy.ID, true)
+			if err != nil {
+				return fmt.Errorf("error deleting cross-posted copy %s: %s", postCopy.ID, err)
+			}
+		}
+		return nil
+	}
+
+	return app.storage.PerformTransaction(transaction)
 }
 
 func (app *Application) sendGroupNotification(clientID string, notification model.GroupNotification, predicate model.MutePreferencePredicate) error {
@@ -659,24 +999,34 @@ func (app *Application) sendGroupNotification(clientID string, notification mode
 		memberStatuses = []string{"admin", "member"}
 	}
 
-	members, err := app.findGroupMemberships(nil, clientID, model.MembershipFilter{
-		GroupIDs: []string{notification.GroupID},
-		UserIDs:  notification.Members.ToUserIDs(),
-		Statuses: memberStatuses,
-	})
-
-	if err != nil {
-		return err
-	}
+	transaction := func(context storage.TransactionContext) error {
+		userIDs := notification.Members.ToUserIDs()
+		if len(notification.LabelIDs) > 0 {
+			var err error
+			userIDs, err = app.findAudienceUserIDs(context, clientID, notification.GroupID, userIDs, notification.LabelIDs, nil)
+			if err != nil {
+				return err
+			}
+			if len(userIDs) == 0 {
+				// nobody has any of the labels
+				return nil
+			}
+		}
 
-	recipients := members.GetMembersAsNotificationRecipients(predicate)
-	app.sendNotification(recipients, notification.Topic, notification.Subject, notification.Body, notification.Data, app.config.AppID, app.config.OrgID)
+		members, err := app.findGroupMemberships(context, clientID, model.NewServiceMemberViewer(), model.MembershipFilter{
+			GroupIDs: []string{notification.GroupID},
+			UserIDs:  userIDs,
+			Statuses: memberStatuses,
+		})
+		if err != nil {
+			return err
+		}
 
-	return nil
-}
+		recipients := members.GetMembersAsNotificationRecipients(predicate)
+		return app.queueNotification(context, clientID, recipients, notification.Topic, notification.Subject, notification.Body, notification.Data, app.config.AppID, app.config.OrgID, nil)
+	}
 
-func (app *Application) sendNotification(recipients []notifications.Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string) {
-	app.notifications.SendNotification(recipients, topic, title, text, data, appID, orgID, nil)
+	return app.storage.PerformTransaction(transaction)
 }
 
 func (app *Application) getManagedGroupConfigs(clientID string) ([]model.ManagedGroupConfig, error) {
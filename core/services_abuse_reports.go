@@ -0,0 +1,115 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordAbuseReport adds the report to the active abuse case of the target or opens a new case
+func (app *Application) recordAbuseReport(context storage.TransactionContext, clientID string, current *model.User, groupID string, targetType string, targetID string, targetTitle string,
+	comment string, sendToDean bool, sendToGroupAdmins bool) (*model.AbuseReport, error) {
+	report := model.AbuseReport{
+		ID:          uuid.NewString(),
+		ClientID:    clientID,
+		GroupID:     groupID,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		TargetTitle: targetTitle,
+	}
+	entry := model.AbuseReportEntry{
+		ReporterID:         current.ID,
+		ReporterExternalID: current.ExternalID,
+		ReporterName:       current.Name,
+		Comment:            comment,
+		SendToDean:         sendToDean,
+		SendToGroupAdmins:  sendToGroupAdmins,
+		DateCreated:        time.Now(),
+	}
+
+	result, err := app.storage.AddAbuseReport(context, report, entry)
+	if err != nil {
+		log.Printf("error app.recordAbuseReport() - %s", err)
+		return nil, fmt.Errorf("error recording abuse report: %s", err)
+	}
+	return result, nil
+}
+
+func (app *Application) getAbuseReports(clientID string, filter model.AbuseReportsFilter) ([]model.AbuseReport, error) {
+	return app.storage.FindAbuseReports(clientID, filter)
+}
+
+func (app *Application) getAbuseReport(clientID string, id string) (*model.AbuseReport, error) {
+	return app.storage.FindAbuseReport(nil, clientID, id)
+}
+
+func (app *Application) updateAbuseReport(clientID string, current *model.User, id string, status *string, assigneeID *string, resolutionNotes *string) (*model.AbuseReport, error) {
+	if status != nil && !model.IsValidAbuseReportStatus(*status) {
+		return nil, fmt.Errorf("invalid abuse report status %s", *status)
+	}
+
+	var report *model.AbuseReport
+	transaction := func(context storage.TransactionContext) error {
+		var err error
+		report, err = app.storage.FindAbuseReport(context, clientID, id)
+		if err != nil {
+			return fmt.Errorf("error finding abuse report: %s", err)
+		}
+		if report == nil {
+			return fmt.Errorf("missing abuse report for id %s", id)
+		}
+		if report.IsClosed() {
+			return fmt.Errorf("the abuse report %s is already closed", id)
+		}
+
+		now := time.Now()
+		if assigneeID != nil {
+			if len(*assigneeID) > 0 {
+				report.AssigneeID = assigneeID
+			} else {
+				report.AssigneeID = nil
+			}
+		}
+		if resolutionNotes != nil {
+			report.ResolutionNotes = *resolutionNotes
+		}
+		if status != nil {
+			report.Status = *status
+			if report.IsClosed() {
+				report.ResolvedBy = &current.ID
+				report.DateResolved = &now
+			}
+		}
+		report.DateUpdated = &now
+
+		err = app.storage.UpdateAbuseReport(context, *report)
+		if err != nil {
+			return fmt.Errorf("error updating abuse report: %s", err)
+		}
+		return nil
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -28,5 +28,28 @@ func (app *Application) analyticsFindPosts(groupID *string, startDate *time.Time
 }
 
 func (app *Application) analyticsFindMembers(groupID *string, startDate *time.Time, endDate *time.Time) ([]model.GroupMembership, error) {
-	return app.storage.AnalyticsFindMembers(groupID, startDate, endDate)
+	members, err := app.storage.AnalyticsFindMembers(groupID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// the members of all the clients are returned so the policies are applied per client
+	indexesByClient := map[string][]int{}
+	for i, member := range members {
+		indexesByClient[member.ClientID] = append(indexesByClient[member.ClientID], i)
+	}
+	for clientID, indexes := range indexesByClient {
+		clientMembers := make([]model.GroupMembership, len(indexes))
+		for i, index := range indexes {
+			clientMembers[i] = members[index]
+		}
+		err = app.applyMemberVisibility(clientID, model.NewServiceMemberViewer(), clientMembers)
+		if err != nil {
+			return nil, err
+		}
+		for i, index := range indexes {
+			members[index] = clientMembers[i]
+		}
+	}
+	return members, nil
 }
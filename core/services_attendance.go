@@ -0,0 +1,422 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// checkAttendanceAdmin checks that the group is an attendance group and the current user is its admin
+func checkAttendanceAdmin(group *model.Group) error {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return fmt.Errorf("only group admins can manage the attendance")
+	}
+	if !group.AttendanceGroup {
+		return fmt.Errorf("the group %s is not an attendance group", group.ID)
+	}
+	return nil
+}
+
+func (app *Application) getAttendanceSessions(clientID string, current *model.User, group *model.Group) ([]model.AttendanceSession, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+	return app.storage.FindAttendanceSessions(clientID, group.ID)
+}
+
+func (app *Application) createAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) (*model.AttendanceSession, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+	err := app.validateAttendanceSession(clientID, group, &session)
+	if err != nil {
+		return nil, err
+	}
+
+	session.ID = uuid.NewString()
+	session.ClientID = clientID
+	session.GroupID = group.ID
+	session.CreatedBy = current.ID
+	session.DateCreated = time.Now().UTC()
+	session.DateUpdated = nil
+	session.CheckInWindow = nil
+
+	err = app.storage.InsertAttendanceSession(session)
+	if err != nil {
+		return nil, fmt.Errorf("error creating attendance session: %s", err)
+	}
+	return &session, nil
+}
+
+func (app *Application) updateAttendanceSession(clientID string, current *model.User, group *model.Group, session model.AttendanceSession) error {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return err
+	}
+	err := app.validateAttendanceSession(clientID, group, &session)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	session.ClientID = clientID
+	session.GroupID = group.ID
+	session.DateUpdated = &now
+	return app.storage.UpdateAttendanceSession(session)
+}
+
+func (app *Application) deleteAttendanceSession(clientID string, current *model.User, group *model.Group, id string) error {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return err
+	}
+	return app.storage.DeleteAttendanceSession(clientID, group.ID, id)
+}
+
+// validateAttendanceSession validates the session and checks that the linked event belongs to the group
+func (app *Application) validateAttendanceSession(clientID string, group *model.Group, session *model.AttendanceSession) error {
+	err := session.Validate()
+	if err != nil {
+		return err
+	}
+	if session.EventID == nil {
+		return nil
+	}
+
+	events, err := app.storage.FindEvents(clientID, nil, group.ID, false)
+	if err != nil {
+		return fmt.Errorf("error finding the events of group %s: %s", group.ID, err)
+	}
+	for _, event := range events {
+		if event.EventID == *session.EventID {
+			return nil
+		}
+	}
+	return fmt.Errorf("the event %s is not linked to group %s", *session.EventID, group.ID)
+}
+
+func (app *Application) getAttendanceRecords(clientID string, current *model.User, group *model.Group, sessionID string) ([]model.AttendanceRecord, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+	records, err := app.storage.FindAttendanceRecords(clientID, group.ID, &sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, len(records))
+	names := make([]*string, len(records))
+	for i := range records {
+		userIDs[i] = records[i].UserID
+		names[i] = &records[i].Name
+	}
+	err = app.applyAttendanceNameVisibility(clientID, current, group.ID, userIDs, names)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (app *Application) checkInAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return err
+	}
+	session, memberships, err := app.findAttendanceMemberships(clientID, group, sessionID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	records := make([]model.AttendanceRecord, len(memberships))
+	for i, membership := range memberships {
+		records[i] = model.AttendanceRecord{ID: uuid.NewString(), ClientID: clientID, GroupID: group.ID, SessionID: session.ID,
+			MembershipID: membership.ID, UserID: membership.UserID, Name: membership.Name, Source: model.AttendanceSourceAdmin,
+			CheckedInBy: current.ID, DateCheckedIn: now}
+	}
+	return app.storage.CheckInAttendance(clientID, group.ID, records)
+}
+
+func (app *Application) checkOutAttendance(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return err
+	}
+	session, memberships, err := app.findAttendanceMemberships(clientID, group, sessionID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	membershipIDs := make([]string, len(memberships))
+	for i, membership := range memberships {
+		membershipIDs[i] = membership.ID
+	}
+	return app.storage.CheckOutAttendance(clientID, group.ID, session.ID, membershipIDs, current.ID, time.Now().UTC())
+}
+
+// findAttendanceMemberships finds the session and the memberships of the users. All users must be members or admins of the group.
+func (app *Application) findAttendanceMemberships(clientID string, group *model.Group, sessionID string, userIDs []string) (*model.AttendanceSession, []model.GroupMembership, error) {
+	if len(userIDs) == 0 {
+		return nil, nil, fmt.Errorf("the user ids are required")
+	}
+	session, err := app.storage.FindAttendanceSession(clientID, group.ID, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finding attendance session %s: %s", sessionID, err)
+	}
+	if session == nil {
+		return nil, nil, fmt.Errorf("the attendance session %s does not exist", sessionID)
+	}
+
+	memberships, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
+		GroupIDs: []string{group.ID},
+		UserIDs:  userIDs,
+		Statuses: []string{model.MembershipStatusMember, model.MembershipStatusAdmin},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finding the memberships of group %s: %s", group.ID, err)
+	}
+	if len(memberships.Items) != len(userIDs) {
+		found := map[string]bool{}
+		for _, membership := range memberships.Items {
+			found[membership.UserID] = true
+		}
+		missing := []string{}
+		for _, userID := range userIDs {
+			if !found[userID] {
+				missing = append(missing, userID)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, nil, fmt.Errorf("the users %s are not members of group %s", strings.Join(missing, ", "), group.ID)
+		}
+	}
+	return session, memberships.Items, nil
+}
+
+func (app *Application) getAttendanceReport(clientID string, current *model.User, group *model.Group) (*model.AttendanceReport, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+
+	sessions, err := app.storage.FindAttendanceSessions(clientID, group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the attendance sessions of group %s: %s", group.ID, err)
+	}
+	records, err := app.storage.FindAttendanceRecords(clientID, group.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the attendance records of group %s: %s", group.ID, err)
+	}
+	memberships, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
+		GroupIDs: []string{group.ID},
+		Statuses: []string{model.MembershipStatusMember, model.MembershipStatusAdmin},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error finding the memberships of group %s: %s", group.ID, err)
+	}
+
+	report := model.NewAttendanceReport(sessions, records, memberships.Items)
+
+	userIDs := make([]string, len(report.Members))
+	names := make([]*string, len(report.Members))
+	for i := range report.Members {
+		userIDs[i] = report.Members[i].UserID
+		names[i] = &report.Members[i].Name
+	}
+	err = app.applyAttendanceNameVisibility(clientID, current, group.ID, userIDs, names)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// applyAttendanceNameVisibility clears the names of the members which the member visibility policy hides from the current user.
+// The attendance keeps the names outside of the memberships, so they are checked as memberships of the group.
+func (app *Application) applyAttendanceNameVisibility(clientID string, current *model.User, groupID string, userIDs []string, names []*string) error {
+	memberships := make([]model.GroupMembership, len(userIDs))
+	for i := range userIDs {
+		memberships[i] = model.GroupMembership{GroupID: groupID, UserID: userIDs[i], Name: *names[i]}
+	}
+	err := app.applyMemberVisibility(clientID, model.NewUserMemberViewer(current), memberships)
+	if err != nil {
+		return fmt.Errorf("error applying the member visibility to the attendance of group %s: %s", groupID, err)
+	}
+	for i := range memberships {
+		*names[i] = memberships[i].Name
+	}
+	return nil
+}
+
+func (app *Application) openAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string,
+	dateOpens *time.Time, dateCloses time.Time, codePeriod int, autoAddNonMembers bool) (*model.AttendanceSession, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+	session, err := app.storage.FindAttendanceSession(clientID, group.ID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding attendance session %s: %s", sessionID, err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("the attendance session %s does not exist", sessionID)
+	}
+
+	now := time.Now().UTC()
+	opens := now
+	if dateOpens != nil {
+		opens = *dateOpens
+	}
+	if !dateCloses.After(now) {
+		return nil, fmt.Errorf("the check-in window must close in the future")
+	}
+	window, err := model.NewAttendanceCheckInWindow(opens, dateCloses, codePeriod, autoAddNonMembers, current.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.storage.SetAttendanceCheckInWindow(clientID, group.ID, sessionID, window)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the check-in window of session %s: %s", sessionID, err)
+	}
+	session.CheckInWindow = window
+	return session, nil
+}
+
+func (app *Application) closeAttendanceCheckInWindow(clientID string, current *model.User, group *model.Group, sessionID string) error {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return err
+	}
+	return app.storage.SetAttendanceCheckInWindow(clientID, group.ID, sessionID, nil)
+}
+
+func (app *Application) getAttendanceCheckInCode(clientID string, current *model.User, group *model.Group, sessionID string) (*model.AttendanceCheckInCode, error) {
+	if err := checkAttendanceAdmin(group); err != nil {
+		return nil, err
+	}
+	session, err := app.storage.FindAttendanceSession(clientID, group.ID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding attendance session %s: %s", sessionID, err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("the attendance session %s does not exist", sessionID)
+	}
+	return model.NewAttendanceCheckInCode(*session, time.Now().UTC())
+}
+
+// selfCheckInAttendance checks in the current user with the code displayed by the admins. The session id is optional as the code
+// identifies the session among the open ones. The wrong codes are counted so that the codes cannot be guessed.
+func (app *Application) selfCheckInAttendance(clientID string, current *model.User, group *model.Group, sessionID *string, code string) (*model.AttendanceRecord, error) {
+	if group == nil {
+		return nil, fmt.Errorf("the group does not exist")
+	}
+	if !group.AttendanceGroup {
+		return nil, fmt.Errorf("the group %s is not an attendance group", group.ID)
+	}
+	membership := group.CurrentMember
+	if membership != nil && membership.IsRejected() {
+		return nil, fmt.Errorf("the user %s is rejected for group %s", current.ID, group.ID)
+	}
+
+	now := time.Now().UTC()
+	failures, err := app.storage.CountAttendanceCheckInFailures(clientID, group.ID, current.ID, now.Add(-model.AttendanceCheckInFailuresPeriod))
+	if err != nil {
+		return nil, fmt.Errorf("error counting the check-in failures of user %s: %s", current.ID, err)
+	}
+	if failures >= model.MaxAttendanceCheckInFailures {
+		return nil, model.ErrTooManyAttendanceCheckInFailures
+	}
+
+	session, err := app.findAttendanceSessionByCode(clientID, group.ID, sessionID, code, now)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		err = app.storage.InsertAttendanceCheckInFailure(clientID, group.ID, current.ID, now)
+		if err != nil {
+			log.Printf("error recording the check-in failure of user %s: %s", current.ID, err)
+		}
+		return nil, model.ErrInvalidAttendanceCode
+	}
+
+	if membership == nil || membership.IsPendingMember() {
+		if !session.CheckInWindow.AutoAddNonMembers {
+			return nil, fmt.Errorf("only the members of group %s may check in", group.ID)
+		}
+	}
+	if membership == nil {
+		membership, err = app.addAttendanceMembership(clientID, current, group)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record := model.AttendanceRecord{ID: uuid.NewString(), ClientID: clientID, GroupID: group.ID, SessionID: session.ID,
+		MembershipID: membership.ID, UserID: current.ID, Name: membership.Name, Source: model.AttendanceSourceSelf,
+		CheckedInBy: current.ID, DateCheckedIn: now}
+	err = app.storage.CheckInAttendance(clientID, group.ID, []model.AttendanceRecord{record})
+	if err != nil {
+		return nil, fmt.Errorf("error checking in user %s: %s", current.ID, err)
+	}
+	return &record, nil
+}
+
+// findAttendanceSessionByCode finds the session whose check-in window accepts the code. Returns nil if there is no such session.
+func (app *Application) findAttendanceSessionByCode(clientID string, groupID string, sessionID *string, code string, now time.Time) (*model.AttendanceSession, error) {
+	var sessions []model.AttendanceSession
+	if sessionID != nil {
+		session, err := app.storage.FindAttendanceSession(clientID, groupID, *sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("error finding attendance session %s: %s", *sessionID, err)
+		}
+		if session != nil {
+			sessions = append(sessions, *session)
+		}
+	} else {
+		var err error
+		sessions, err = app.storage.FindOpenAttendanceSessions(clientID, groupID, now)
+		if err != nil {
+			return nil, fmt.Errorf("error finding the open attendance sessions of group %s: %s", groupID, err)
+		}
+	}
+
+	for _, session := range sessions {
+		if session.CheckInWindow != nil && session.CheckInWindow.VerifyCode(code, now) {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+// addAttendanceMembership requests a membership for a user who checks in to a group which they are not a member of
+func (app *Application) addAttendanceMembership(clientID string, current *model.User, group *model.Group) (*model.GroupMembership, error) {
+	answers := make([]model.MemberAnswer, len(group.MembershipQuestions))
+	for i, question := range group.MembershipQuestions {
+		answers[i] = model.MemberAnswer{Question: question}
+	}
+	membership := &model.GroupMembership{
+		UserID:        current.ID,
+		ExternalID:    current.ExternalID,
+		Name:          current.Name,
+		NetID:         current.NetID,
+		Email:         current.Email,
+		MemberAnswers: answers,
+	}
+
+	err := app.createPendingMembership(clientID, current, group, membership)
+	if err != nil {
+		return nil, fmt.Errorf("error adding user %s to group %s: %s", current.ID, group.ID, err)
+	}
+	return membership, nil
+}
@@ -83,6 +83,8 @@ func (app *Application) synchronizeAuthman(clientID string, checkThreshold bool)
 			return
 		}
 		log.Printf("Global Authman synchronization finished for clientID: %s\n", clientID)
+		app.publishCommittedWebhookEvent(clientID, model.WebhookEventSyncCompleted,
+			model.WebhookSyncCompletedData{StartTime: &startTime, EndTime: &endTime})
 	}
 	defer finishAuthmanSync()
 
@@ -164,7 +166,7 @@ func (app *Application) synchronizeAuthman(clientID string, checkThreshold bool)
 									if member.ExternalID == uin {
 										if member.Status != "admin" {
 											now := time.Now()
-											member.Status = "admin"
+											member.ChangeStatus(model.MembershipStatusAdmin, model.MembershipTransitionSourceSync, nil, "")
 											member.DateUpdated = &now
 											membershipsForUpdate = append(membershipsForUpdate, member)
 											groupUpdated = true
@@ -241,24 +243,31 @@ func (app *Application) buildMembersByExternalIDs(clientID string, externalIDs [
 		}
 
 		for _, externalID := range externalIDs {
+			// the synchronization may create members and admins
+			history := []model.MembershipTransition{}
+			if transition, err := model.NewMembershipTransition("", memberStatus, model.MembershipTransitionSourceSync, nil, ""); err == nil {
+				history = append(history, *transition)
+			}
 			if value, ok := userExternalIDmapping[externalID]; ok {
 				members = append(members, model.GroupMembership{
-					ID:          uuid.NewString(),
-					ClientID:    clientID,
-					UserID:      value.ID,
-					ExternalID:  externalID,
-					Name:        value.GetFullName(),
-					Email:       value.Profile.Email,
-					Status:      memberStatus,
-					DateCreated: time.Now(),
+					ID:            uuid.NewString(),
+					ClientID:      clientID,
+					UserID:        value.ID,
+					ExternalID:    externalID,
+					Name:          value.GetFullName(),
+					Email:         value.Profile.Email,
+					Status:        memberStatus,
+					StatusHistory: history,
+					DateCreated:   time.Now(),
 				})
 			} else {
 				members = append(members, model.GroupMembership{
-					ID:          uuid.NewString(),
-					ClientID:    clientID,
-					ExternalID:  externalID,
-					Status:      memberStatus,
-					DateCreated: time.Now(),
+					ID:            uuid.NewString(),
+					ClientID:      clientID,
+					ExternalID:    externalID,
+					Status:        memberStatus,
+					StatusHistory: history,
+					DateCreated:   time.Now(),
 				})
 			}
 		}
@@ -295,6 +304,8 @@ func (app *Application) synchronizeAuthmanGroup(clientID string, groupID string)
 			return
 		}
 		log.Printf("Authman synchronization for group %s finished", *group.AuthmanGroup)
+		app.publishCommittedWebhookEvent(clientID, model.WebhookEventSyncCompleted,
+			model.WebhookSyncCompletedData{GroupID: &group.ID, AuthmanGroup: group.AuthmanGroup, StartTime: group.SyncStartTime, EndTime: group.SyncEndTime})
 	}
 	defer finishAuthmanSync()
 
@@ -434,7 +445,19 @@ func (app *Application) syncAuthmanGroupMemberships(clientID string, authmanGrou
 			}
 		}
 
-		err = app.storage.BulkUpdateGroupMembershipsByExternalID(clientID, authmanGroup.ID, updateOperations, false)
+		err = app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+			created, err := app.storage.BulkUpdateGroupMembershipsByExternalID(context, clientID, authmanGroup.ID, updateOperations, false)
+			if err != nil {
+				return err
+			}
+			for _, membership := range created {
+				err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipCreated, membership)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 		if err != nil {
 			log.Printf("Error on bulk saving step: %d, items: %d memberships, core accounts: %d in Authman %s: %s\n", step, len(updateOperations), len(localUsers), *authmanGroup.AuthmanGroup, err)
 		} else {
@@ -479,11 +502,24 @@ func (app *Application) syncAuthmanGroupMemberships(clientID string, authmanGrou
 
 	// Delete removed non-admin members
 	log.Printf("Deleting removed members for Authman %s...\n", *authmanGroup.AuthmanGroup)
-	deleteCount, err := app.storage.DeleteUnsyncedGroupMemberships(clientID, authmanGroup.ID, syncID)
+	var deleted []model.GroupMembership
+	err = app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		deleted, err = app.storage.DeleteUnsyncedGroupMemberships(context, clientID, authmanGroup.ID, syncID)
+		if err != nil {
+			return err
+		}
+		for _, membership := range deleted {
+			err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipRemoved, membership)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		log.Printf("Error deleting removed memberships in Authman %s\n", *authmanGroup.AuthmanGroup)
 	} else {
-		log.Printf("%d memberships removed from Authman %s\n", deleteCount, *authmanGroup.AuthmanGroup)
+		log.Printf("%d memberships removed from Authman %s\n", len(deleted), *authmanGroup.AuthmanGroup)
 	}
 
 	err = app.storage.UpdateGroupStats(nil, clientID, authmanGroup.ID, false, false, true, true)
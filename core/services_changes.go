@@ -0,0 +1,42 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"groups/core/model"
+	"time"
+)
+
+const (
+	changeFeedDefaultLimit = 100
+	changeFeedMaxLimit     = 1000
+	changeFeedDefaultWait  = 20 // seconds
+	changeFeedMaxWait      = 60 // seconds
+)
+
+func (app *Application) getChanges(clientID string, since *string, limit *int64, wait *int64) (*model.ChangeBatch, error) {
+	changesLimit := changeFeedDefaultLimit
+	if limit != nil && *limit > 0 {
+		changesLimit = int(min(*limit, changeFeedMaxLimit))
+	}
+	waitSeconds := int64(changeFeedDefaultWait)
+	if wait != nil && *wait >= 0 {
+		waitSeconds = min(*wait, changeFeedMaxWait)
+	}
+	// a zero wait still needs a moment to read the already available changes
+	maxWait := max(time.Duration(waitSeconds)*time.Second, 500*time.Millisecond)
+
+	return app.storage.FindChanges(clientID, since, changesLimit, maxWait)
+}
@@ -0,0 +1,211 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxContentFilterPatternLength is the max length of a single content filter pattern
+const maxContentFilterPatternLength = 512
+
+// compiledContentFilter keeps the expressions of a content filter together with the version they were compiled from
+type compiledContentFilter struct {
+	version     time.Time
+	expressions []*regexp.Regexp
+}
+
+// contentFilterCache keeps the compiled expressions of the content filters so they are not compiled for every post.
+// An entry is compiled again once the filter is updated, so the changes made through other instances are picked up too.
+type contentFilterCache struct {
+	lock    sync.RWMutex
+	entries map[string]compiledContentFilter
+}
+
+func newContentFilterCache() *contentFilterCache {
+	return &contentFilterCache{entries: map[string]compiledContentFilter{}}
+}
+
+// expressions gives the compiled expressions of the filter, compiling them when the filter is not cached or has changed
+func (c *contentFilterCache) expressions(filter model.ContentFilter) ([]*regexp.Regexp, error) {
+	version := filter.DateCreated
+	if filter.DateUpdated != nil {
+		version = *filter.DateUpdated
+	}
+
+	c.lock.RLock()
+	entry, ok := c.entries[filter.ID]
+	c.lock.RUnlock()
+	if ok && entry.version.Equal(version) {
+		return entry.expressions, nil
+	}
+
+	expressions, err := compileContentFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[filter.ID] = compiledContentFilter{version: version, expressions: expressions}
+	c.lock.Unlock()
+	return expressions, nil
+}
+
+// invalidate removes the compiled expressions of the filter
+func (c *contentFilterCache) invalidate(id string) {
+	c.lock.Lock()
+	delete(c.entries, id)
+	c.lock.Unlock()
+}
+
+// compileContentFilter builds the case insensitive expressions for the words and the patterns of the filter
+func compileContentFilter(filter model.ContentFilter) ([]*regexp.Regexp, error) {
+	expressions := make([]*regexp.Regexp, 0, len(filter.Words)+len(filter.Patterns))
+	for _, word := range filter.Words {
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+		expressions = append(expressions, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`\b`))
+	}
+	for _, pattern := range filter.Patterns {
+		if pattern == "" {
+			continue
+		}
+		if len(pattern) > maxContentFilterPatternLength {
+			return nil, fmt.Errorf("pattern %s exceeds the max length of %d", pattern, maxContentFilterPatternLength)
+		}
+		expression, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %s", pattern, err)
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+// applyContentFilters checks the subject and the body of the post against the content filters of the client and the group.
+// Depending on the group settings a matching post is rejected, held for a group admin review or its matched words are masked.
+func (app *Application) applyContentFilters(clientID string, group *model.Group, post *model.Post) error {
+	if group == nil || post == nil {
+		return nil
+	}
+
+	filters, err := app.storage.FindContentFilters(clientID, &group.ID)
+	if err != nil {
+		return fmt.Errorf("error finding content filters: %s", err)
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+
+	action := model.ContentFilterActionReject
+	if group.Settings != nil {
+		action = group.Settings.PostPreferences.GetContentFilterAction()
+	}
+
+	mask := func(match string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(match))
+	}
+
+	matched := false
+	for _, filter := range filters {
+		expressions, err := app.contentFilters.expressions(filter)
+		if err != nil {
+			log.Printf("error app.applyContentFilters() - content filter %s: %s", filter.ID, err)
+			continue
+		}
+		for _, expression := range expressions {
+			if !expression.MatchString(post.Subject) && !expression.MatchString(post.Body) {
+				continue
+			}
+			matched = true
+			if action != model.ContentFilterActionMask {
+				break
+			}
+			post.Subject = expression.ReplaceAllStringFunc(post.Subject, mask)
+			post.Body = expression.ReplaceAllStringFunc(post.Body, mask)
+		}
+		if matched && action != model.ContentFilterActionMask {
+			break
+		}
+	}
+	if !matched {
+		return nil
+	}
+
+	switch action {
+	case model.ContentFilterActionHold:
+		post.Status = model.PostStatusPendingReview
+	case model.ContentFilterActionReject:
+		return fmt.Errorf("the post contains blocked content")
+	}
+	return nil
+}
+
+func (app *Application) getContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error) {
+	return app.storage.FindContentFilters(clientID, groupID)
+}
+
+func (app *Application) createContentFilter(clientID string, current *model.User, filter model.ContentFilter) (*model.ContentFilter, error) {
+	_, err := compileContentFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.ID = uuid.NewString()
+	filter.ClientID = clientID
+	filter.CreatedBy = current.ID
+	filter.DateCreated = time.Now()
+	filter.DateUpdated = nil
+	err = app.storage.InsertContentFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+func (app *Application) updateContentFilter(clientID string, filter model.ContentFilter) error {
+	_, err := compileContentFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	filter.ClientID = clientID
+	err = app.storage.UpdateContentFilter(filter)
+	if err != nil {
+		return err
+	}
+	app.contentFilters.invalidate(filter.ID)
+	return nil
+}
+
+func (app *Application) deleteContentFilter(clientID string, id string) error {
+	err := app.storage.DeleteContentFilter(id, clientID)
+	if err != nil {
+		return err
+	}
+	app.contentFilters.invalidate(id)
+	return nil
+}
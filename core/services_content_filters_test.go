@@ -0,0 +1,137 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"groups/core/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+// contentFiltersStorage returns the same content filters for every group
+type contentFiltersStorage struct {
+	Storage
+	filters []model.ContentFilter
+}
+
+func (s *contentFiltersStorage) FindContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error) {
+	return s.filters, nil
+}
+
+func TestCompileContentFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		words     []string
+		patterns  []string
+		wantCount int
+		wantErr   bool
+	}{
+		{"words and patterns", []string{"spam", " scam "}, []string{`free\s+money`}, 3, false},
+		{"empty words and patterns are skipped", []string{"", "  "}, []string{""}, 0, false},
+		{"words are quoted", []string{"a.b(c"}, nil, 1, false},
+		{"invalid pattern", nil, []string{"(unclosed"}, 0, true},
+		{"long pattern", nil, []string{strings.Repeat("a", maxContentFilterPatternLength+1)}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expressions, err := compileContentFilter(model.ContentFilter{Words: tt.words, Patterns: tt.patterns})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compileContentFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(expressions) != tt.wantCount {
+				t.Errorf("compileContentFilter() count = %d, want %d", len(expressions), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestApplyContentFilters(t *testing.T) {
+	filters := []model.ContentFilter{
+		{ID: "f1", Words: []string{"spam"}, DateCreated: time.Now()},
+		{ID: "f2", Patterns: []string{`free\s+money`}, DateCreated: time.Now()},
+	}
+	app := &Application{storage: &contentFiltersStorage{filters: filters}, contentFilters: newContentFilterCache()}
+
+	tests := []struct {
+		name        string
+		action      string
+		subject     string
+		body        string
+		wantErr     bool
+		wantSubject string
+		wantBody    string
+		wantStatus  string
+	}{
+		{"no match", model.ContentFilterActionReject, "Hello", "Spammer is not a whole word", false, "Hello", "Spammer is not a whole word", ""},
+		{"reject", model.ContentFilterActionReject, "Hello", "This is SPAM", true, "", "", ""},
+		{"default action rejects", "", "Free  money", "Hello", true, "", "", ""},
+		{"hold", model.ContentFilterActionHold, "Hello", "This is spam", false, "Hello", "This is spam", model.PostStatusPendingReview},
+		{"mask", model.ContentFilterActionMask, "Spam here", "Get free money, no spam", false, "**** here", "Get **********, no ****", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := model.DefaultGroupSettings()
+			settings.PostPreferences.ContentFilterAction = tt.action
+			group := &model.Group{ID: "g1", Settings: &settings}
+			post := &model.Post{Subject: tt.subject, Body: tt.body}
+
+			err := app.applyContentFilters("client", group, post)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyContentFilters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if post.Subject != tt.wantSubject || post.Body != tt.wantBody {
+				t.Errorf("applyContentFilters() = %q %q, want %q %q", post.Subject, post.Body, tt.wantSubject, tt.wantBody)
+			}
+			if post.Status != tt.wantStatus {
+				t.Errorf("applyContentFilters() status = %q, want %q", post.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestContentFilterCache(t *testing.T) {
+	cache := newContentFilterCache()
+	created := time.Now()
+	filter := model.ContentFilter{ID: "f1", Words: []string{"spam"}, DateCreated: created}
+
+	first, err := cache.expressions(filter)
+	if err != nil {
+		t.Fatalf("expressions() error = %v", err)
+	}
+	second, _ := cache.expressions(filter)
+	if first[0] != second[0] {
+		t.Error("expressions() must reuse the compiled expressions of an unchanged filter")
+	}
+
+	updated := created.Add(time.Minute)
+	filter.Words = []string{"scam"}
+	filter.DateUpdated = &updated
+	third, _ := cache.expressions(filter)
+	if !third[0].MatchString("scam") {
+		t.Error("expressions() must compile the updated filter again")
+	}
+
+	cache.invalidate(filter.ID)
+	fourth, _ := cache.expressions(filter)
+	if fourth[0] == third[0] {
+		t.Error("expressions() must compile the invalidated filter again")
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"log"
+)
+
+// validateCrossPostGroup checks if the current user is allowed to post to all members of the group
+func validateCrossPostGroup(group *model.Group) error {
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdminOrMember() {
+		return fmt.Errorf("the user is not member or admin of the group %s", group.Title)
+	}
+	if group.CurrentMember.IsMember() && group.Settings != nil &&
+		(!group.Settings.PostPreferences.AllowSendPost || !group.Settings.PostPreferences.CanSendPostToAll) {
+		return fmt.Errorf("posts are not allowed for group %s", group.Title)
+	}
+	return nil
+}
+
+// crossPost creates a copy of the post within every group. The first group gets the origin post and the other groups get copies linked to it.
+func (app *Application) crossPost(clientID string, current *model.User, post *model.Post, groupIDs []string) ([]model.Post, error) {
+	if post.ParentID != nil {
+		return nil, fmt.Errorf("only top level posts can be cross-posted")
+	}
+	if len(post.ToMembersList) > 0 {
+		return nil, fmt.Errorf("posts to specific members cannot be cross-posted")
+	}
+	if len(post.ToLabelIDs) > 0 {
+		return nil, fmt.Errorf("posts to member labels cannot be cross-posted")
+	}
+
+	groups := make([]*model.Group, 0, len(groupIDs))
+	added := map[string]bool{}
+	for _, groupID := range groupIDs {
+		if added[groupID] {
+			continue
+		}
+		added[groupID] = true
+
+		group, err := app.getGroup(clientID, current, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("error finding group %s: %s", groupID, err)
+		}
+		if group == nil {
+			return nil, fmt.Errorf("missing group for id %s", groupID)
+		}
+		err = validateCrossPostGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if len(groups) < 2 {
+		return nil, fmt.Errorf("at least two groups are required for cross-posting")
+	}
+
+	var posts []model.Post
+	transaction := func(context storage.TransactionContext) error {
+		posts = make([]model.Post, 0, len(groups))
+		var originID *string
+		for _, group := range groups {
+			groupPost := *post
+			groupPost.ID = ""
+			groupPost.GroupID = group.ID
+			groupPost.OriginID = originID
+			groupPost.SeriesID = nil
+			groupPost.Reactions = nil
+			groupPost.ReactionCounts = nil
+
+			createdPost, err := app.insertPost(context, clientID, current, &groupPost, group)
+			if err != nil {
+				return fmt.Errorf("error cross-posting to group %s: %s", group.Title, err)
+			}
+			if originID == nil {
+				originID = &createdPost.ID
+			}
+			posts = append(posts, *createdPost)
+
+			err = app.publishWebhookEvent(context, clientID, model.WebhookEventPostCreated, createdPost)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := app.sendCrossPostNotifications(context, clientID, &current.ID, &current.Name, groups, posts)
+		if err != nil {
+			return err
+		}
+		for i, createdPost := range posts {
+			if !createdPost.IsPublished() {
+				err := app.sendGroupNotificationForPendingPost(context, clientID, current, groups[i], &posts[i])
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	go app.createPostRewards(clientID, current)
+
+	return posts, nil
+}
+
+// sendCrossPostNotifications queues notifications for the members of all groups about the published copies of the cross-posted post. Every user is notified only once.
+func (app *Application) sendCrossPostNotifications(context storage.TransactionContext, clientID string, currentUserID *string, currentUserName *string, groups []*model.Group, posts []model.Post) error {
+	notifiedUserIDs := map[string]bool{}
+	if currentUserID != nil {
+		notifiedUserIDs[*currentUserID] = true
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		if !post.IsPublished() {
+			continue
+		}
+
+		var group *model.Group
+		for _, item := range groups {
+			if item.ID == post.GroupID {
+				group = item
+				break
+			}
+		}
+		if group == nil {
+			continue
+		}
+
+		err := app.sendGroupNotificationForNewPostSkipping(context, clientID, currentUserID, currentUserName, group, post, notifiedUserIDs)
+		if err != nil {
+			log.Printf("error sending cross-post notification for group %s - %s", group.ID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// crossPostCopyUpdate is an edit of the origin post prepared for one of its copies
+type crossPostCopyUpdate struct {
+	group *model.Group
+	post  model.Post
+	hold  bool
+}
+
+// prepareCrossPostCopies applies the edit of the origin post to every copy and checks it against the content filters of the copy group
+func (app *Application) prepareCrossPostCopies(clientID string, post *model.Post) ([]crossPostCopyUpdate, error) {
+	if post == nil || post.IsCrossPostCopy() {
+		return nil, nil
+	}
+
+	copies, err := app.storage.FindCrossPostCopies(nil, clientID, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding cross-posted copies: %s", err)
+	}
+
+	updates := make([]crossPostCopyUpdate, 0, len(copies))
+	for _, postCopy := range copies {
+		group, err := app.storage.FindGroup(nil, clientID, postCopy.GroupID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error finding group %s: %s", postCopy.GroupID, err)
+		}
+		if group == nil {
+			continue
+		}
+
+		postCopy.Subject = post.Subject
+		postCopy.Body = post.Body
+		postCopy.BodyFormat = post.BodyFormat
+		postCopy.Private = post.Private
+		postCopy.UseAsNotification = post.UseAsNotification
+		postCopy.ImageURL = post.ImageURL
+		postCopy.DateScheduled = post.DateScheduled
+		postCopy.DateExpires = post.DateExpires
+
+		postCopy.Status = ""
+		err = app.applyContentFilters(clientID, group, &postCopy)
+		if err != nil {
+			return nil, fmt.Errorf("the copy within group %s: %s", group.Title, err)
+		}
+		hold := postCopy.Status == model.PostStatusPendingReview
+
+		err = postCopy.RenderBody()
+		if err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, crossPostCopyUpdate{group: group, post: postCopy, hold: hold})
+	}
+	return updates, nil
+}
+
+// updateCrossPostCopies stores the prepared copies of the updated origin post and holds for review the ones caught by the content filters
+func (app *Application) updateCrossPostCopies(context storage.TransactionContext, clientID string, current *model.User, updates []crossPostCopyUpdate) error {
+	for _, update := range updates {
+		err := app.storage.UpdateCrossPostCopy(context, clientID, update.post)
+		if err != nil {
+			return err
+		}
+
+		if update.hold {
+			err = app.storage.UpdatePostStatus(context, clientID, update.post.GroupID, update.post.ID, model.PostStatusPendingReview, nil, "")
+			if err != nil {
+				return fmt.Errorf("error holding the copy within group %s for review: %s", update.group.Title, err)
+			}
+
+			postCopy := update.post
+			postCopy.Status = model.PostStatusPendingReview
+			err = app.sendGroupNotificationForPendingPost(context, clientID, current, update.group, &postCopy)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"html"
+	"log"
+	"strings"
+	"time"
+)
+
+// maxDigestListedPosts is the max count of posts which are listed for a group within the digest. The rest are only counted.
+const maxDigestListedPosts = 10
+
+// digestGroupActivity wraps the activity of a group for the digest period
+type digestGroupActivity struct {
+	group  model.Group
+	posts  []model.Post
+	events []model.Event
+
+	topPosts map[string]*model.Post // the top level posts of the replies
+
+	pendingMembershipsCount *int64
+	pendingPostsCount       *int64
+}
+
+func (app *Application) processDigests() error {
+	log.Printf("processDigests:BEGIN")
+	defer log.Printf("processDigests:END")
+
+	startTime := time.Now()
+	syncKey := "digests"
+	var memberships []model.GroupMembership
+	transaction := func(context storage.TransactionContext) error {
+		// the run stays open until the digests are sent so that another run does not send the same digests
+		err := app.checkForConcurentRun(context, startTime, syncKey)
+		if err != nil {
+			return err
+		}
+
+		memberships, err = app.storage.FindDigestMemberships(context, startTime)
+		return err
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		log.Printf("processDigests task error: %s", err)
+		return err
+	}
+
+	log.Printf("processDigests: Found %d memberships with due digest", len(memberships))
+	digestedIDs, sendErr := app.sendDigests(memberships, startTime)
+	if sendErr != nil {
+		log.Printf("processDigests send error: %s", sendErr)
+	}
+
+	// only the handed off digests are marked. The rest are sent by the next run.
+	transaction = func(context storage.TransactionContext) error {
+		if len(digestedIDs) > 0 {
+			err := app.storage.UpdateMembershipsDateDigested(context, digestedIDs, startTime)
+			if err != nil {
+				return err
+			}
+		}
+
+		endTime := time.Now()
+		return app.storage.SaveSyncTimes(context, model.SyncTimes{StartTime: &startTime, EndTime: &endTime, Key: syncKey})
+	}
+	err = app.storage.PerformTransaction(transaction)
+	if err != nil {
+		log.Printf("processDigests task error: %s", err)
+		return err
+	}
+	return sendErr
+}
+
+// sendDigests sends one email digest to every user for all of the user's provided memberships.
+// It returns the ids of the memberships whose digest was handed off or had nothing to send.
+func (app *Application) sendDigests(memberships []model.GroupMembership, until time.Time) ([]string, error) {
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+
+	// the activity of every group is loaded once for the longest period needed
+	periodStarts := map[string]time.Time{}
+	userMemberships := map[string][]model.GroupMembership{}
+	var userKeys []string
+	for _, membership := range memberships {
+		since := digestPeriodStart(membership, until)
+		if start, ok := periodStarts[membership.GroupID]; !ok || since.Before(start) {
+			periodStarts[membership.GroupID] = since
+		}
+
+		userKey := membership.ClientID + "_" + membership.UserID
+		if _, ok := userMemberships[userKey]; !ok {
+			userKeys = append(userKeys, userKey)
+		}
+		userMemberships[userKey] = append(userMemberships[userKey], membership)
+	}
+
+	groupIDs := make([]string, 0, len(periodStarts))
+	for groupID := range periodStarts {
+		groupIDs = append(groupIDs, groupID)
+	}
+	groups, err := app.storage.FindGroupsByGroupIDs(groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error finding digest groups: %s", err)
+	}
+
+	activities := map[string]*digestGroupActivity{}
+	var creatorIDs []string
+	for _, group := range groups {
+		activity := &digestGroupActivity{group: group, topPosts: map[string]*model.Post{}}
+		activity.posts, err = app.storage.FindDigestPosts(group.ClientID, group.ID, periodStarts[group.ID], until)
+		if err != nil {
+			return nil, fmt.Errorf("error finding digest posts for group %s: %s", group.ID, err)
+		}
+		activity.events, err = app.storage.FindDigestEvents(group.ClientID, group.ID, periodStarts[group.ID], until)
+		if err != nil {
+			return nil, fmt.Errorf("error finding digest events for group %s: %s", group.ID, err)
+		}
+		for i := range activity.posts {
+			if activity.posts[i].ParentID == nil {
+				activity.topPosts[activity.posts[i].ID] = &activity.posts[i]
+			}
+			creatorIDs = append(creatorIDs, activity.posts[i].Creator.UserID)
+		}
+		activities[group.ID] = activity
+	}
+
+	// the names of the FERPA protected users are not shown
+	ferpaUserIDs := map[string]bool{}
+	if len(creatorIDs) > 0 {
+		ferpaAccounts, err := app.corebb.RetrieveFerpaAccounts(creatorIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving FERPA accounts: %s", err)
+		}
+		for _, userID := range ferpaAccounts {
+			ferpaUserIDs[userID] = true
+		}
+	}
+
+	sentCount := 0
+	var digestedIDs []string
+	for _, userKey := range userKeys {
+		sent, err := app.sendUserDigest(userMemberships[userKey], activities, ferpaUserIDs, until)
+		if err != nil {
+			log.Printf("error sending digest to %s - %s", userKey, err)
+			continue
+		}
+		if sent {
+			sentCount++
+		}
+		for _, membership := range userMemberships[userKey] {
+			digestedIDs = append(digestedIDs, membership.ID)
+		}
+	}
+	log.Printf("processDigests: Sent %d digests", sentCount)
+
+	return digestedIDs, nil
+}
+
+// sendUserDigest sends the digest of all provided memberships of a user. Nothing is sent if there is no activity.
+func (app *Application) sendUserDigest(memberships []model.GroupMembership, activities map[string]*digestGroupActivity, ferpaUserIDs map[string]bool, until time.Time) (bool, error) {
+	var email string
+	frequency := ""
+	var sections []string
+	for _, membership := range memberships {
+		if email == "" {
+			email = membership.Email
+		}
+		if frequency == "" {
+			frequency = membership.NotificationsPreferences.DigestFrequency
+		} else if frequency != membership.NotificationsPreferences.DigestFrequency {
+			frequency = "mixed"
+		}
+
+		activity := activities[membership.GroupID]
+		if activity == nil {
+			continue
+		}
+		section, err := app.buildDigestGroupSection(membership, activity, ferpaUserIDs, digestPeriodStart(membership, until))
+		if err != nil {
+			return false, err
+		}
+		if section != "" {
+			sections = append(sections, section)
+		}
+	}
+	if len(sections) == 0 {
+		return false, nil
+	}
+	if email == "" {
+		return false, fmt.Errorf("missing email")
+	}
+
+	subject := "Your groups digest"
+	switch frequency {
+	case model.DigestFrequencyDaily:
+		subject = "Your daily groups digest"
+	case model.DigestFrequencyWeekly:
+		subject = "Your weekly groups digest"
+	}
+
+	err := app.notifications.SendMail(email, subject, strings.Join(sections, "\n"))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// buildDigestGroupSection builds the HTML digest section of a group for the member. It returns an empty string if there is no activity visible for the member.
+func (app *Application) buildDigestGroupSection(membership model.GroupMembership, activity *digestGroupActivity, ferpaUserIDs map[string]bool, since time.Time) (string, error) {
+	isAdmin := membership.IsAdmin()
+
+	var posts []model.Post
+	repliesCount := 0
+	for _, post := range activity.posts {
+		if !post.DateCreated.After(since) || post.Creator.UserID == membership.UserID {
+			continue
+		}
+		visible, err := app.isDigestPostVisible(membership, activity, post)
+		if err != nil {
+			return "", err
+		}
+		if !visible {
+			continue
+		}
+
+		if post.ParentID == nil {
+			posts = append(posts, post)
+		} else {
+			repliesCount++
+		}
+	}
+
+	eventsCount := 0
+	for _, event := range activity.events {
+		if !event.DateCreated.After(since) {
+			continue
+		}
+		if isAdmin || !event.IsTargeted() || event.HasToMemberUser(&membership.UserID, &membership.ExternalID) || event.HasToMemberLabel(membership.LabelIDs) {
+			eventsCount++
+		}
+	}
+
+	var pendingMembershipsCount, pendingPostsCount int64
+	if isAdmin {
+		if activity.pendingMembershipsCount == nil {
+			count, err := app.storage.CountPendingMemberships(activity.group.ClientID, activity.group.ID)
+			if err != nil {
+				return "", fmt.Errorf("error counting pending memberships: %s", err)
+			}
+			activity.pendingMembershipsCount = &count
+		}
+		if activity.pendingPostsCount == nil {
+			count, err := app.storage.CountPendingPosts(activity.group.ClientID, activity.group.ID)
+			if err != nil {
+				return "", fmt.Errorf("error counting pending posts: %s", err)
+			}
+			activity.pendingPostsCount = &count
+		}
+		pendingMembershipsCount = *activity.pendingMembershipsCount
+		pendingPostsCount = *activity.pendingPostsCount
+	}
+
+	if len(posts) == 0 && repliesCount == 0 && eventsCount == 0 && pendingMembershipsCount == 0 && pendingPostsCount == 0 {
+		return "", nil
+	}
+
+	groupStr := "Group"
+	if activity.group.ResearchGroup {
+		groupStr = "Research Project"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("<h3>%s - %s</h3>\n", groupStr, html.EscapeString(activity.group.Title)))
+	if len(posts) > 0 {
+		builder.WriteString(fmt.Sprintf("<div>New posts: %d</div>\n<ul>\n", len(posts)))
+		for i, post := range posts {
+			if i == maxDigestListedPosts {
+				builder.WriteString(fmt.Sprintf("<li>and %d more</li>\n", len(posts)-maxDigestListedPosts))
+				break
+			}
+			creatorName := post.Creator.Name
+			if ferpaUserIDs[post.Creator.UserID] || creatorName == "" {
+				creatorName = "A member"
+			}
+			builder.WriteString(fmt.Sprintf("<li>%s by %s</li>\n", html.EscapeString(post.Subject), html.EscapeString(creatorName)))
+		}
+		builder.WriteString("</ul>\n")
+	}
+	if repliesCount > 0 {
+		builder.WriteString(fmt.Sprintf("<div>New replies: %d</div>\n", repliesCount))
+	}
+	if eventsCount > 0 {
+		builder.WriteString(fmt.Sprintf("<div>New events: %d</div>\n", eventsCount))
+	}
+	if pendingMembershipsCount > 0 {
+		builder.WriteString(fmt.Sprintf("<div>Membership requests waiting for approval: %d</div>\n", pendingMembershipsCount))
+	}
+	if pendingPostsCount > 0 {
+		builder.WriteString(fmt.Sprintf("<div>Posts waiting for review: %d</div>\n", pendingPostsCount))
+	}
+	return builder.String(), nil
+}
+
+// isDigestPostVisible checks if the post is visible for the member. The replies are visible if their top level post is visible.
+func (app *Application) isDigestPostVisible(membership model.GroupMembership, activity *digestGroupActivity, post model.Post) (bool, error) {
+	if !post.IsPublished() {
+		return false, nil
+	}
+	if membership.IsAdmin() {
+		return true, nil
+	}
+	if !post.UserCanSeePost(membership.UserID, membership.LabelIDs) {
+		return false, nil
+	}
+	if post.ParentID == nil {
+		return true, nil
+	}
+
+	topPostID := *post.ParentID
+	if post.TopParentID != nil {
+		topPostID = *post.TopParentID
+	}
+	topPost, ok := activity.topPosts[topPostID]
+	if !ok {
+		var err error
+		topPost, err = app.storage.FindPostWithoutReplies(nil, activity.group.ClientID, nil, activity.group.ID, topPostID, false)
+		if err != nil {
+			return false, fmt.Errorf("error finding top level post %s: %s", topPostID, err)
+		}
+		activity.topPosts[topPostID] = topPost
+	}
+	return topPost != nil && topPost.IsPublished() && !topPost.IsExpired() && topPost.UserCanSeePost(membership.UserID, membership.LabelIDs), nil
+}
+
+// digestPeriodStart returns the start of the period which the digest of the membership covers
+func digestPeriodStart(membership model.GroupMembership, until time.Time) time.Time {
+	since := until.Add(-membership.NotificationsPreferences.GetDigestPeriod())
+	if membership.DateDigested != nil && membership.DateDigested.After(since) {
+		return *membership.DateDigested
+	}
+	return since
+}
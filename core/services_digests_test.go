@@ -0,0 +1,119 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"groups/core/model"
+	"groups/driven/storage"
+	"testing"
+	"time"
+)
+
+// digestsStorage finds the top level posts by id
+type digestsStorage struct {
+	Storage
+	posts map[string]*model.Post
+}
+
+func (s *digestsStorage) FindPostWithoutReplies(context storage.TransactionContext, clientID string, userID *string, groupID string, postID string, filterByToMembers bool) (*model.Post, error) {
+	return s.posts[postID], nil
+}
+
+func TestDigestPeriodStart(t *testing.T) {
+	until := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	recent := until.Add(-2 * time.Hour)
+	old := until.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		frequency    string
+		dateDigested *time.Time
+		want         time.Time
+	}{
+		{"daily never digested", model.DigestFrequencyDaily, nil, until.Add(-24 * time.Hour)},
+		{"weekly never digested", model.DigestFrequencyWeekly, nil, until.Add(-7 * 24 * time.Hour)},
+		{"daily digested within the period", model.DigestFrequencyDaily, &recent, recent},
+		{"weekly digested within the period", model.DigestFrequencyWeekly, &recent, recent},
+		{"digested before the period", model.DigestFrequencyDaily, &old, until.Add(-24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			membership := model.GroupMembership{
+				NotificationsPreferences: model.NotificationsPreferences{DigestFrequency: tt.frequency},
+				DateDigested:             tt.dateDigested,
+			}
+			if got := digestPeriodStart(membership, until); !got.Equal(tt.want) {
+				t.Errorf("digestPeriodStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDigestPostVisible(t *testing.T) {
+	expired := time.Now()
+	topID := "top"
+	targetedID := "targeted"
+	pendingID := "pending"
+	expiredID := "expired"
+	missingID := "missing"
+	app := &Application{storage: &digestsStorage{posts: map[string]*model.Post{
+		topID:      {ID: topID},
+		targetedID: {ID: targetedID, ToMembersList: []model.ToMember{{UserID: "u2"}}},
+		pendingID:  {ID: pendingID, Status: model.PostStatusPendingReview},
+		expiredID:  {ID: expiredID, DateExpired: &expired},
+	}}}
+
+	member := model.GroupMembership{UserID: "u1", Status: "member", LabelIDs: []string{"l1"}}
+	admin := model.GroupMembership{UserID: "u3", Status: "admin"}
+	reply := func(topParentID string) model.Post {
+		return model.Post{ParentID: &topParentID, TopParentID: &topParentID}
+	}
+
+	tests := []struct {
+		name       string
+		membership model.GroupMembership
+		post       model.Post
+		want       bool
+	}{
+		{"published post", member, model.Post{}, true},
+		{"pending post", member, model.Post{Status: model.PostStatusPendingReview}, false},
+		{"pending post for admin", admin, model.Post{Status: model.PostStatusPendingReview}, false},
+		{"rejected post", member, model.Post{Status: model.PostStatusRejected}, false},
+		{"post to other members", member, model.Post{ToMembersList: []model.ToMember{{UserID: "u2"}}}, false},
+		{"post to other members for admin", admin, model.Post{ToMembersList: []model.ToMember{{UserID: "u2"}}}, true},
+		{"post to the member", member, model.Post{ToMembersList: []model.ToMember{{UserID: "u1"}}}, true},
+		{"post to the member label", member, model.Post{ToLabelIDs: []string{"l1"}}, true},
+		{"post to other labels", member, model.Post{ToLabelIDs: []string{"l2"}}, false},
+		{"reply to a visible post", member, reply(topID), true},
+		{"reply to a post to other members", member, reply(targetedID), false},
+		{"reply to a pending post", member, reply(pendingID), false},
+		{"reply to an expired post", member, reply(expiredID), false},
+		{"reply to a missing post", member, reply(missingID), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := &digestGroupActivity{group: model.Group{ID: "g1", ClientID: "client"}, topPosts: map[string]*model.Post{}}
+			got, err := app.isDigestPostVisible(tt.membership, activity, tt.post)
+			if err != nil {
+				t.Fatalf("isDigestPostVisible() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isDigestPostVisible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
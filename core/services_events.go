@@ -15,12 +15,10 @@
 package core
 
 import (
-	"fmt"
 	"groups/core/model"
 	"groups/driven/notifications"
 	"groups/driven/storage"
 	"log"
-	"strings"
 )
 
 func (app *Application) findAdminGroupsForEvent(clientID string, current *model.User, eventID string) ([]string, error) {
@@ -76,9 +74,14 @@ func (app *Application) getGroupCalendarEvents(clientID string, current *model.U
 	return nil, err
 }
 
-func (app *Application) createEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, creator *model.Creator) (*model.Event, error) {
+func (app *Application) createEvent(clientID string, current *model.User, eventID string, group *model.Group, toMemberList []model.ToMember, toLabelIDs []string, creator *model.Creator) (*model.Event, error) {
 	var skipUserID *string
 
+	toLabelIDs, err := model.ValidateMemberLabelIDs(toLabelIDs, group.MemberLabels)
+	if err != nil {
+		return nil, err
+	}
+
 	if current != nil && creator == nil {
 		creator = &model.Creator{
 			UserID: current.ID,
@@ -91,13 +94,14 @@ func (app *Application) createEvent(clientID string, current *model.User, eventI
 	}
 
 	var event *model.Event
-	err := app.storage.PerformTransaction(func(context storage.TransactionContext) error {
-		_, err := app.storage.CreateEvent(context, clientID, eventID, group.ID, toMemberList, creator)
+	err = app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		var err error
+		event, err = app.storage.CreateEvent(context, clientID, eventID, group.ID, toMemberList, toLabelIDs, creator)
 		if err != nil {
 			return err
 		}
 
-		app.notifyGroupMembersForNewEvent(context, clientID, current, group, nil, skipUserID)
+		app.notifyGroupMembersForNewEvent(context, clientID, current, group, event, skipUserID)
 
 		return nil
 	})
@@ -113,7 +117,7 @@ func (app *Application) createCalendarEventForGroups(clientID string, adminIdent
 	var createdEvent map[string]interface{}
 
 	app.storage.PerformTransaction(func(context storage.TransactionContext) error {
-		memberships, err := app.findGroupMemberships(context, clientID, model.MembershipFilter{
+		memberships, err := app.findGroupMemberships(context, clientID, model.NewServiceMemberViewer(), model.MembershipFilter{
 			GroupIDs: groupIDs,
 			UserID:   &current.ID,
 			Statuses: []string{"admin"},
@@ -134,7 +138,7 @@ func (app *Application) createCalendarEventForGroups(clientID string, adminIdent
 				eventID := createdEvent["id"].(string)
 
 				for _, membership := range memberships.Items {
-					mapping, err := app.storage.CreateEvent(context, clientID, eventID, membership.GroupID, nil, &model.Creator{
+					mapping, err := app.storage.CreateEvent(context, clientID, eventID, membership.GroupID, nil, nil, &model.Creator{
 						UserID: current.ID,
 						Name:   current.Name,
 						Email:  current.Email,
@@ -166,7 +170,7 @@ func (app *Application) createCalendarEventForGroupsMembers(clientID string, org
 	for _, groupID := range groupIDs {
 
 		var userIDs []string
-		memberships, err := app.findGroupMemberships(nil, clientID, model.MembershipFilter{
+		memberships, err := app.findGroupMemberships(nil, clientID, model.NewServiceMemberViewer(), model.MembershipFilter{
 			GroupIDs: []string{groupID},
 			Statuses: []string{"admin", "member"},
 		})
@@ -195,11 +199,16 @@ func (app *Application) createCalendarEventForGroupsMembers(clientID string, org
 	return nil
 }
 
-func (app *Application) createCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error) {
+func (app *Application) createCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error) {
 	var createdEvent map[string]interface{}
 
-	err := app.storage.PerformTransaction(func(context storage.TransactionContext) error {
-		memberships, err := app.findGroupMemberships(context, clientID, model.MembershipFilter{
+	labelIDs, err := app.validateEventLabels(clientID, groupID, labelIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		memberships, err := app.findGroupMemberships(context, clientID, model.NewServiceMemberViewer(), model.MembershipFilter{
 			GroupIDs: []string{groupID},
 			UserID:   &current.ID,
 			Statuses: []string{"admin"},
@@ -224,7 +233,7 @@ func (app *Application) createCalendarEventSingleGroup(clientID string, current
 
 				eventID := createdEvent["id"].(string)
 
-				mapping, err := app.storage.CreateEvent(context, clientID, eventID, groupID, members, &model.Creator{
+				mapping, err := app.storage.CreateEvent(context, clientID, eventID, groupID, members, labelIDs, &model.Creator{
 					UserID: current.ID,
 					Name:   current.Name,
 					Email:  current.Email,
@@ -244,20 +253,25 @@ func (app *Application) createCalendarEventSingleGroup(clientID string, current
 		return nil
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return createdEvent, members, nil
+	return createdEvent, members, labelIDs, nil
 }
 
-func (app *Application) updateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember) (map[string]interface{}, []model.ToMember, error) {
-	memberships, err := app.findGroupMemberships(nil, clientID, model.MembershipFilter{
+func (app *Application) updateCalendarEventSingleGroup(clientID string, current *model.User, event map[string]interface{}, groupID string, members []model.ToMember, labelIDs []string) (map[string]interface{}, []model.ToMember, []string, error) {
+	labelIDs, err := app.validateEventLabels(clientID, groupID, labelIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	memberships, err := app.findGroupMemberships(nil, clientID, model.NewServiceMemberViewer(), model.MembershipFilter{
 		GroupIDs: []string{groupID},
 		UserID:   &current.ID,
 		Statuses: []string{"admin"},
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if len(memberships.Items) > 0 {
@@ -270,20 +284,20 @@ func (app *Application) updateCalendarEventSingleGroup(clientID string, current
 		currentAccount := model.AccountIdentifiers{AccountID: &current.ID, ExternalID: &current.NetID}
 		createdEvent, err := app.calendar.UpdateCalendarEvent(currentAccount, eventID, event, current.OrgID, current.AppID)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		if createdEvent != nil {
 			var mappedGroupIDs []string
 			eventID := createdEvent["id"].(string)
 
-			err := app.storage.UpdateEvent(clientID, eventID, groupID, members)
+			err := app.storage.UpdateEvent(clientID, eventID, groupID, members, labelIDs)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 
 			for _, groupID := range groupIDs {
-				mapping, err := app.storage.CreateEvent(nil, clientID, eventID, groupID, members, &model.Creator{
+				mapping, err := app.storage.CreateEvent(nil, clientID, eventID, groupID, members, labelIDs, &model.Creator{
 					UserID: current.ID,
 					Name:   current.Name,
 					Email:  current.Email,
@@ -295,15 +309,19 @@ func (app *Application) updateCalendarEventSingleGroup(clientID string, current
 					mappedGroupIDs = append(mappedGroupIDs, mapping.GroupID)
 				}
 			}
-			return createdEvent, members, nil
+			return createdEvent, members, labelIDs, nil
 		}
 	}
 
-	return nil, nil, nil
+	return nil, nil, nil, nil
 }
 
-func (app *Application) updateEvent(clientID string, _ *model.User, eventID string, groupID string, toMemberList []model.ToMember) error {
-	return app.storage.UpdateEvent(clientID, eventID, groupID, toMemberList)
+func (app *Application) updateEvent(clientID string, _ *model.User, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string) error {
+	toLabelIDs, err := app.validateEventLabels(clientID, groupID, toLabelIDs)
+	if err != nil {
+		return err
+	}
+	return app.storage.UpdateEvent(clientID, eventID, groupID, toMemberList, toLabelIDs)
 }
 
 func (app *Application) deleteEvent(clientID string, _ *model.User, eventID string, groupID string) error {
@@ -317,8 +335,17 @@ func (app *Application) deleteEvent(clientID string, _ *model.User, eventID stri
 func (app *Application) notifyGroupMembersForNewEvent(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, event *model.Event, skipUserID *string) {
 	var userIDs []string
 	var recipients []notifications.Recipient
-	if len(event.ToMembersList) > 0 {
-		userIDs = event.GetMembersAsUserIDs(skipUserID)
+	if event != nil && event.IsTargeted() {
+		var err error
+		userIDs, err = app.findAudienceUserIDs(context, clientID, group.ID, event.GetMembersAsUserIDs(skipUserID), event.ToLabelIDs, skipUserID)
+		if err != nil {
+			app.logger.Errorf("notifyGroupMembersForNewEvent() Error finding the event recipients: %s", err)
+			return
+		}
+		if len(userIDs) == 0 {
+			// the event is addressed to nobody else
+			return
+		}
 	}
 
 	result, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
@@ -344,29 +371,28 @@ func (app *Application) notifyGroupMembersForNewEvent(context storage.Transactio
 			appID = current.AppID
 			orgID = current.OrgID
 		}
-		groupStr := "Group"
-		if group.ResearchGroup {
-			groupStr = "Research Project"
-		}
-
-		err = app.notifications.SendNotification(
+		err = app.queueTemplatedNotification(
+			context,
+			clientID,
+			&group.ID,
+			model.NotificationOperationEventCreated,
+			model.NewNotificationTemplateData(group),
+			result.GetNotificationLocales(),
 			recipients,
 			&topic,
-			fmt.Sprintf("%s - %s", groupStr, group.Title),
-			fmt.Sprintf("New event has been published in '%s' %s", group.Title, strings.ToLower(groupStr)),
 			map[string]string{
 				"type":        "group",
-				"operation":   "event_created",
+				"operation":   model.NotificationOperationEventCreated,
 				"entity_type": "group",
 				"entity_id":   group.ID,
 				"entity_name": group.Title,
 			},
 			appID,
 			orgID,
-			nil,
+			notifications.DefaultPriority,
 		)
 		if err != nil {
-			app.logger.Errorf("notifyGroupMembersForNewEvent() Error sending notification group memberships: %s", err)
+			app.logger.Errorf("notifyGroupMembersForNewEvent() Error queueing notification for group memberships: %s", err)
 		}
 	}
 }
@@ -0,0 +1,135 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+)
+
+// checkMemberLabelsAdmin checks that the current user is an admin of the group
+func checkMemberLabelsAdmin(group *model.Group) error {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return fmt.Errorf("only group admins can manage the member labels")
+	}
+	return nil
+}
+
+func (app *Application) updateGroupMemberLabels(clientID string, current *model.User, group *model.Group, labels []model.MemberLabel) ([]model.MemberLabel, error) {
+	if err := checkMemberLabelsAdmin(group); err != nil {
+		return nil, err
+	}
+	labels, err := model.ValidateMemberLabels(labels, group.MemberLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.storage.UpdateGroupMemberLabels(clientID, group.ID, labels)
+	if err != nil {
+		return nil, fmt.Errorf("error updating the member labels of group %s: %s", group.ID, err)
+	}
+	return labels, nil
+}
+
+func (app *Application) updateMembershipLabels(clientID string, current *model.User, group *model.Group, membershipID string, labelIDs []string) error {
+	if err := checkMemberLabelsAdmin(group); err != nil {
+		return err
+	}
+	labelIDs, err := model.ValidateMemberLabelIDs(labelIDs, group.MemberLabels)
+	if err != nil {
+		return err
+	}
+	membership, err := app.storage.FindGroupMembershipByID(clientID, membershipID)
+	if err != nil {
+		return fmt.Errorf("error finding membership %s: %s", membershipID, err)
+	}
+	if membership == nil || membership.GroupID != group.ID {
+		return fmt.Errorf("the membership %s does not exist in group %s", membershipID, group.ID)
+	}
+
+	err = app.storage.UpdateMembershipLabels(clientID, membership.ID, labelIDs)
+	if err != nil {
+		return fmt.Errorf("error updating the member labels of membership %s: %s", membership.ID, err)
+	}
+	return nil
+}
+
+// validatePostLabels checks the member labels which a post is addressed to. Only the group admins may address top level posts to labels.
+func validatePostLabels(group *model.Group, post *model.Post) error {
+	if len(post.ToLabelIDs) == 0 {
+		return nil
+	}
+	if post.ParentID != nil {
+		return fmt.Errorf("replies cannot be addressed to member labels")
+	}
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return fmt.Errorf("only group admins can address posts to member labels")
+	}
+	labelIDs, err := model.ValidateMemberLabelIDs(post.ToLabelIDs, group.MemberLabels)
+	if err != nil {
+		return err
+	}
+	post.ToLabelIDs = labelIDs
+	return nil
+}
+
+// validateEventLabels checks the member labels which an event of the group is addressed to
+func (app *Application) validateEventLabels(clientID string, groupID string, labelIDs []string) ([]string, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	group, err := app.storage.FindGroup(nil, clientID, groupID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error finding group %s: %s", groupID, err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("the group %s does not exist", groupID)
+	}
+	return model.ValidateMemberLabelIDs(labelIDs, group.MemberLabels)
+}
+
+// findAudienceUserIDs resolves the recipients of the content addressed to the user ids and to the members with any of the labels.
+// The labels are resolved at the time of the call, so the members who are assigned to a label later are included in the next resolution.
+// The result is never nil, so an empty result means that nobody is addressed.
+func (app *Application) findAudienceUserIDs(context storage.TransactionContext, clientID string, groupID string, userIDs []string, labelIDs []string, skipUserID *string) ([]string, error) {
+	result := make([]string, 0, len(userIDs))
+	added := map[string]bool{}
+	add := func(userID string) {
+		if added[userID] || (skipUserID != nil && *skipUserID == userID) {
+			return
+		}
+		added[userID] = true
+		result = append(result, userID)
+	}
+
+	for _, userID := range userIDs {
+		add(userID)
+	}
+	if len(labelIDs) > 0 {
+		memberships, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
+			GroupIDs: []string{groupID},
+			LabelIDs: labelIDs,
+			Statuses: []string{"member", "admin"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error finding the members with labels %v: %s", labelIDs, err)
+		}
+		for _, membership := range memberships.Items {
+			add(membership.UserID)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"groups/core/model"
+	"groups/driven/storage"
+	"reflect"
+	"testing"
+)
+
+// labelsStorage returns the memberships of the group which have any of the filtered labels
+type labelsStorage struct {
+	Storage
+	memberships []model.GroupMembership
+}
+
+func (s *labelsStorage) FindGroupMembershipsWithContext(context storage.TransactionContext, clientID string, filter model.MembershipFilter) (model.MembershipCollection, error) {
+	var items []model.GroupMembership
+	for _, membership := range s.memberships {
+		if model.HasAnyMemberLabel(membership.LabelIDs, filter.LabelIDs) {
+			items = append(items, membership)
+		}
+	}
+	return model.MembershipCollection{Items: items}, nil
+}
+
+func TestFindAudienceUserIDs(t *testing.T) {
+	app := &Application{storage: &labelsStorage{memberships: []model.GroupMembership{
+		{UserID: "u1", LabelIDs: []string{"l1"}},
+		{UserID: "u2", LabelIDs: []string{"l1", "l2"}},
+		{UserID: "u3", LabelIDs: []string{"l2"}},
+		{UserID: "u4"},
+	}}}
+	skip := "u2"
+
+	tests := []struct {
+		name       string
+		userIDs    []string
+		labelIDs   []string
+		skipUserID *string
+		want       []string
+	}{
+		{"members only", []string{"u4", "u1"}, nil, nil, []string{"u4", "u1"}},
+		{"labels only", nil, []string{"l2"}, nil, []string{"u2", "u3"}},
+		{"members and labels without duplicates", []string{"u3", "u4"}, []string{"l1", "l2"}, nil, []string{"u3", "u4", "u1", "u2"}},
+		{"skipped user", []string{"u2"}, []string{"l1"}, &skip, []string{"u1"}},
+		{"no audience", nil, nil, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := app.findAudienceUserIDs(nil, "client", "g1", tt.userIDs, tt.labelIDs, tt.skipUserID)
+			if err != nil {
+				t.Fatalf("findAudienceUserIDs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findAudienceUserIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// checkMemberNotesAdmin checks that the current user is an admin of the group
+func checkMemberNotesAdmin(group *model.Group) error {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return fmt.Errorf("only group admins can manage the member notes")
+	}
+	return nil
+}
+
+// findMemberNotesMembership finds a membership of the group
+func (app *Application) findMemberNotesMembership(clientID string, group *model.Group, membershipID string) (*model.GroupMembership, error) {
+	membership, err := app.storage.FindGroupMembershipByID(clientID, membershipID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding membership %s: %s", membershipID, err)
+	}
+	if membership == nil || membership.GroupID != group.ID {
+		return nil, fmt.Errorf("the membership %s does not exist in group %s", membershipID, group.ID)
+	}
+	return membership, nil
+}
+
+func (app *Application) getMemberNotes(clientID string, current *model.User, group *model.Group, membershipID string) ([]model.MemberNote, error) {
+	if err := checkMemberNotesAdmin(group); err != nil {
+		return nil, err
+	}
+	membership, err := app.findMemberNotesMembership(clientID, group, membershipID)
+	if err != nil {
+		return nil, err
+	}
+	return app.storage.FindMemberNotes(clientID, *membership)
+}
+
+func (app *Application) createMemberNote(clientID string, current *model.User, group *model.Group, membershipID string, text string) (*model.MemberNote, error) {
+	if err := checkMemberNotesAdmin(group); err != nil {
+		return nil, err
+	}
+	text, err := model.ValidateMemberNoteText(text)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := app.findMemberNotesMembership(clientID, group, membershipID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := model.MemberNote{
+		ID:           uuid.NewString(),
+		ClientID:     clientID,
+		GroupID:      group.ID,
+		MembershipID: membership.ID,
+		UserID:       membership.UserID,
+		Text:         text,
+		Author:       model.Creator{UserID: current.ID, Name: current.Name, Email: current.Email},
+		DateCreated:  time.Now().UTC(),
+	}
+	err = app.storage.InsertMemberNote(note)
+	if err != nil {
+		return nil, fmt.Errorf("error creating member note: %s", err)
+	}
+	return &note, nil
+}
+
+func (app *Application) updateMemberNote(clientID string, current *model.User, group *model.Group, noteID string, text string) (*model.MemberNote, error) {
+	if err := checkMemberNotesAdmin(group); err != nil {
+		return nil, err
+	}
+	text, err := model.ValidateMemberNoteText(text)
+	if err != nil {
+		return nil, err
+	}
+	note, err := app.storage.FindMemberNote(clientID, group.ID, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding member note %s: %s", noteID, err)
+	}
+	if note == nil {
+		return nil, fmt.Errorf("the member note %s does not exist", noteID)
+	}
+
+	now := time.Now().UTC()
+	note.Text = text
+	note.DateUpdated = &now
+	note.UpdatedBy = &model.Creator{UserID: current.ID, Name: current.Name, Email: current.Email}
+	err = app.storage.UpdateMemberNote(*note)
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (app *Application) deleteMemberNote(clientID string, current *model.User, group *model.Group, noteID string) error {
+	if err := checkMemberNotesAdmin(group); err != nil {
+		return err
+	}
+	return app.storage.DeleteMemberNote(clientID, group.ID, noteID)
+}
+
+// getMemberRoster gives the members of the group together with the admin notes on them
+func (app *Application) getMemberRoster(clientID string, current *model.User, group *model.Group) ([]model.MemberRosterEntry, error) {
+	if err := checkMemberNotesAdmin(group); err != nil {
+		return nil, err
+	}
+
+	memberships, err := app.findGroupMemberships(nil, clientID, model.NewUserMemberViewer(current), model.MembershipFilter{GroupIDs: []string{group.ID}})
+	if err != nil {
+		return nil, err
+	}
+	notes, err := app.storage.FindGroupMemberNotes(clientID, group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the member notes of group %s: %s", group.ID, err)
+	}
+
+	// the retained notes on a previous membership are attached by the user
+	notesByMembership := map[string][]model.MemberNote{}
+	notesByUser := map[string][]model.MemberNote{}
+	for _, note := range notes {
+		notesByMembership[note.MembershipID] = append(notesByMembership[note.MembershipID], note)
+		if len(note.UserID) > 0 {
+			notesByUser[note.UserID] = append(notesByUser[note.UserID], note)
+		}
+	}
+
+	roster := make([]model.MemberRosterEntry, len(memberships.Items))
+	for i, membership := range memberships.Items {
+		memberNotes := notesByMembership[membership.ID]
+		if len(membership.UserID) > 0 {
+			memberNotes = notesByUser[membership.UserID]
+			for _, note := range notesByMembership[membership.ID] {
+				if note.UserID != membership.UserID {
+					memberNotes = append(memberNotes, note)
+				}
+			}
+		}
+		if memberNotes == nil {
+			memberNotes = []model.MemberNote{}
+		}
+		roster[i] = model.MemberRosterEntry{Membership: membership, Notes: memberNotes}
+	}
+	return roster, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+)
+
+// searchGroupMembers searches the members of a group. The query is matched only against the member fields which the group settings allow the current user to see.
+func (app *Application) searchGroupMembers(clientID string, current *model.User, group *model.Group, search model.MemberSearch) (*model.MemberSearchResult, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdminOrMember() {
+		return nil, fmt.Errorf("only the group members can search the members")
+	}
+	isAdmin := group.CurrentMember.IsAdmin()
+
+	settings := model.DefaultGroupSettings()
+	if group.Settings != nil {
+		settings = *group.Settings
+	}
+	if !isAdmin && !settings.MemberInfoPreferences.AllowMemberInfo {
+		return nil, fmt.Errorf("the group %s does not allow the members to see the member info", group.ID)
+	}
+
+	err := search.Validate(isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	viewer := model.NewUserMemberViewer(current).ForGroup(group.CurrentMember)
+	policy := model.NewMemberVisibilityPolicy(group, nil)
+	search.Fields = policy.SearchableFields(viewer)
+	search.ProfileValues = policy.FilterProfileValues(viewer, search.ProfileValues)
+	search.IDs = nil
+
+	var items []model.GroupMembership
+	var total int64
+	if len(search.Query) > 0 && len(search.Fields) == 0 {
+		items = []model.GroupMembership{}
+	} else if len(search.Query) > 0 && search.Match == model.MemberSearchMatchFuzzy {
+		items, total, err = app.fuzzySearchGroupMembers(clientID, current, group.ID, search)
+	} else {
+		search.ExcludedUserIDs, err = app.findMemberSearchFerpaUserIDs(clientID, current, group.ID, search)
+		if err == nil {
+			items, total, err = app.storage.SearchGroupMemberships(clientID, group.ID, search)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error searching the members of group %s: %s", group.ID, err)
+	}
+
+	err = app.applyMemberVisibility(clientID, model.NewUserMemberViewer(current), items)
+	if err != nil {
+		return nil, fmt.Errorf("error protecting the members of group %s: %s", group.ID, err)
+	}
+
+	if items == nil {
+		items = []model.GroupMembership{}
+	}
+	return &model.MemberSearchResult{Items: items, Total: total}, nil
+}
+
+// findMemberSearchFerpaUserIDs gives the FERPA protected members who match the search by their protected data.
+// Their name, NetID, email, profile values and labels are hidden from the other users, so they must not be found by them.
+func (app *Application) findMemberSearchFerpaUserIDs(clientID string, current *model.User, groupID string, search model.MemberSearch) ([]string, error) {
+	if len(search.Query) == 0 && len(search.ProfileValues) == 0 && len(search.LabelIDs) == 0 {
+		return nil, nil
+	}
+	userIDs, err := app.storage.FindMemberSearchUserIDs(clientID, groupID, search)
+	if err != nil {
+		return nil, err
+	}
+	return app.findFerpaUserIDs(withoutUserID(userIDs, current.ID))
+}
+
+// fuzzySearchGroupMembers matches the query against all the sorted candidates batch by batch and loads the requested page of the matching memberships
+func (app *Application) fuzzySearchGroupMembers(clientID string, current *model.User, groupID string, search model.MemberSearch) ([]model.GroupMembership, int64, error) {
+	var matching []model.GroupMembership
+	var matchingUserIDs []string
+	for offset := int64(0); ; offset += model.FuzzyMemberSearchBatchSize {
+		candidates, err := app.storage.FindMemberSearchCandidates(clientID, groupID, search, offset, model.FuzzyMemberSearchBatchSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, candidate := range candidates {
+			if model.MatchesMemberSearch(search.Query, model.MemberSearchTokens(candidate, search.Fields), true) {
+				matching = append(matching, candidate)
+				matchingUserIDs = append(matchingUserIDs, candidate.UserID)
+			}
+		}
+		if int64(len(candidates)) < model.FuzzyMemberSearchBatchSize {
+			break
+		}
+	}
+
+	// the FERPA protected members must not be found by their protected data
+	ferpaUserIDs, err := app.findFerpaUserIDs(withoutUserID(matchingUserIDs, current.ID))
+	if err != nil {
+		return nil, 0, err
+	}
+	excluded := map[string]bool{}
+	for _, userID := range ferpaUserIDs {
+		excluded[userID] = true
+	}
+	matchingIDs := []string{}
+	for _, candidate := range matching {
+		if !excluded[candidate.UserID] {
+			matchingIDs = append(matchingIDs, candidate.ID)
+		}
+	}
+	total := int64(len(matchingIDs))
+	if search.Offset >= total {
+		return []model.GroupMembership{}, total, nil
+	}
+
+	pageSearch := search
+	pageSearch.Query = ""
+	pageSearch.IDs = matchingIDs[search.Offset:min(search.Offset+search.Limit, total)]
+	pageSearch.Offset = 0
+	items, _, err := app.storage.SearchGroupMemberships(clientID, groupID, pageSearch)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// withoutUserID gives the user ids except the provided one
+func withoutUserID(userIDs []string, userID string) []string {
+	result := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != userID {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+)
+
+// ferpaLookupBatchSize is the max number of user ids in a single FERPA lookup
+const ferpaLookupBatchSize = 500
+
+// applyMemberVisibility applies the visibility policies of their groups to the memberships. This is done for every response which contains member data.
+func (app *Application) applyMemberVisibility(clientID string, viewer model.MemberViewer, memberships []model.GroupMembership) error {
+	if len(memberships) == 0 {
+		return nil
+	}
+
+	groupIDs := []string{}
+	userIDs := []string{}
+	seenGroups := map[string]bool{}
+	for _, membership := range memberships {
+		if !seenGroups[membership.GroupID] {
+			seenGroups[membership.GroupID] = true
+			groupIDs = append(groupIDs, membership.GroupID)
+		}
+		if len(membership.UserID) > 0 && membership.UserID != viewer.UserID {
+			userIDs = append(userIDs, membership.UserID)
+		}
+	}
+
+	ferpaUserIDs, err := app.findFerpaUserIDs(userIDs)
+	if err != nil {
+		return err
+	}
+	policies, viewers, err := app.getMemberVisibilityPolicies(clientID, viewer, groupIDs, ferpaUserIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range memberships {
+		groupID := memberships[i].GroupID
+		policies[groupID].Apply(viewers[groupID], &memberships[i])
+	}
+	return nil
+}
+
+// getMemberVisibilityPolicies gives the visibility policies of the groups and the viewer resolved for every group
+func (app *Application) getMemberVisibilityPolicies(clientID string, viewer model.MemberViewer, groupIDs []string, ferpaUserIDs []string) (map[string]model.MemberVisibilityPolicy, map[string]model.MemberViewer, error) {
+	groups, err := app.storage.FindGroupsV3(nil, clientID, model.GroupsFilter{GroupIDs: groupIDs})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finding the groups for the member visibility: %s", err)
+	}
+	groupsByID := map[string]*model.Group{}
+	for i := range groups {
+		groupsByID[groups[i].ID] = &groups[i]
+	}
+
+	viewerMemberships := map[string]*model.GroupMembership{}
+	if viewer.Role == model.MemberViewerRoleUser {
+		result, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{GroupIDs: groupIDs, UserID: &viewer.UserID})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error finding the viewer memberships: %s", err)
+		}
+		for i := range result.Items {
+			viewerMemberships[result.Items[i].GroupID] = &result.Items[i]
+		}
+	}
+
+	policies := map[string]model.MemberVisibilityPolicy{}
+	viewers := map[string]model.MemberViewer{}
+	for _, groupID := range groupIDs {
+		policies[groupID] = model.NewMemberVisibilityPolicy(groupsByID[groupID], ferpaUserIDs)
+		viewers[groupID] = viewer.ForGroup(viewerMemberships[groupID])
+	}
+	return policies, viewers, nil
+}
+
+// filterVisibleProfileValues keeps only the profile value filters which the viewer may see in all the groups so that the filters do not disclose hidden values
+func (app *Application) filterVisibleProfileValues(clientID string, viewer model.MemberViewer, groupIDs []string, values map[string]interface{}) (map[string]interface{}, error) {
+	if len(values) == 0 || viewer.Role == model.MemberViewerRoleSystemAdmin || viewer.Role == model.MemberViewerRoleService ||
+		viewer.Role == model.MemberViewerRoleInternal {
+		return values, nil
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	policies, viewers, err := app.getMemberVisibilityPolicies(clientID, viewer, groupIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range groupIDs {
+		values = policies[groupID].FilterProfileValues(viewers[groupID], values)
+	}
+	return values, nil
+}
+
+// filterVisibleLabelIDs drops the member labels filter unless the viewer may see the labels within all the groups
+func (app *Application) filterVisibleLabelIDs(clientID string, viewer model.MemberViewer, groupIDs []string, labelIDs []string) ([]string, error) {
+	if len(labelIDs) == 0 || viewer.Role == model.MemberViewerRoleSystemAdmin || viewer.Role == model.MemberViewerRoleService ||
+		viewer.Role == model.MemberViewerRoleInternal {
+		return labelIDs, nil
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	policies, viewers, err := app.getMemberVisibilityPolicies(clientID, viewer, groupIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range groupIDs {
+		if !policies[groupID].CanFilterByLabels(viewers[groupID]) {
+			return nil, nil
+		}
+	}
+	return labelIDs, nil
+}
+
+// findFerpaUserIDs gives the users whose data is protected by FERPA
+func (app *Application) findFerpaUserIDs(userIDs []string) ([]string, error) {
+	var result []string
+	for start := 0; start < len(userIDs); start += ferpaLookupBatchSize {
+		batch := userIDs[start:min(start+ferpaLookupBatchSize, len(userIDs))]
+		ferpa, err := app.corebb.RetrieveFerpaAccounts(batch)
+		if err != nil {
+			return nil, fmt.Errorf("RetrieveFerpaAccounts error: %s", err)
+		}
+		result = append(result, ferpa...)
+	}
+	return result, nil
+}
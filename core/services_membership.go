@@ -17,9 +17,9 @@ package core
 import (
 	"fmt"
 	"groups/core/model"
+	"groups/driven/notifications"
 	"groups/driven/storage"
 	"log"
-	"strings"
 )
 
 func (app *Application) checkUserGroupMembershipPermission(clientID string, current *model.User, groupID string) (*model.Group, bool) {
@@ -45,35 +45,33 @@ func (app *Application) findGroupsV3(clientID string, filter model.GroupsFilter)
 	return app.storage.FindGroupsV3(nil, clientID, filter)
 }
 
-func (app *Application) findGroupMemberships(context storage.TransactionContext, clientID string, filter model.MembershipFilter) (model.MembershipCollection, error) {
-	c, err := app.storage.FindGroupMembershipsWithContext(context, clientID, filter)
-
-	if len(filter.GroupIDs) > 0 {
-		groups, err := app.findGroupsV3(clientID, model.GroupsFilter{
-			GroupIDs: filter.GroupIDs,
-		})
-		if err != nil {
-			return model.MembershipCollection{}, fmt.Errorf("app.findGroupMemberships() error: %s", err)
-		}
-
-		groupIDMapping := map[string]model.Group{}
-		for _, group := range groups {
-			groupIDMapping[group.ID] = group
-		}
+func (app *Application) findGroupMemberships(context storage.TransactionContext, clientID string, viewer model.MemberViewer, filter model.MembershipFilter) (model.MembershipCollection, error) {
+	err := model.ValidateProfileValuesFilter(filter.ProfileValues)
+	if err != nil {
+		return model.MembershipCollection{}, err
+	}
+	profileValues, err := app.filterVisibleProfileValues(clientID, viewer, filter.GroupIDs, filter.ProfileValues)
+	if err != nil {
+		return model.MembershipCollection{}, fmt.Errorf("app.findGroupMemberships() error: %s", err)
+	}
+	filter.ProfileValues = profileValues
+	labelIDs, err := app.filterVisibleLabelIDs(clientID, viewer, filter.GroupIDs, filter.LabelIDs)
+	if err != nil {
+		return model.MembershipCollection{}, fmt.Errorf("app.findGroupMemberships() error: %s", err)
+	}
+	filter.LabelIDs = labelIDs
 
-		for index, member := range c.Items {
-			if group, ok := groupIDMapping[member.GroupID]; ok {
-				c.Items[index].ApplyGroupSettings(group.Settings)
-			}
-		}
+	c, err := app.storage.FindGroupMembershipsWithContext(context, clientID, filter)
+	if err != nil {
+		return model.MembershipCollection{}, err
 	}
 
-	collection, err := app.protectByFerpa(c, c.Items)
+	err = app.applyMemberVisibility(clientID, viewer, c.Items)
 	if err != nil {
-		return model.MembershipCollection{}, fmt.Errorf("app.fprotectByFerpa() error: %s", err)
+		return model.MembershipCollection{}, fmt.Errorf("app.findGroupMemberships() error: %s", err)
 	}
 
-	return collection, err
+	return c, nil
 }
 
 // Check if a slice contains a value
@@ -86,32 +84,22 @@ func contains(slice []string, value string) bool {
 	return false
 }
 
-func (app *Application) protectByFerpa(col model.MembershipCollection, items []model.GroupMembership) (model.MembershipCollection, error) {
-	var userIds []string
-	for _, s := range items {
-		if s.UserID != "" {
-			userIds = append(userIds, s.UserID)
-		}
-	}
+func (app *Application) findGroupMembershipByID(clientID string, id string) (*model.GroupMembership, error) {
+	return app.storage.FindGroupMembershipByID(clientID, id)
+}
 
-	ferpa, err := app.corebb.RetrieveFerpaAccounts(userIds)
+func (app *Application) getMembershipStatusHistory(clientID string, membershipID string) ([]model.MembershipTransition, error) {
+	membership, err := app.storage.FindGroupMembershipByID(clientID, membershipID)
 	if err != nil {
-		return model.MembershipCollection{}, fmt.Errorf("RetrieveFerpaAccounts error: %s", err)
+		return nil, err
 	}
-
-	for i := range items {
-		if contains(ferpa, items[i].UserID) {
-			items[i] = model.GroupMembership{
-				UserID: items[i].UserID,
-			}
-		}
+	if membership == nil {
+		return nil, fmt.Errorf("membership %s not found", membershipID)
 	}
-
-	return col, nil
-}
-
-func (app *Application) findGroupMembershipByID(clientID string, id string) (*model.GroupMembership, error) {
-	return app.storage.FindGroupMembershipByID(clientID, id)
+	if membership.StatusHistory == nil {
+		return []model.MembershipTransition{}, nil
+	}
+	return membership.StatusHistory, nil
 }
 
 func (app *Application) findUserGroupMemberships(clientID string, userID string) (model.MembershipCollection, error) {
@@ -119,62 +107,93 @@ func (app *Application) findUserGroupMemberships(clientID string, userID string)
 }
 
 func (app *Application) createPendingMembership(clientID string, current *model.User, group *model.Group, member *model.GroupMembership) error {
+	if !model.IsValidDigestFrequency(member.NotificationsPreferences.DigestFrequency) {
+		return fmt.Errorf("invalid digest frequency %s", member.NotificationsPreferences.DigestFrequency)
+	}
+	if len(member.NotificationsPreferences.Locale) > model.MaxNotificationLocaleLength {
+		return fmt.Errorf("the locale is longer than %d characters", model.MaxNotificationLocaleLength)
+	}
 
 	if group.CanJoinAutomatically {
-		member.Status = "member"
+		member.Status = model.MembershipStatusMember
 	} else {
-		member.Status = "pending"
+		member.Status = model.MembershipStatusPending
 	}
 
-	err := app.storage.CreatePendingMembership(clientID, current, group, member)
+	// a rejected user may request again
+	previousStatus := ""
+	storedMembership, _ := app.storage.FindGroupMembership(clientID, group.ID, current.ID)
+	if storedMembership != nil && storedMembership.IsRejected() {
+		previousStatus = storedMembership.Status
+	}
+	transition, err := model.NewMembershipTransition(previousStatus, member.Status, model.MembershipTransitionSourceRequest, current, "")
 	if err != nil {
 		return err
 	}
+	member.StatusHistory = []model.MembershipTransition{*transition}
 
-	adminMemberships, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
-		GroupIDs: []string{group.ID},
-		Statuses: []string{"admin"},
-	})
-	if err == nil && len(adminMemberships.Items) > 0 {
-		if len(adminMemberships.Items) > 0 {
-			recipients := adminMemberships.GetMembersAsNotificationRecipients(func(member model.GroupMembership) (bool, bool) {
-				return true, member.NotificationsPreferences.OverridePreferences &&
-					(member.NotificationsPreferences.InvitationsMuted || member.NotificationsPreferences.AllMute)
-			})
-
-			if len(recipients) > 0 {
-				topic := "group.invitations"
-				groupStr := "Group"
-				if group.ResearchGroup {
-					groupStr = "Research Project"
-				}
+	transaction := func(context storage.TransactionContext) error {
+		err := app.storage.CreatePendingMembership(context, clientID, current, group, member)
+		if err != nil {
+			return err
+		}
+		if len(previousStatus) > 0 {
+			err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipStatusChanged,
+				model.WebhookMembershipStatusChangedData{Membership: *member, PreviousStatus: previousStatus})
+		} else {
+			err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipCreated, member)
+		}
+		if err != nil {
+			return err
+		}
 
-				message := fmt.Sprintf("New membership request for '%s' %s has been submitted", group.Title, strings.ToLower(groupStr))
-				if group.CanJoinAutomatically {
-					message = fmt.Sprintf("%s joined '%s' %s", member.GetDisplayName(), group.Title, strings.ToLower(groupStr))
-				}
+		adminMemberships, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
+			GroupIDs: []string{group.ID},
+			Statuses: []string{"admin"},
+		})
+		if err != nil {
+			return fmt.Errorf("error finding group admins: %s", err)
+		}
+		recipients := adminMemberships.GetMembersAsNotificationRecipients(func(member model.GroupMembership) (bool, bool) {
+			return true, member.NotificationsPreferences.OverridePreferences &&
+				(member.NotificationsPreferences.InvitationsMuted || member.NotificationsPreferences.AllMute)
+		})
+		if len(recipients) == 0 {
+			return nil
+		}
 
-				app.notifications.SendNotification(
-					recipients,
-					&topic,
-					fmt.Sprintf("%s - %s", groupStr, group.Title),
-					message,
-					map[string]string{
-						"type":        "group",
-						"operation":   "pending_member",
-						"entity_type": "group",
-						"entity_id":   group.ID,
-						"entity_name": group.Title,
-					},
-					current.AppID,
-					current.OrgID,
-					nil,
-				)
-			}
+		topic := "group.invitations"
+		data := model.NewNotificationTemplateData(group)
+		if group.CanJoinAutomatically {
+			data.Joined = true
+			data.UserName = member.GetDisplayName()
 		}
-	} else {
-		log.Printf("Unable to retrieve group by membership id: %s\n", err)
-		// return err // No reason to fail if the main part succeeds
+
+		return app.queueTemplatedNotification(
+			context,
+			clientID,
+			&group.ID,
+			model.NotificationOperationPendingMember,
+			data,
+			adminMemberships.GetNotificationLocales(),
+			recipients,
+			&topic,
+			map[string]string{
+				"type":        "group",
+				"operation":   model.NotificationOperationPendingMember,
+				"entity_type": "group",
+				"entity_id":   group.ID,
+				"entity_name": group.Title,
+			},
+			current.AppID,
+			current.OrgID,
+			notifications.DefaultPriority,
+		)
+	}
+
+	err = app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return err
 	}
 
 	if group.CanJoinAutomatically && group.AuthmanEnabled {
@@ -205,71 +224,77 @@ func (app *Application) createMembership(clientID string, current *model.User, g
 		}
 	}
 
-	err := app.storage.CreateMembership(clientID, current, group, membership)
+	err := membership.RecordCreation(model.MembershipTransitionSourceAdmin, current, "")
 	if err != nil {
 		return err
 	}
 
-	memberships, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
-		GroupIDs: []string{group.ID},
-		Statuses: []string{"admin"},
-	})
-	if err == nil && len(memberships.Items) > 0 {
+	transaction := func(context storage.TransactionContext) error {
+		err := app.storage.CreateMembership(context, clientID, current, group, membership)
+		if err != nil {
+			return err
+		}
+		err = app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipCreated, membership)
+		if err != nil {
+			return err
+		}
+
+		memberships, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
+			GroupIDs: []string{group.ID},
+			Statuses: []string{"admin"},
+		})
+		if err != nil {
+			return fmt.Errorf("error finding group admins: %s", err)
+		}
 		recipients := memberships.GetMembersAsNotificationRecipients(func(member model.GroupMembership) (bool, bool) {
 			return member.UserID != current.ID, member.NotificationsPreferences.OverridePreferences &&
 				(member.NotificationsPreferences.InvitationsMuted || membership.NotificationsPreferences.AllMute)
 		})
-
-		groupStr := "Group"
-		if group.ResearchGroup {
-			groupStr = "Research Project"
+		if len(recipients) == 0 {
+			return nil
 		}
 
-		var message string
-		if membership.Status == "membership" || membership.Status == "admin" {
-			message = fmt.Sprintf("New membership joined '%s' %s", group.Title, strings.ToLower(groupStr))
-		} else {
-			message = fmt.Sprintf("New membership request for '%s' %s has been submitted", group.Title, strings.ToLower(groupStr))
-		}
-
-		if len(recipients) > 0 {
-			topic := "group.invitations"
-			app.notifications.SendNotification(
-				recipients,
-				&topic,
-				fmt.Sprintf("%s - %s", groupStr, group.Title),
-				message,
-				map[string]string{
-					"type":        "group",
-					"operation":   "pending_member",
-					"entity_type": "group",
-					"entity_id":   group.ID,
-					"entity_name": group.Title,
-				},
-				current.AppID,
-				current.OrgID,
-				nil,
-			)
+		data := model.NewNotificationTemplateData(group)
+		data.Joined = membership.Status == "membership" || membership.Status == "admin"
+
+		topic := "group.invitations"
+		return app.queueTemplatedNotification(
+			context,
+			clientID,
+			&group.ID,
+			model.NotificationOperationPendingMember,
+			data,
+			memberships.GetNotificationLocales(),
+			recipients,
+			&topic,
+			map[string]string{
+				"type":        "group",
+				"operation":   model.NotificationOperationPendingMember,
+				"entity_type": "group",
+				"entity_id":   group.ID,
+				"entity_name": group.Title,
+			},
+			current.AppID,
+			current.OrgID,
+			notifications.DefaultPriority,
+		)
+	}
 
-		}
+	err = app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return err
+	}
 
-		if group.AuthmanEnabled && group.AuthmanGroup != nil {
-			err = app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, membership.ExternalID)
-			if err != nil {
-				return err
-			}
+	if group.AuthmanEnabled && group.AuthmanGroup != nil {
+		err = app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, membership.ExternalID)
+		if err != nil {
+			return err
 		}
-
-	} else if err != nil {
-		log.Printf("Unable to retrieve group by membership id: %s\n", err)
-		// return err // No reason to fail if the main part succeeds
 	}
-	if err == nil && group != nil {
-		if group.CanJoinAutomatically && group.AuthmanEnabled {
-			err := app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, current.ExternalID)
-			if err != nil {
-				log.Printf("err app.createMembership() - error storing membership in Authman: %s", err)
-			}
+	if group.CanJoinAutomatically && group.AuthmanEnabled {
+		err := app.authman.AddAuthmanMemberToGroup(*group.AuthmanGroup, current.ExternalID)
+		if err != nil {
+			log.Printf("err app.createMembership() - error storing membership in Authman: %s", err)
 		}
 	}
 
@@ -277,7 +302,7 @@ func (app *Application) createMembership(clientID string, current *model.User, g
 }
 
 func (app *Application) deletePendingMembership(clientID string, current *model.User, groupID string) error {
-	err := app.storage.DeleteMembership(clientID, groupID, current.ID)
+	err := app.deleteUserMembership(clientID, groupID, current.ID)
 	if err != nil {
 		return err
 	}
@@ -295,13 +320,35 @@ func (app *Application) deletePendingMembership(clientID string, current *model.
 	return nil
 }
 
+// deleteUserMembership deletes the membership of the user within the group and publishes its removal in the same transaction
+func (app *Application) deleteUserMembership(clientID string, groupID string, userID string) error {
+	return app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+		membership, err := app.storage.FindGroupMembershipWithContext(context, clientID, groupID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = app.storage.DeleteMembershipWithContext(context, clientID, groupID, userID)
+		if err != nil || membership == nil {
+			return err
+		}
+		return app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipRemoved, membership)
+	})
+}
+
 func (app *Application) deleteMembershipByID(clientID string, current *model.User, membershipID string) error {
 
 	membership, _ := app.storage.FindGroupMembershipByID(clientID, membershipID)
 
 	if membership != nil {
 
-		err := app.storage.DeleteMembershipByID(clientID, current, membership.ID)
+		err := app.storage.PerformTransaction(func(context storage.TransactionContext) error {
+			err := app.storage.DeleteMembershipByID(context, clientID, current, membership.ID)
+			if err != nil {
+				return err
+			}
+			return app.publishWebhookEvent(context, clientID, model.WebhookEventMembershipRemoved, membership)
+		})
 		if err != nil {
 			return err
 		}
@@ -320,7 +367,7 @@ func (app *Application) deleteMembershipByID(clientID string, current *model.Use
 }
 
 func (app *Application) deleteMembership(clientID string, current *model.User, groupID string) error {
-	err := app.storage.DeleteMembership(clientID, groupID, current.ID)
+	err := app.deleteUserMembership(clientID, groupID, current.ID)
 	if err != nil {
 		return err
 	}
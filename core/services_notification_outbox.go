@@ -0,0 +1,132 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/notifications"
+	"groups/driven/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// outboxLease is the time for which a claimed notification is hidden from the other workers
+	outboxLease = 5 * time.Minute
+	// outboxBatchSize is the max count of notifications delivered on a single worker tick
+	outboxBatchSize = 100
+)
+
+// queueNotification stores a notification intent in the outbox. Pass the transaction context of the domain change
+// so that the notification is persisted only if the change is committed.
+func (app *Application) queueNotification(context storage.TransactionContext, clientID string, recipients []notifications.Recipient, topic *string,
+	title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time) error {
+	return app.queueNotificationWithPriority(context, clientID, recipients, topic, title, text, data, appID, orgID, dateScheduled, notifications.DefaultPriority)
+}
+
+// queueNotificationWithPriority stores a notification intent with the desired priority in the outbox
+func (app *Application) queueNotificationWithPriority(context storage.TransactionContext, clientID string, recipients []notifications.Recipient, topic *string,
+	title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time, priority int) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	notification := model.OutboxNotification{
+		ID:              uuid.NewString(),
+		ClientID:        clientID,
+		Recipients:      recipients,
+		Topic:           topic,
+		Title:           title,
+		Text:            text,
+		Data:            data,
+		AppID:           appID,
+		OrgID:           orgID,
+		DateScheduled:   dateScheduled,
+		Priority:        priority,
+		Status:          model.OutboxStatusPending,
+		DateNextAttempt: now,
+		DateCreated:     now,
+	}
+	err := app.storage.InsertOutboxNotification(context, notification)
+	if err != nil {
+		return fmt.Errorf("error queueing notification: %s", err)
+	}
+	return nil
+}
+
+// processNotificationOutbox delivers the due outbox notifications. The failed deliveries are retried with
+// exponential backoff and dead-lettered after model.OutboxMaxAttempts attempts.
+func (app *Application) processNotificationOutbox() error {
+	sent := 0
+	failed := 0
+	for i := 0; i < outboxBatchSize; i++ {
+		notification, err := app.storage.ClaimOutboxNotification(time.Now().UTC(), outboxLease)
+		if err != nil {
+			return err
+		}
+		if notification == nil {
+			break
+		}
+
+		attempts := notification.Attempts + 1
+		err = app.notifications.SendNotificationWithPriority(notification.Recipients, notification.Topic, notification.Title, notification.Text,
+			notification.Data, notification.AppID, notification.OrgID, notification.DateScheduled, notification.Priority)
+		if err == nil {
+			sent++
+			err = app.storage.MarkOutboxNotificationSent(notification.ID, attempts)
+			if err != nil {
+				log.Printf("processNotificationOutbox: error marking notification %s as sent: %s", notification.ID, err)
+			}
+			continue
+		}
+
+		failed++
+		status := model.OutboxStatusPending
+		if attempts >= model.OutboxMaxAttempts {
+			status = model.OutboxStatusDead
+			log.Printf("processNotificationOutbox: notification %s is dead-lettered after %d attempts: %s", notification.ID, attempts, err)
+		}
+		markErr := app.storage.MarkOutboxNotificationFailed(notification.ID, attempts, err.Error(), status, time.Now().UTC().Add(model.GetRetryDelay(attempts)))
+		if markErr != nil {
+			log.Printf("processNotificationOutbox: error marking notification %s as failed: %s", notification.ID, markErr)
+		}
+	}
+	if sent > 0 || failed > 0 {
+		log.Printf("processNotificationOutbox: sent %d, failed %d notifications", sent, failed)
+	}
+	return nil
+}
+
+func (app *Application) getOutboxNotifications(clientID string, status *string, offset *int64, limit *int64) ([]model.OutboxNotification, error) {
+	if status != nil && !model.IsValidOutboxStatus(*status) {
+		return nil, fmt.Errorf("invalid outbox status: %s", *status)
+	}
+	return app.storage.FindOutboxNotifications(clientID, status, offset, limit)
+}
+
+func (app *Application) replayOutboxNotification(clientID string, id string) error {
+	replayed, err := app.storage.ReplayOutboxNotification(clientID, id)
+	if err != nil {
+		return err
+	}
+	if !replayed {
+		return fmt.Errorf("dead-lettered notification %s not found", id)
+	}
+	return nil
+}
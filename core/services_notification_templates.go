@@ -0,0 +1,125 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/notifications"
+	"groups/driven/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// queueTemplatedNotification renders the notification of the operation with the most specific template for every
+// recipient locale and queues it. locales maps the recipient user ids to their locales, nil means the default locale for all.
+func (app *Application) queueTemplatedNotification(context storage.TransactionContext, clientID string, groupID *string, operation string,
+	data model.NotificationTemplateData, locales map[string]string, recipients []notifications.Recipient, topic *string,
+	payload map[string]string, appID string, orgID string, priority int) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	templates, err := app.storage.FindApplicableNotificationTemplates(context, clientID, groupID, operation)
+	if err != nil {
+		return fmt.Errorf("error finding notification templates: %s", err)
+	}
+
+	recipientsByLocale := map[string][]notifications.Recipient{}
+	for _, recipient := range recipients {
+		locale := locales[recipient.UserID]
+		recipientsByLocale[locale] = append(recipientsByLocale[locale], recipient)
+	}
+
+	for locale, localeRecipients := range recipientsByLocale {
+		template := model.SelectNotificationTemplate(templates, operation, groupID, locale)
+		title, body, err := template.Render(data)
+		if err != nil {
+			log.Printf("error app.queueTemplatedNotification() - template %s: %s", template.ID, err)
+			defaultTemplate := model.DefaultNotificationTemplates[operation]
+			title, body, err = defaultTemplate.Render(data)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = app.queueNotificationWithPriority(context, clientID, localeRecipients, topic, title, body, payload, appID, orgID, nil, priority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (app *Application) getNotificationTemplates(clientID string, groupID *string, operation *string) ([]model.NotificationTemplate, error) {
+	return app.storage.FindNotificationTemplates(clientID, groupID, operation)
+}
+
+func (app *Application) getDefaultNotificationTemplates() map[string]model.NotificationTemplate {
+	return model.DefaultNotificationTemplates
+}
+
+func (app *Application) createNotificationTemplate(clientID string, template model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	err := template.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if template.GroupID != nil {
+		group, err := app.storage.FindGroup(nil, clientID, *template.GroupID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error finding group %s: %s", *template.GroupID, err)
+		}
+		if group == nil {
+			return nil, fmt.Errorf("missing group for id %s", *template.GroupID)
+		}
+	}
+
+	template.ID = uuid.NewString()
+	template.ClientID = clientID
+	template.DateCreated = time.Now().UTC()
+	template.DateUpdated = nil
+	err = app.storage.InsertNotificationTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (app *Application) updateNotificationTemplate(clientID string, template model.NotificationTemplate) error {
+	existing, err := app.storage.FindNotificationTemplate(clientID, template.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("notification template could not be found for id: %s", template.ID)
+	}
+
+	// the operation, the group and the locale identify the template and cannot be changed
+	existing.Title = template.Title
+	existing.Body = template.Body
+	err = existing.Validate()
+	if err != nil {
+		return err
+	}
+
+	return app.storage.UpdateNotificationTemplate(*existing)
+}
+
+func (app *Application) deleteNotificationTemplate(clientID string, id string) error {
+	return app.storage.DeleteNotificationTemplate(clientID, id)
+}
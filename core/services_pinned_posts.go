@@ -0,0 +1,219 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/notifications"
+	"groups/driven/storage"
+	"log"
+	"time"
+)
+
+func (app *Application) getPinnedPosts(clientID string, current *model.User, groupID string) ([]model.Post, error) {
+	posts, err := app.storage.FindPinnedPosts(nil, clientID, &current.ID, groupID, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range posts {
+		posts[i].ApplyMyReactions(current.ID)
+	}
+	return posts, nil
+}
+
+// preparePinnedPostForCreate validates the pin & announcement flags of a new post. Only group admins may pin top level posts.
+// The pinned posts are counted within the transaction which inserts the post.
+func (app *Application) preparePinnedPostForCreate(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, post *model.Post) error {
+	post.PinnedBy = nil
+	post.DatePinned = nil
+
+	isAdmin := group != nil && group.CurrentMember != nil && group.CurrentMember.IsAdmin()
+	if !isAdmin || post.ParentID != nil {
+		post.Pinned = false
+		post.IsAnnouncement = false
+		post.DateAnnouncementExpires = nil
+		return nil
+	}
+
+	if post.IsAnnouncement {
+		post.Pinned = true
+	} else {
+		post.DateAnnouncementExpires = nil
+	}
+	if !post.Pinned {
+		return nil
+	}
+
+	if post.DateAnnouncementExpires != nil && post.DateAnnouncementExpires.Before(time.Now()) {
+		return fmt.Errorf("the announcement expiration date is in the past")
+	}
+
+	count, err := app.storage.CountPinnedPosts(context, clientID, group.ID)
+	if err != nil {
+		return fmt.Errorf("error counting pinned posts: %s", err)
+	}
+	if group.Settings == nil {
+		settings := model.DefaultGroupSettings()
+		group.Settings = &settings
+	}
+	if count >= int64(group.Settings.PostPreferences.GetMaxPinnedPosts()) {
+		return fmt.Errorf("the group already has the max count of %d pinned posts", group.Settings.PostPreferences.GetMaxPinnedPosts())
+	}
+
+	now := time.Now()
+	post.PinnedBy = &current.ID
+	post.DatePinned = &now
+	return nil
+}
+
+func (app *Application) pinPost(clientID string, current *model.User, group *model.Group, postID string, pinned bool, isAnnouncement bool, dateAnnouncementExpires *time.Time) (*model.Post, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can pin posts")
+	}
+	if dateAnnouncementExpires != nil && dateAnnouncementExpires.Before(time.Now()) {
+		return nil, fmt.Errorf("the announcement expiration date is in the past")
+	}
+	if isAnnouncement {
+		pinned = true
+	} else {
+		dateAnnouncementExpires = nil
+	}
+
+	maxPinnedPosts := model.DefaultMaxPinnedPosts
+	if group.Settings != nil {
+		maxPinnedPosts = group.Settings.PostPreferences.GetMaxPinnedPosts()
+	}
+
+	var post *model.Post
+	var announce bool
+	transaction := func(context storage.TransactionContext) error {
+		var err error
+		post, err = app.storage.FindPost(context, clientID, &current.ID, group.ID, postID, true, false)
+		if err != nil {
+			return fmt.Errorf("error finding post: %s", err)
+		}
+		if post == nil {
+			return fmt.Errorf("missing post for id %s", postID)
+		}
+		if post.ParentID != nil {
+			return fmt.Errorf("only top level posts can be pinned")
+		}
+		if !post.IsPublished() {
+			return fmt.Errorf("only published posts can be pinned")
+		}
+
+		if pinned && !post.Pinned {
+			count, err := app.storage.CountPinnedPosts(context, clientID, group.ID)
+			if err != nil {
+				return fmt.Errorf("error counting pinned posts: %s", err)
+			}
+			if count >= int64(maxPinnedPosts) {
+				return fmt.Errorf("the group already has the max count of %d pinned posts", maxPinnedPosts)
+			}
+		}
+
+		announce = isAnnouncement && !post.IsActiveAnnouncement()
+
+		err = app.storage.UpdatePostPinned(context, clientID, group.ID, postID, pinned, &current.ID, isAnnouncement, dateAnnouncementExpires)
+		if err != nil {
+			return fmt.Errorf("error pinning post: %s", err)
+		}
+
+		if announce {
+			return app.sendGroupNotificationForAnnouncement(context, clientID, current, group, post)
+		}
+		return nil
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Pinned = pinned
+	post.IsAnnouncement = isAnnouncement
+	post.DateAnnouncementExpires = dateAnnouncementExpires
+	if pinned {
+		now := time.Now()
+		post.PinnedBy = &current.ID
+		post.DatePinned = &now
+	} else {
+		post.PinnedBy = nil
+		post.DatePinned = nil
+	}
+
+	return post, nil
+}
+
+func (app *Application) sendGroupNotificationForAnnouncement(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, post *model.Post) error {
+	recipientsUserIDs, err := app.getPostNotificationRecipientsAsUserIDs(clientID, post, &current.ID)
+	if err != nil {
+		return err
+	}
+	if recipientsUserIDs != nil && len(recipientsUserIDs) == 0 {
+		// the announcement is addressed to nobody else
+		return nil
+	}
+
+	result, err := app.storage.FindGroupMemberships(clientID, model.MembershipFilter{
+		GroupIDs: []string{group.ID},
+		UserIDs:  recipientsUserIDs,
+		Statuses: []string{"member", "admin"},
+	})
+	if err != nil {
+		log.Printf("error app.sendGroupNotificationForAnnouncement() - %s", err)
+		return fmt.Errorf("error app.sendGroupNotificationForAnnouncement() - %s", err)
+	}
+
+	recipients := result.GetMembersAsNotificationRecipients(func(member model.GroupMembership) (bool, bool) {
+		return member.IsAdminOrMember() && (current.ID != member.UserID),
+			member.NotificationsPreferences.OverridePreferences &&
+				(member.NotificationsPreferences.PostsMuted || member.NotificationsPreferences.AllMute)
+	})
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	data := model.NewNotificationTemplateData(group)
+	data.UserName = current.Name
+	data.PostSubject = post.Subject
+	data.PostBody = post.Body
+
+	topic := "group.posts"
+	return app.queueTemplatedNotification(
+		context,
+		clientID,
+		&group.ID,
+		model.NotificationOperationPostAnnouncement,
+		data,
+		result.GetNotificationLocales(),
+		recipients,
+		&topic,
+		map[string]string{
+			"type":         "group",
+			"operation":    model.NotificationOperationPostAnnouncement,
+			"entity_type":  "group",
+			"entity_id":    group.ID,
+			"entity_name":  group.Title,
+			"post_id":      post.ID,
+			"post_subject": post.Subject,
+			"post_body":    post.Body,
+		},
+		app.config.AppID,
+		app.config.OrgID,
+		notifications.AnnouncementPriority,
+	)
+}
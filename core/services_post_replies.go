@@ -0,0 +1,71 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+)
+
+const (
+	// maxRepliesDepth is the max count of reply levels which can be loaded with one call
+	maxRepliesDepth = 5
+	// defaultRepliesLimit is the count of replies loaded on every level when no limit is provided
+	defaultRepliesLimit int64 = 20
+	// maxRepliesLimit is the max count of replies which can be loaded on every level
+	maxRepliesLimit int64 = 100
+)
+
+// normalizeRepliesLimit applies the default and the max replies limit
+func normalizeRepliesLimit(limit *int64) *int64 {
+	value := defaultRepliesLimit
+	if limit != nil && *limit > 0 {
+		value = *limit
+	}
+	if value > maxRepliesLimit {
+		value = maxRepliesLimit
+	}
+	return &value
+}
+
+func (app *Application) getPostReplies(clientID string, current *model.User, groupID string, postID string, offset *int64, limit *int64, depth int, order *string) ([]model.Post, error) {
+	membership, _ := app.storage.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		return nil, fmt.Errorf("the user is not member or admin of the group")
+	}
+	isAdmin := membership.IsAdmin()
+
+	post, _ := app.storage.FindPostWithoutReplies(nil, clientID, &current.ID, groupID, postID, !isAdmin)
+	post = applyPostModerationVisibility(post, current.ID, isAdmin)
+	if post == nil || (post.IsExpired() && !isAdmin) {
+		return nil, fmt.Errorf("the post %s is not available", postID)
+	}
+
+	if depth < 1 {
+		depth = 1
+	} else if depth > maxRepliesDepth {
+		depth = maxRepliesDepth
+	}
+
+	limit = normalizeRepliesLimit(limit)
+	replies, err := app.storage.FindReplies(nil, clientID, current.ID, isAdmin, groupID, postID, offset, limit, depth, order)
+	if err != nil {
+		return nil, err
+	}
+	for i := range replies {
+		replies[i].ApplyMyReactions(current.ID)
+	}
+	return replies, nil
+}
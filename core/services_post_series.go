@@ -0,0 +1,228 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (app *Application) createPostSeries(clientID string, current *model.User, group *model.Group, series model.PostSeries) (*model.PostSeries, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can create recurring posts")
+	}
+
+	err := series.Recurrence.ApplyRRule()
+	if err != nil {
+		return nil, err
+	}
+	err = series.Recurrence.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if series.TimeZone != "" {
+		if _, err := time.LoadLocation(series.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %s", series.TimeZone)
+		}
+	}
+	series.ToLabelIDs, err = model.ValidateMemberLabelIDs(series.ToLabelIDs, group.MemberLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	series.ID = uuid.NewString()
+	series.ClientID = clientID
+	series.GroupID = group.ID
+	series.Creator = model.Creator{
+		UserID: current.ID,
+		Email:  current.Email,
+		Name:   current.Name,
+	}
+	series.Status = model.PostSeriesStatusActive
+	series.OccurrencesCount = 0
+	series.DateLastOccurrence = nil
+	series.DateCreated = now
+	series.DateUpdated = nil
+	series.DateNextOccurrence = series.NextOccurrence(now)
+	if series.DateNextOccurrence == nil {
+		return nil, fmt.Errorf("the recurring post has no occurrences in the future")
+	}
+
+	err = app.storage.InsertPostSeries(series)
+	if err != nil {
+		return nil, fmt.Errorf("error creating post series: %s", err)
+	}
+	return &series, nil
+}
+
+func (app *Application) getPostSeriesList(clientID string, current *model.User, group *model.Group, statuses []string) ([]model.PostSeries, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can see recurring posts")
+	}
+	return app.storage.FindPostSeriesList(clientID, group.ID, statuses)
+}
+
+func (app *Application) updatePostSeriesStatus(clientID string, current *model.User, group *model.Group, id string, status string) (*model.PostSeries, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can update recurring posts")
+	}
+
+	var series *model.PostSeries
+	transaction := func(context storage.TransactionContext) error {
+		var err error
+		series, err = app.storage.FindPostSeries(context, clientID, group.ID, id)
+		if err != nil {
+			return fmt.Errorf("error finding post series: %s", err)
+		}
+		if series == nil {
+			return fmt.Errorf("missing post series for id %s", id)
+		}
+		if series.Status == model.PostSeriesStatusCanceled || series.Status == model.PostSeriesStatusCompleted {
+			return fmt.Errorf("the post series %s is already %s", id, series.Status)
+		}
+
+		next := series.DateNextOccurrence
+		switch status {
+		case model.PostSeriesStatusActive:
+			// the occurrences missed while the series was paused are skipped
+			next = series.NextOccurrence(time.Now())
+			if next == nil {
+				status = model.PostSeriesStatusCompleted
+			}
+		case model.PostSeriesStatusPaused:
+		case model.PostSeriesStatusCanceled:
+			next = nil
+		default:
+			return fmt.Errorf("invalid post series status %s", status)
+		}
+
+		err = app.storage.UpdatePostSeriesStatus(context, clientID, group.ID, id, status, next)
+		if err != nil {
+			return fmt.Errorf("error updating post series: %s", err)
+		}
+		series.Status = status
+		series.DateNextOccurrence = next
+		return nil
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (app *Application) processPostSeries() error {
+	log.Printf("processPostSeries:BEGIN")
+	defer log.Printf("processPostSeries:END")
+
+	startTime := time.Now()
+	syncKey := "post_series"
+	var list []model.PostSeries
+	transaction := func(context storage.TransactionContext) error {
+		err := app.checkForConcurentRun(context, startTime, syncKey)
+		if err != nil {
+			return err
+		}
+
+		list, err = app.storage.FindDuePostSeries(context, startTime)
+		return err
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		log.Printf("processPostSeries task running on another instance. error: %s", err)
+		return err
+	}
+
+	log.Printf("processPostSeries: Found %d due post series", len(list))
+	for _, series := range list {
+		err = app.publishPostSeriesOccurrence(series, startTime)
+		if err != nil {
+			log.Printf("processPostSeries: error publishing occurrence of series %s - %s", series.ID, err)
+		}
+	}
+
+	endTime := time.Now()
+	return app.storage.SaveSyncTimes(nil, model.SyncTimes{StartTime: &startTime, EndTime: &endTime, Key: syncKey})
+}
+
+// publishPostSeriesOccurrence creates the post for the due occurrence of the series and moves the series to its next occurrence
+func (app *Application) publishPostSeriesOccurrence(series model.PostSeries, now time.Time) error {
+	if series.DateNextOccurrence == nil {
+		return nil
+	}
+	occurrence := *series.DateNextOccurrence
+
+	series.OccurrencesCount++
+	// the occurrences missed while the service was down are skipped
+	next := series.NextOccurrence(now)
+	claimed, err := app.storage.ClaimPostSeriesOccurrence(nil, series, occurrence, next)
+	if err != nil {
+		return fmt.Errorf("error claiming occurrence: %s", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	group, err := app.storage.FindGroup(nil, series.ClientID, series.GroupID, &series.Creator.UserID)
+	if err != nil {
+		return fmt.Errorf("error finding group: %s", err)
+	}
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("publishPostSeriesOccurrence: the creator of series %s is not a group admin anymore - canceling", series.ID)
+		return app.storage.UpdatePostSeriesStatus(nil, series.ClientID, series.GroupID, series.ID, model.PostSeriesStatusCanceled, nil)
+	}
+
+	// the labels removed from the group since the series was created are not addressed any more
+	var toLabelIDs []string
+	for _, labelID := range series.ToLabelIDs {
+		if model.FindMemberLabel(group.MemberLabels, labelID) != nil {
+			toLabelIDs = append(toLabelIDs, labelID)
+		}
+	}
+	if len(series.ToLabelIDs) > 0 && len(toLabelIDs) == 0 && len(series.ToMembersList) == 0 {
+		log.Printf("publishPostSeriesOccurrence: the member labels of series %s do not exist anymore - skipping the occurrence", series.ID)
+		return nil
+	}
+
+	creator := &model.User{
+		ID:    series.Creator.UserID,
+		Email: series.Creator.Email,
+		Name:  series.Creator.Name,
+	}
+	post := &model.Post{
+		GroupID:           series.GroupID,
+		Subject:           series.Subject,
+		Body:              series.Body,
+		Private:           series.Private,
+		UseAsNotification: series.UseAsNotification,
+		ImageURL:          series.ImageURL,
+		ToMembersList:     series.ToMembersList,
+		ToLabelIDs:        toLabelIDs,
+		SeriesID:          &series.ID,
+	}
+	_, err = app.createGroupPost(series.ClientID, creator, post, group)
+	if err != nil {
+		return fmt.Errorf("error creating post: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"log"
+	"time"
+)
+
+// preparePostExpiration validates the expiration date of a new post. Only top level posts expire, the replies are hidden together with their top level post.
+func preparePostExpiration(post *model.Post) error {
+	post.DateExpired = nil
+	if post.ParentID != nil {
+		post.DateExpires = nil
+		return nil
+	}
+	if post.DateExpires != nil && post.DateExpires.Before(time.Now()) {
+		return fmt.Errorf("the expiration date is in the past")
+	}
+	return nil
+}
+
+func (app *Application) getExpiredPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can see expired posts")
+	}
+	return app.storage.FindExpiredPosts(clientID, group.ID, offset, limit)
+}
+
+func (app *Application) processExpiredPosts() error {
+	log.Printf("processExpiredPosts:BEGIN")
+	defer log.Printf("processExpiredPosts:END")
+
+	startTime := time.Now()
+	syncKey := "expired_posts"
+	transaction := func(context storage.TransactionContext) error {
+		err := app.checkForConcurentRun(context, startTime, syncKey)
+		if err != nil {
+			return err
+		}
+
+		posts, err := app.storage.FindPostsToExpire(context, startTime)
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			err = app.storage.HideExpiredPost(context, post.ClientID, post.GroupID, post.ID, startTime)
+			if err != nil {
+				return fmt.Errorf("error hiding expired post %s: %s", post.ID, err)
+			}
+		}
+		log.Printf("processExpiredPosts: Hidden %d expired posts", len(posts))
+
+		posts, err = app.storage.FindPostsToPurge(context, startTime.Add(-model.ExpiredPostsGracePeriod))
+		if err != nil {
+			return err
+		}
+		for _, post := range posts {
+			err = app.storage.PurgeExpiredPost(context, post.ClientID, post.GroupID, post.ID)
+			if err != nil {
+				return fmt.Errorf("error purging expired post %s: %s", post.ID, err)
+			}
+		}
+		log.Printf("processExpiredPosts: Purged %d expired posts", len(posts))
+
+		endTime := time.Now()
+		return app.storage.SaveSyncTimes(context, model.SyncTimes{StartTime: &startTime, EndTime: &endTime, Key: syncKey})
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		log.Printf("processExpiredPosts task error: %s", err)
+		return err
+	}
+	return nil
+}
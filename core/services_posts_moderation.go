@@ -0,0 +1,206 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"groups/driven/notifications"
+	"groups/driven/storage"
+	"log"
+)
+
+// postRequiresApproval checks if a new post of the current member must wait for a group admin review
+func postRequiresApproval(group *model.Group) bool {
+	if group == nil || group.Settings == nil || !group.Settings.PostPreferences.RequirePostApproval {
+		return false
+	}
+	return group.CurrentMember == nil || !group.CurrentMember.IsAdmin()
+}
+
+// applyPostModerationVisibility removes the posts which wait for review or are rejected unless the user is their creator or a group admin
+func applyPostModerationVisibility(post *model.Post, userID string, isAdmin bool) *model.Post {
+	if post == nil || isAdmin {
+		return post
+	}
+	if !post.IsPublished() && post.Creator.UserID != userID {
+		return nil
+	}
+
+	if len(post.Replies) > 0 {
+		replies := make([]model.Post, 0, len(post.Replies))
+		for _, reply := range post.Replies {
+			if visibleReply := applyPostModerationVisibility(&reply, userID, isAdmin); visibleReply != nil {
+				replies = append(replies, *visibleReply)
+			}
+		}
+		post.Replies = replies
+	}
+	return post
+}
+
+func (app *Application) getPendingPosts(clientID string, current *model.User, group *model.Group, offset *int64, limit *int64) ([]model.Post, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can review posts")
+	}
+	return app.storage.FindPendingPosts(clientID, group.ID, offset, limit)
+}
+
+func (app *Application) reviewPost(clientID string, current *model.User, group *model.Group, postID string, approve bool, rejectReason string) (*model.Post, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can review posts")
+	}
+
+	status := model.PostStatusApproved
+	if !approve {
+		status = model.PostStatusRejected
+	} else {
+		rejectReason = ""
+	}
+
+	var post *model.Post
+	transaction := func(context storage.TransactionContext) error {
+		var err error
+		post, err = app.storage.FindPost(context, clientID, &current.ID, group.ID, postID, true, false)
+		if err != nil {
+			return fmt.Errorf("error finding post: %s", err)
+		}
+		if post == nil {
+			return fmt.Errorf("missing post for id %s", postID)
+		}
+		if post.Status != model.PostStatusPendingReview {
+			return fmt.Errorf("the post %s does not wait for review", postID)
+		}
+
+		err = app.storage.UpdatePostStatus(context, clientID, group.ID, postID, status, &current.ID, rejectReason)
+		if err != nil {
+			return fmt.Errorf("error updating post status: %s", err)
+		}
+
+		post.Status = status
+		post.ReviewedBy = &current.ID
+		post.RejectReason = rejectReason
+
+		// Scheduled posts are announced by the scheduled posts task once they are approved
+		if approve && post.DateScheduled == nil {
+			err = app.sendGroupNotificationForNewPost(context, clientID, &post.Creator.UserID, &post.Creator.Name, group, post)
+			if err != nil {
+				return err
+			}
+		}
+		return app.sendPostReviewNotification(context, clientID, group, post, approve)
+	}
+
+	err := app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (app *Application) sendGroupNotificationForPendingPost(context storage.TransactionContext, clientID string, current *model.User, group *model.Group, post *model.Post) error {
+	result, err := app.storage.FindGroupMembershipsWithContext(context, clientID, model.MembershipFilter{
+		GroupIDs: []string{group.ID},
+		Statuses: []string{"admin"},
+	})
+	if err != nil {
+		log.Printf("error app.sendGroupNotificationForPendingPost() - %s", err)
+		return fmt.Errorf("error app.sendGroupNotificationForPendingPost() - %s", err)
+	}
+
+	recipients := result.GetMembersAsNotificationRecipients(func(member model.GroupMembership) (bool, bool) {
+		return member.UserID != current.ID,
+			member.NotificationsPreferences.OverridePreferences &&
+				(member.NotificationsPreferences.PostsMuted || member.NotificationsPreferences.AllMute)
+	})
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	data := model.NewNotificationTemplateData(group)
+	data.UserName = current.Name
+	data.PostSubject = post.Subject
+	data.PostBody = post.Body
+
+	topic := "group.posts"
+	return app.queueTemplatedNotification(
+		context,
+		clientID,
+		&group.ID,
+		model.NotificationOperationPostPendingReview,
+		data,
+		result.GetNotificationLocales(),
+		recipients,
+		&topic,
+		map[string]string{
+			"type":         "group",
+			"operation":    model.NotificationOperationPostPendingReview,
+			"entity_type":  "group",
+			"entity_id":    group.ID,
+			"entity_name":  group.Title,
+			"post_id":      post.ID,
+			"post_subject": post.Subject,
+			"post_body":    post.Body,
+		},
+		app.config.AppID,
+		app.config.OrgID,
+		notifications.DefaultPriority,
+	)
+}
+
+func (app *Application) sendPostReviewNotification(context storage.TransactionContext, clientID string, group *model.Group, post *model.Post, approved bool) error {
+	operation := model.NotificationOperationPostApproved
+	if !approved {
+		operation = model.NotificationOperationPostRejected
+	}
+	data := model.NewNotificationTemplateData(group)
+	data.PostSubject = post.Subject
+	data.Reason = post.RejectReason
+
+	var locales map[string]string
+	membership, _ := app.storage.FindGroupMembership(clientID, group.ID, post.Creator.UserID)
+	if membership != nil {
+		locales = map[string]string{membership.UserID: membership.NotificationsPreferences.Locale}
+	}
+
+	topic := "group.posts"
+	err := app.queueTemplatedNotification(
+		context,
+		clientID,
+		&group.ID,
+		operation,
+		data,
+		locales,
+		[]notifications.Recipient{{UserID: post.Creator.UserID, Name: post.Creator.Name}},
+		&topic,
+		map[string]string{
+			"type":         "group",
+			"operation":    operation,
+			"entity_type":  "group",
+			"entity_id":    group.ID,
+			"entity_name":  group.Title,
+			"post_id":      post.ID,
+			"post_subject": post.Subject,
+		},
+		app.config.AppID,
+		app.config.OrgID,
+		notifications.DefaultPriority,
+	)
+	if err != nil {
+		log.Printf("error app.sendPostReviewNotification() - %s", err)
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+)
+
+func (app *Application) updateGroupProfileFields(clientID string, current *model.User, group *model.Group, fields []model.MemberProfileField) ([]model.MemberProfileField, error) {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return nil, fmt.Errorf("only group admins can update the profile fields")
+	}
+	if fields == nil {
+		fields = []model.MemberProfileField{}
+	}
+	err := model.ValidateMemberProfileFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	removedKeys := []string{}
+	for _, field := range group.ProfileFields {
+		if model.FindMemberProfileField(fields, field.Key) == nil {
+			removedKeys = append(removedKeys, field.Key)
+		}
+	}
+
+	err = app.storage.UpdateGroupProfileFields(clientID, group.ID, fields, removedKeys)
+	if err != nil {
+		return nil, fmt.Errorf("error updating the profile fields of group %s: %s", group.ID, err)
+	}
+	return fields, nil
+}
+
+func (app *Application) updateMemberProfileValues(clientID string, current *model.User, group *model.Group, membershipID string, values map[string]interface{}) error {
+	if group == nil || group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		return fmt.Errorf("only group admins can update the profile values of the members")
+	}
+	membership, err := app.storage.FindGroupMembershipByID(clientID, membershipID)
+	if err != nil || membership == nil || membership.GroupID != group.ID {
+		return fmt.Errorf("membership %s not found in group %s", membershipID, group.ID)
+	}
+
+	values, err = normalizeProfileValues(group.ProfileFields, values, false)
+	if err != nil {
+		return err
+	}
+	return app.storage.UpdateMembershipProfileValues(clientID, membershipID, values)
+}
+
+func (app *Application) updateOwnProfileValues(clientID string, current *model.User, group *model.Group, values map[string]interface{}) error {
+	if group == nil || group.CurrentMember == nil || group.CurrentMember.IsRejected() {
+		return fmt.Errorf("only the group members can update their profile values")
+	}
+
+	values, err := normalizeProfileValues(group.ProfileFields, values, true)
+	if err != nil {
+		return err
+	}
+	return app.storage.UpdateMembershipProfileValues(clientID, group.CurrentMember.ID, values)
+}
+
+// normalizeProfileValues checks the values against the group profile fields
+func normalizeProfileValues(fields []model.MemberProfileField, values map[string]interface{}, memberEditableOnly bool) (map[string]interface{}, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("the profile values are required")
+	}
+
+	result := map[string]interface{}{}
+	for key, value := range values {
+		field := model.FindMemberProfileField(fields, key)
+		if field == nil {
+			return nil, fmt.Errorf("the group has no profile field %s", key)
+		}
+		if memberEditableOnly && !field.MemberEditable {
+			return nil, fmt.Errorf("the profile field %s can be updated only by the group admins", key)
+		}
+		normalized, err := field.NormalizeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = normalized
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+)
+
+// validateReaction checks if the reaction is allowed by the group post preferences or by the client reactions config
+func (app *Application) validateReaction(clientID string, groupID string, reaction string) error {
+	group, err := app.storage.FindGroup(nil, clientID, groupID, nil)
+	if err != nil {
+		return fmt.Errorf("error finding group: %s", err)
+	}
+	if group == nil {
+		return fmt.Errorf("missing group for id %s", groupID)
+	}
+
+	var groupAllowedReactions []string
+	if group.Settings != nil {
+		groupAllowedReactions = group.Settings.PostPreferences.AllowedReactions
+	}
+
+	var clientAllowedReactions []string
+	config, err := app.storage.FindReactionsConfig(nil, clientID)
+	if err != nil {
+		return fmt.Errorf("error finding reactions config: %s", err)
+	}
+	if config != nil {
+		clientAllowedReactions = config.AllowedReactions
+	}
+
+	return model.ValidateReaction(reaction, clientAllowedReactions, groupAllowedReactions)
+}
+
+func (app *Application) getPostReactions(clientID string, current *model.User, groupID string, postID string, reaction *string, offset *int64, limit *int64) ([]model.PostReaction, error) {
+	membership, _ := app.storage.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		return nil, fmt.Errorf("the user is not member or admin of the group")
+	}
+	isAdmin := membership.IsAdmin()
+
+	post, _ := app.storage.FindPostWithoutReplies(nil, clientID, &current.ID, groupID, postID, !isAdmin)
+	post = applyPostModerationVisibility(post, current.ID, isAdmin)
+	if post == nil || (post.IsExpired() && !isAdmin) {
+		return nil, fmt.Errorf("the post %s is not available", postID)
+	}
+
+	return app.storage.FindPostReactions(clientID, groupID, postID, reaction, offset, limit)
+}
+
+func (app *Application) getReactionsConfig(clientID string) (*model.ReactionsConfig, error) {
+	config, err := app.storage.FindReactionsConfig(nil, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &model.ReactionsConfig{Type: "reactions", ClientID: clientID, AllowedReactions: []string{}}
+	}
+	return config, nil
+}
+
+func (app *Application) updateReactionsConfig(config model.ReactionsConfig) error {
+	for _, reaction := range config.AllowedReactions {
+		err := model.ValidateReaction(reaction, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return app.storage.SaveReactionsConfig(nil, config)
+}
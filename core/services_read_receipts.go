@@ -0,0 +1,117 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"groups/core/model"
+	"log"
+	"time"
+)
+
+func (app *Application) markGroupAsRead(clientID string, current *model.User, groupID string, dateLastRead *time.Time) error {
+	now := time.Now()
+	if dateLastRead == nil || dateLastRead.After(now) {
+		dateLastRead = &now
+	}
+	return app.storage.UpdateMembershipDateLastRead(clientID, groupID, current.ID, *dateLastRead)
+}
+
+func (app *Application) markPostSeen(clientID string, current *model.User, groupID string, postID string) error {
+	membership, _ := app.storage.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		return fmt.Errorf("the user is not member or admin of the group")
+	}
+	isAdmin := membership.IsAdmin()
+
+	post, err := app.storage.FindPostWithoutReplies(nil, clientID, &current.ID, groupID, postID, !isAdmin)
+	if err != nil {
+		return fmt.Errorf("error finding post: %s", err)
+	}
+	post = applyPostModerationVisibility(post, current.ID, isAdmin)
+	if post == nil || (post.IsExpired() && !isAdmin) {
+		return fmt.Errorf("the post %s is not available", postID)
+	}
+
+	return app.storage.MarkPostSeen(clientID, groupID, postID, current.ID)
+}
+
+func (app *Application) getPostSeenRecords(clientID string, current *model.User, group *model.Group, postID string, offset *int64, limit *int64) ([]model.PostSeen, error) {
+	post, err := app.storage.FindPost(nil, clientID, &current.ID, group.ID, postID, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("error finding post: %s", err)
+	}
+	if post == nil {
+		return nil, fmt.Errorf("missing post for id %s", postID)
+	}
+
+	isAdmin := group.CurrentMember != nil && group.CurrentMember.IsAdmin()
+	if !isAdmin && post.Creator.UserID != current.ID {
+		return nil, fmt.Errorf("only the creator of the post or group admins can see who has seen it")
+	}
+
+	records, err := app.storage.FindPostSeenRecords(clientID, group.ID, postID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	userIDs := make([]string, len(records))
+	for i, record := range records {
+		userIDs[i] = record.UserID
+	}
+	members, err := app.findGroupMemberships(nil, clientID, model.NewUserMemberViewer(current), model.MembershipFilter{
+		GroupIDs: []string{group.ID},
+		UserIDs:  userIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, record := range records {
+		member := members.GetMembershipByAccountID(record.UserID)
+		if member != nil {
+			records[i].Name = member.Name
+		}
+	}
+
+	return records, nil
+}
+
+// applyUnreadPostsCount sets the count of the unread posts for the groups where the current user is a member or admin
+func (app *Application) applyUnreadPostsCount(clientID string, current *model.User, groups []model.Group) {
+	var memberships []model.GroupMembership
+	for _, group := range groups {
+		if group.CurrentMember != nil {
+			memberships = append(memberships, *group.CurrentMember)
+		}
+	}
+	if len(memberships) == 0 {
+		return
+	}
+
+	counts, err := app.storage.CountUnreadPosts(clientID, current.ID, memberships)
+	if err != nil {
+		log.Printf("error app.applyUnreadPostsCount() - %s", err)
+		return
+	}
+
+	for index, group := range groups {
+		if count, ok := counts[group.ID]; ok {
+			groups[index].UnreadPostsCount = &count
+		}
+	}
+}
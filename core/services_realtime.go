@@ -0,0 +1,347 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"groups/core/model"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// feedSubscriptionBufferSize is the count of the events which a subscription may lag behind before it is dropped
+const feedSubscriptionBufferSize = 64
+
+// feedSubscription is a real-time feed connection of a user
+type feedSubscription struct {
+	id       string
+	clientID string
+	userID   string
+	groupID  *string             // nil means all the groups of the user
+	statuses map[string]string   // the membership status of the user for every group. It is accessed by the dispatching only once registered
+	labels   map[string][]string // the member labels of the user for every group. It is accessed by the dispatching only once registered
+	events   chan model.FeedEvent
+}
+
+func (s *feedSubscription) coversGroup(groupID string) bool {
+	return s.groupID == nil || *s.groupID == groupID
+}
+
+func (s *feedSubscription) isMember(groupID string) bool {
+	status := s.statuses[groupID]
+	return status == "member" || status == "admin"
+}
+
+// feedHub keeps the real-time feed subscriptions of the current service instance.
+// Every instance watches the database change stream so the events fan out to the subscriptions of all instances.
+type feedHub struct {
+	lock          sync.RWMutex
+	subscriptions map[string]*feedSubscription
+	token         *string // the change stream token to resume from
+}
+
+func newFeedHub() *feedHub {
+	return &feedHub{subscriptions: map[string]*feedSubscription{}}
+}
+
+func (h *feedHub) add(subscription *feedSubscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.subscriptions[subscription.id] = subscription
+}
+
+func (h *feedHub) remove(id string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if subscription, ok := h.subscriptions[id]; ok {
+		delete(h.subscriptions, id)
+		close(subscription.events)
+	}
+}
+
+func (h *feedHub) clientSubscriptions(clientID string) []*feedSubscription {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	var subscriptions []*feedSubscription
+	for _, subscription := range h.subscriptions {
+		if subscription.clientID == clientID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions
+}
+
+// publish sends the event to the subscription. The subscription is dropped if it does not keep up so that the client reconnects.
+func (h *feedHub) publish(subscription *feedSubscription, event model.FeedEvent) {
+	h.lock.RLock()
+	_, ok := h.subscriptions[subscription.id]
+	if ok {
+		select {
+		case subscription.events <- event:
+		default:
+			ok = false
+		}
+	}
+	h.lock.RUnlock()
+
+	if !ok {
+		log.Printf("dropping the slow real-time feed subscription %s of %s", subscription.id, subscription.userID)
+		h.remove(subscription.id)
+	}
+}
+
+func (app *Application) subscribeToFeed(clientID string, current *model.User, groupID *string) (<-chan model.FeedEvent, func(), error) {
+	filter := model.MembershipFilter{UserID: &current.ID, Statuses: []string{"member", "admin"}}
+	if groupID != nil {
+		filter.GroupIDs = []string{*groupID}
+	}
+	memberships, err := app.storage.FindGroupMemberships(clientID, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finding the memberships of %s: %s", current.ID, err)
+	}
+	if groupID != nil && len(memberships.Items) == 0 {
+		return nil, nil, fmt.Errorf("%s is not a member of group %s", current.ID, *groupID)
+	}
+
+	subscription := &feedSubscription{id: uuid.NewString(), clientID: clientID, userID: current.ID, groupID: groupID,
+		statuses: map[string]string{}, labels: map[string][]string{}, events: make(chan model.FeedEvent, feedSubscriptionBufferSize)}
+	for _, membership := range memberships.Items {
+		subscription.statuses[membership.GroupID] = membership.Status
+		subscription.labels[membership.GroupID] = membership.LabelIDs
+	}
+	app.feed.add(subscription)
+
+	unsubscribe := func() {
+		app.feed.remove(subscription.id)
+	}
+	return subscription.events, unsubscribe, nil
+}
+
+// watchRealtimeFeed dispatches the database changes to the real-time feed subscriptions. It restarts the change stream when it fails.
+func (app *Application) watchRealtimeFeed() {
+	for {
+		err := app.storage.WatchChanges(app.feed.token, app.dispatchFeedChange)
+		if errors.Is(err, model.ErrChangeTokenExpired) {
+			app.feed.token = nil
+		}
+		log.Printf("the real-time feed change stream has stopped - %v", err)
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func (app *Application) dispatchFeedChange(change model.ChangeEvent) {
+	if len(change.ID) > 0 {
+		token := change.ID
+		app.feed.token = &token
+	}
+
+	subscriptions := app.feed.clientSubscriptions(change.ClientID)
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	switch change.EntityType {
+	case model.ChangeEntityMembership:
+		app.dispatchFeedMembershipChange(change, subscriptions)
+	case model.ChangeEntityPost:
+		app.dispatchFeedPostChange(change, subscriptions)
+	}
+}
+
+func (app *Application) dispatchFeedMembershipChange(change model.ChangeEvent, subscriptions []*feedSubscription) {
+	var membership, previous model.GroupMembership
+	if err := decodeChangeData(change.Data, &membership); err != nil {
+		log.Printf("error decoding membership change %s - %s", change.EntityID, err)
+		return
+	}
+	if err := decodeChangeData(change.PreviousData, &previous); err != nil {
+		log.Printf("error decoding previous membership %s - %s", change.EntityID, err)
+		return
+	}
+	userID := membership.UserID
+	if len(userID) == 0 {
+		userID = previous.UserID
+	}
+
+	// keep the statuses up to date so that the visibility follows the membership changes
+	for _, subscription := range subscriptions {
+		if subscription.userID == userID && subscription.coversGroup(change.GroupID) {
+			if change.Operation == model.ChangeOperationDeleted {
+				delete(subscription.statuses, change.GroupID)
+				delete(subscription.labels, change.GroupID)
+			} else {
+				subscription.statuses[change.GroupID] = membership.Status
+				subscription.labels[change.GroupID] = membership.LabelIDs
+			}
+		}
+	}
+
+	// the previous status is not known if the pre-images are not available
+	approved := change.Operation == model.ChangeOperationUpdated && contains(change.UpdatedFields, "status") &&
+		membership.IsAdminOrMember() && (change.PreviousData == nil || previous.IsPendingMember())
+	if !approved {
+		return
+	}
+
+	// the admins receive the member as the visibility policy of the group allows
+	adminMemberships := []model.GroupMembership{membership}
+	err := app.applyMemberVisibility(change.ClientID, model.MemberViewer{Role: model.MemberViewerRoleAdmin}, adminMemberships)
+	if err != nil {
+		log.Printf("error applying the member visibility to membership %s - %s", change.EntityID, err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.coversGroup(change.GroupID) {
+			continue
+		}
+		if subscription.userID == membership.UserID {
+			app.feed.publish(subscription, newFeedMembershipApprovedEvent(change, membership))
+		} else if subscription.statuses[change.GroupID] == "admin" {
+			app.feed.publish(subscription, newFeedMembershipApprovedEvent(change, adminMemberships[0]))
+		}
+	}
+}
+
+func newFeedMembershipApprovedEvent(change model.ChangeEvent, membership model.GroupMembership) model.FeedEvent {
+	return model.FeedEvent{Type: model.FeedEventMembershipApproved, GroupID: change.GroupID, Date: change.Date,
+		Member: &model.FeedMember{MembershipID: membership.ID, UserID: membership.UserID, Name: membership.Name,
+			PhotoURL: membership.PhotoURL, Status: membership.Status}}
+}
+
+func (app *Application) dispatchFeedPostChange(change model.ChangeEvent, subscriptions []*feedSubscription) {
+	data := change.Data
+	if change.Operation == model.ChangeOperationDeleted {
+		data = change.PreviousData
+	}
+	if data == nil {
+		// a deleted post without a pre-image cannot be checked for visibility
+		return
+	}
+	var post model.Post
+	if err := decodeChangeData(data, &post); err != nil {
+		log.Printf("error decoding post change %s - %s", change.EntityID, err)
+		return
+	}
+
+	eventType := feedPostEventType(change, post)
+	if len(eventType) == 0 {
+		return
+	}
+	event := model.FeedEvent{Type: eventType, GroupID: change.GroupID, PostID: &post.ID, Date: change.Date}
+	switch eventType {
+	case model.FeedEventPostCreated, model.FeedEventPostUpdated:
+		event.Post = &post
+	case model.FeedEventPostReactions:
+		event.ReactionCounts = post.ReactionCounts
+	}
+
+	var topPost *model.Post
+	topPostLoaded := false
+	for _, subscription := range subscriptions {
+		if !subscription.coversGroup(change.GroupID) || !subscription.isMember(change.GroupID) {
+			continue
+		}
+		if subscription.statuses[change.GroupID] != "admin" {
+			if !post.IsPublished() && post.Creator.UserID != subscription.userID {
+				continue
+			}
+			if !post.UserCanSeePost(subscription.userID, subscription.labels[change.GroupID]) {
+				continue
+			}
+			if post.ParentID != nil {
+				// the replies are visible if their top level post is visible
+				if !topPostLoaded {
+					topPost = app.findFeedTopPost(change.ClientID, post)
+					topPostLoaded = true
+				}
+				if topPost == nil || !topPost.IsPublished() || !topPost.UserCanSeePost(subscription.userID, subscription.labels[change.GroupID]) {
+					continue
+				}
+			}
+		}
+		app.feed.publish(subscription, event)
+	}
+}
+
+func (app *Application) findFeedTopPost(clientID string, post model.Post) *model.Post {
+	topPostID := *post.ParentID
+	if post.TopParentID != nil {
+		topPostID = *post.TopParentID
+	}
+	topPost, err := app.storage.FindPostWithoutReplies(nil, clientID, nil, post.GroupID, topPostID, false)
+	if err != nil {
+		log.Printf("error finding top level post %s - %s", topPostID, err)
+		return nil
+	}
+	return topPost
+}
+
+// feedPostEventType returns the real-time feed event type for the post change or empty if the change is not pushed
+func feedPostEventType(change model.ChangeEvent, post model.Post) string {
+	// the scheduled posts are not visible until their date
+	if post.DateScheduled != nil && post.DateScheduled.After(time.Now()) {
+		return ""
+	}
+
+	switch change.Operation {
+	case model.ChangeOperationCreated:
+		return model.FeedEventPostCreated
+	case model.ChangeOperationDeleted:
+		return model.FeedEventPostDeleted
+	}
+
+	if len(change.UpdatedFields) == 0 {
+		return model.FeedEventPostUpdated
+	}
+	reactionsOnly := true
+	for _, field := range change.UpdatedFields {
+		if !strings.HasPrefix(field, "reaction_counts") {
+			reactionsOnly = false
+			break
+		}
+	}
+	switch {
+	case reactionsOnly:
+		return model.FeedEventPostReactions
+	case post.IsExpired():
+		return model.FeedEventPostDeleted
+	case post.DateScheduled != nil && len(change.UpdatedFields) == 1 && change.UpdatedFields[0] == "date_notified":
+		// a scheduled post has just been published
+		return model.FeedEventPostCreated
+	case contains(change.UpdatedFields, "status") && post.Status == model.PostStatusApproved:
+		// a reviewed post becomes visible for the members
+		return model.FeedEventPostCreated
+	}
+	return model.FeedEventPostUpdated
+}
+
+// decodeChangeData decodes the normalized change feed document into the entity
+func decodeChangeData(data map[string]interface{}, entity interface{}) error {
+	if data == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, entity)
+}
@@ -0,0 +1,196 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"groups/driven/storage"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// feedStorage gives the top level posts of the replies and the groups with the default settings
+type feedStorage struct {
+	Storage
+	posts map[string]model.Post
+}
+
+func (s *feedStorage) FindPostWithoutReplies(context storage.TransactionContext, clientID string, userID *string, groupID string, postID string, filterByToMembers bool) (*model.Post, error) {
+	post, ok := s.posts[postID]
+	if !ok {
+		return nil, nil
+	}
+	return &post, nil
+}
+
+func (s *feedStorage) FindGroupsV3(context storage.TransactionContext, clientID string, filter model.GroupsFilter) ([]model.Group, error) {
+	return nil, nil
+}
+
+// feedCore has no FERPA protected users
+type feedCore struct {
+	Core
+}
+
+func (c *feedCore) RetrieveFerpaAccounts(ids []string) ([]string, error) {
+	return nil, nil
+}
+
+// newTestFeed registers the subscriptions of group g1:
+// admin "a", members "m1" (label l1), "m2" and "c", pending "p", the non-member "n"
+// and the member "o" who follows group g2 only
+func newTestFeed(posts map[string]model.Post) (*Application, []*feedSubscription) {
+	app := &Application{storage: &feedStorage{posts: posts}, corebb: &feedCore{}, feed: newFeedHub()}
+	otherGroupID := "g2"
+	subscription := func(userID string, groupID *string, status string, labelIDs ...string) *feedSubscription {
+		s := &feedSubscription{id: userID, clientID: "client", userID: userID, groupID: groupID,
+			statuses: map[string]string{}, labels: map[string][]string{}, events: make(chan model.FeedEvent, feedSubscriptionBufferSize)}
+		if len(status) > 0 {
+			s.statuses["g1"] = status
+			s.labels["g1"] = labelIDs
+		}
+		app.feed.add(s)
+		return s
+	}
+	subscriptions := []*feedSubscription{
+		subscription("a", nil, "admin"),
+		subscription("m1", nil, "member", "l1"),
+		subscription("m2", nil, "member"),
+		subscription("c", nil, "member"),
+		subscription("p", nil, "pending"),
+		subscription("n", nil, ""),
+		subscription("o", &otherGroupID, "member"),
+	}
+	return app, subscriptions
+}
+
+// receivedFeedEvents gives the users who have received an event of the type
+func receivedFeedEvents(subscriptions []*feedSubscription, eventType string) []string {
+	userIDs := []string{}
+	for _, subscription := range subscriptions {
+		for received := false; !received; {
+			select {
+			case event := <-subscription.events:
+				if event.Type == eventType {
+					userIDs = append(userIDs, subscription.userID)
+					received = true
+				}
+			default:
+				received = true
+			}
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+func toChangeData(t *testing.T, entity interface{}) map[string]interface{} {
+	encoded, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatalf("error encoding the change data: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		t.Fatalf("error decoding the change data: %v", err)
+	}
+	return data
+}
+
+func TestDispatchFeedPostChange(t *testing.T) {
+	topPostID := "top"
+	hiddenTopPostID := "hidden"
+	targetedTopPostID := "targeted"
+	scheduled := time.Now().Add(time.Hour)
+	posts := map[string]model.Post{
+		topPostID:         {ID: topPostID, GroupID: "g1", Creator: model.Creator{UserID: "x"}},
+		hiddenTopPostID:   {ID: hiddenTopPostID, GroupID: "g1", Creator: model.Creator{UserID: "x"}, Status: model.PostStatusPendingReview},
+		targetedTopPostID: {ID: targetedTopPostID, GroupID: "g1", Creator: model.Creator{UserID: "x"}, ToMembersList: []model.ToMember{{UserID: "m2"}}},
+	}
+
+	tests := []struct {
+		name string
+		post model.Post
+		want []string
+	}{
+		{"everyone", model.Post{}, []string{"a", "c", "m1", "m2"}},
+		{"to members list", model.Post{ToMembersList: []model.ToMember{{UserID: "m2"}}}, []string{"a", "c", "m2"}},
+		{"to labels", model.Post{ToLabelIDs: []string{"l1"}}, []string{"a", "c", "m1"}},
+		{"pending review", model.Post{Status: model.PostStatusPendingReview}, []string{"a", "c"}},
+		{"rejected", model.Post{Status: model.PostStatusRejected}, []string{"a", "c"}},
+		{"approved", model.Post{Status: model.PostStatusApproved}, []string{"a", "c", "m1", "m2"}},
+		{"reply to visible post", model.Post{ParentID: &topPostID, TopParentID: &topPostID}, []string{"a", "c", "m1", "m2"}},
+		{"reply to hidden post", model.Post{ParentID: &hiddenTopPostID, TopParentID: &hiddenTopPostID}, []string{"a"}},
+		{"reply to targeted post", model.Post{ParentID: &targetedTopPostID, TopParentID: &targetedTopPostID}, []string{"a", "m2"}},
+		{"scheduled", model.Post{DateScheduled: &scheduled}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, subscriptions := newTestFeed(posts)
+			post := tt.post
+			post.ID = "post"
+			post.GroupID = "g1"
+			post.Creator = model.Creator{UserID: "c"}
+			change := model.ChangeEvent{ClientID: "client", EntityType: model.ChangeEntityPost, EntityID: post.ID, GroupID: "g1",
+				Operation: model.ChangeOperationCreated, Data: toChangeData(t, post)}
+
+			app.dispatchFeedPostChange(change, subscriptions)
+			if got := receivedFeedEvents(subscriptions, model.FeedEventPostCreated); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dispatchFeedPostChange() recipients = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatchFeedMembershipChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		previous      string
+		updatedFields []string
+		want          []string
+	}{
+		{"approved", "member", "pending", []string{"status"}, []string{"a", "p"}},
+		{"approved without pre-image", "member", "", []string{"status"}, []string{"a", "p"}},
+		{"rejected", "rejected", "pending", []string{"status"}, []string{}},
+		{"promoted to admin", "admin", "member", []string{"status"}, []string{}},
+		{"status not updated", "member", "pending", []string{"name"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, subscriptions := newTestFeed(nil)
+			membership := model.GroupMembership{ID: "membership", GroupID: "g1", UserID: "p", Status: tt.status}
+			change := model.ChangeEvent{ClientID: "client", EntityType: model.ChangeEntityMembership, EntityID: membership.ID, GroupID: "g1",
+				Operation: model.ChangeOperationUpdated, UpdatedFields: tt.updatedFields, Data: toChangeData(t, membership)}
+			if len(tt.previous) > 0 {
+				previous := membership
+				previous.Status = tt.previous
+				change.PreviousData = toChangeData(t, previous)
+			}
+
+			app.dispatchFeedMembershipChange(change, subscriptions)
+			if got := receivedFeedEvents(subscriptions, model.FeedEventMembershipApproved); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dispatchFeedMembershipChange() recipients = %v, want %v", got, tt.want)
+			}
+			if got := subscriptions[4].statuses["g1"]; got != tt.status {
+				t.Errorf("dispatchFeedMembershipChange() subscription status = %s, want %s", got, tt.status)
+			}
+		})
+	}
+}
@@ -104,6 +104,13 @@ func (app Application) deleteAppOrgUsersData(accountsIDs []string) {
 			app.logger.Errorf("error deleting posts by account ID - %s", err)
 			return err
 		}
+
+		// delete post seen records
+		err = app.storage.DeletePostsSeenByAccountsIDs(nil, nil, accountsIDs)
+		if err != nil {
+			app.logger.Errorf("error deleting post seen records by account ID - %s", err)
+			return err
+		}
 		return nil
 	})
 
@@ -42,6 +42,8 @@ func (app Application) processScheduledPosts() error {
 
 		log.Printf("processScheduledPosts: Found %d scheduled posts for current the current time", len(posts))
 		var postIds []string
+		// the copies of a cross-posted post share the same notified users
+		notifiedUserIDsByOrigin := map[string]map[string]bool{}
 		if len(posts) > 0 {
 			for _, post := range posts {
 				group, err := app.storage.FindGroup(context, post.ClientID, post.GroupID, nil)
@@ -49,21 +51,31 @@ func (app Application) processScheduledPosts() error {
 					return err
 				}
 				if group != nil {
-					err = app.sendGroupNotificationForNewPost(post.ClientID, &post.Creator.UserID, &post.Creator.Name, group, &post)
+					originID := post.ID
+					if post.OriginID != nil {
+						originID = *post.OriginID
+					}
+					notifiedUserIDs := notifiedUserIDsByOrigin[originID]
+					if notifiedUserIDs == nil {
+						notifiedUserIDs = map[string]bool{post.Creator.UserID: true}
+						notifiedUserIDsByOrigin[originID] = notifiedUserIDs
+					}
+
+					err = app.sendGroupNotificationForNewPostSkipping(context, post.ClientID, &post.Creator.UserID, &post.Creator.Name, group, &post, notifiedUserIDs)
 					if err != nil {
-						return nil
+						return err
 					}
 
 					postIds = append(postIds, post.ID)
 				}
 			}
 		}
-		log.Printf("processScheduledPosts: Successful send of %d notifications for scheduled posts", len(posts))
+		log.Printf("processScheduledPosts: Queued notifications for %d scheduled posts", len(posts))
 
 		if len(postIds) > 0 {
 			err = app.storage.UpdateDateNotifiedForPostIDs(context, postIds, time.Now())
 			if err != nil {
-				return nil
+				return err
 			}
 		}
 
@@ -87,7 +99,7 @@ func (app Application) processScheduledPosts() error {
 }
 
 func (app Application) checkForConcurentRun(context storage.TransactionContext, startTime time.Time, syncKey string) error {
-	times, err := app.storage.FindSyncTimes(context, "", "scheduled_posts", false)
+	times, err := app.storage.FindSyncTimes(context, "", syncKey, false)
 	if err != nil {
 		return err
 	}
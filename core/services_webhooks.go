@@ -0,0 +1,231 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"groups/core/model"
+	"groups/driven/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// webhookLease is the time for which a claimed delivery is hidden from the other workers
+	webhookLease = 5 * time.Minute
+	// webhookBatchSize is the max count of deliveries sent on a single worker tick
+	webhookBatchSize = 100
+	// webhookMaxAttempts is the count of the failed attempts after which the delivery is dead-lettered
+	webhookMaxAttempts = 8
+)
+
+// publishWebhookEvent stores a delivery of the event for every active subscription of the client which receives the event type.
+// Pass the transaction context of the domain change so that the deliveries are persisted only if the change is committed.
+func (app *Application) publishWebhookEvent(context storage.TransactionContext, clientID string, eventType string, data interface{}) error {
+	subscriptions, err := app.storage.FindWebhookSubscriptions(context, clientID)
+	if err != nil {
+		return fmt.Errorf("error finding webhook subscriptions: %s", err)
+	}
+
+	var subscribed []model.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscription.IsSubscribedTo(eventType) {
+			subscribed = append(subscribed, subscription)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	event := model.WebhookEvent{ID: uuid.NewString(), Type: eventType, ClientID: clientID, DateCreated: now, Data: data}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshalling webhook event %s: %s", eventType, err)
+	}
+
+	deliveries := make([]model.WebhookDelivery, len(subscribed))
+	for i, subscription := range subscribed {
+		deliveries[i] = model.WebhookDelivery{
+			ID:              uuid.NewString(),
+			ClientID:        clientID,
+			SubscriptionID:  subscription.ID,
+			EventID:         event.ID,
+			EventType:       eventType,
+			Payload:         string(payload),
+			Status:          model.WebhookDeliveryStatusPending,
+			DateNextAttempt: now,
+			DateCreated:     now,
+		}
+	}
+	err = app.storage.InsertWebhookDeliveries(context, deliveries)
+	if err != nil {
+		return fmt.Errorf("error storing webhook deliveries: %s", err)
+	}
+	return nil
+}
+
+// publishCommittedWebhookEvent publishes an event of a change which has already been committed. The errors are only logged.
+func (app *Application) publishCommittedWebhookEvent(clientID string, eventType string, data interface{}) {
+	err := app.publishWebhookEvent(nil, clientID, eventType, data)
+	if err != nil {
+		log.Printf("error publishing webhook event %s - %s", eventType, err)
+	}
+}
+
+// processWebhookDeliveries sends the due webhook deliveries. The failed deliveries are retried with
+// exponential backoff and dead-lettered after webhookMaxAttempts attempts.
+func (app *Application) processWebhookDeliveries() error {
+	subscriptions := map[string]*model.WebhookSubscription{}
+	delivered := 0
+	failed := 0
+	for i := 0; i < webhookBatchSize; i++ {
+		delivery, err := app.storage.ClaimWebhookDelivery(time.Now().UTC(), webhookLease)
+		if err != nil {
+			return err
+		}
+		if delivery == nil {
+			break
+		}
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = app.storage.FindWebhookSubscription(delivery.ClientID, delivery.SubscriptionID)
+			if err != nil {
+				return err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		attempts := delivery.Attempts + 1
+		if subscription == nil || !subscription.Active {
+			failed++
+			err = app.storage.MarkWebhookDeliveryFailed(delivery.ID, attempts, 0, "the subscription is missing or inactive",
+				model.WebhookDeliveryStatusDead, time.Now().UTC())
+			if err != nil {
+				log.Printf("processWebhookDeliveries: error marking delivery %s as dead: %s", delivery.ID, err)
+			}
+			continue
+		}
+
+		statusCode, err := app.webhooks.Deliver(subscription.URL, subscription.Secret, delivery.EventType, delivery.ID, []byte(delivery.Payload))
+		if err == nil {
+			delivered++
+			err = app.storage.MarkWebhookDeliveryDelivered(delivery.ID, attempts, statusCode)
+			if err != nil {
+				log.Printf("processWebhookDeliveries: error marking delivery %s as delivered: %s", delivery.ID, err)
+			}
+			continue
+		}
+
+		failed++
+		status := model.WebhookDeliveryStatusPending
+		if attempts >= webhookMaxAttempts {
+			status = model.WebhookDeliveryStatusDead
+			log.Printf("processWebhookDeliveries: delivery %s is dead-lettered after %d attempts: %s", delivery.ID, attempts, err)
+		}
+		markErr := app.storage.MarkWebhookDeliveryFailed(delivery.ID, attempts, statusCode, err.Error(), status, time.Now().UTC().Add(model.GetRetryDelay(attempts)))
+		if markErr != nil {
+			log.Printf("processWebhookDeliveries: error marking delivery %s as failed: %s", delivery.ID, markErr)
+		}
+	}
+	if delivered > 0 || failed > 0 {
+		log.Printf("processWebhookDeliveries: delivered %d, failed %d webhook deliveries", delivered, failed)
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (app *Application) getWebhookSubscriptions(clientID string) ([]model.WebhookSubscription, error) {
+	subscriptions, err := app.storage.FindWebhookSubscriptions(nil, clientID)
+	if err != nil {
+		return nil, err
+	}
+	// the secret is revealed only once on creation
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+func (app *Application) createWebhookSubscription(clientID string, current *model.User, subscription model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	err := subscription.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, fmt.Errorf("error generating webhook secret: %s", err)
+		}
+	}
+
+	subscription.ID = uuid.NewString()
+	subscription.ClientID = clientID
+	subscription.CreatedBy = current.ID
+	subscription.DateCreated = time.Now().UTC()
+	subscription.DateUpdated = nil
+	err = app.storage.InsertWebhookSubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (app *Application) updateWebhookSubscription(clientID string, subscription model.WebhookSubscription) error {
+	err := subscription.Validate()
+	if err != nil {
+		return err
+	}
+
+	subscription.ClientID = clientID
+	return app.storage.UpdateWebhookSubscription(subscription)
+}
+
+func (app *Application) deleteWebhookSubscription(clientID string, id string) error {
+	return app.storage.DeleteWebhookSubscription(clientID, id)
+}
+
+func (app *Application) getWebhookDeliveries(clientID string, subscriptionID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error) {
+	if status != nil && !model.IsValidWebhookDeliveryStatus(*status) {
+		return nil, fmt.Errorf("invalid webhook delivery status: %s", *status)
+	}
+	return app.storage.FindWebhookDeliveries(clientID, subscriptionID, status, offset, limit)
+}
+
+func (app *Application) replayWebhookDelivery(clientID string, id string) error {
+	replayed, err := app.storage.ReplayWebhookDelivery(clientID, id)
+	if err != nil {
+		return err
+	}
+	if !replayed {
+		return fmt.Errorf("webhook delivery %s not found or already pending", id)
+	}
+	return nil
+}
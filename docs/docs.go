@@ -37,24 +37,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/group/events/v3": {
-            "post": {
+        "/api/admin/abuse-reports": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Create a calendar event and link it to multiple group ids",
+                "description": "Gets the abuse cases. The repeated reports of the same group, post or reply are grouped within one case.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminCreateCalendarEventMultiGroup",
+                "operationId": "AdminGetAbuseReports",
                 "parameters": [
                     {
                         "type": "string",
@@ -64,112 +61,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
-                        }
+                        "type": "string",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/admin/group/{group-id}/event/{event-id}": {
-            "delete": {
-                "security": [
-                    {
-                        "AppUserAuth": []
-                    }
-                ],
-                "description": "Deletes a group event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin"
-                ],
-                "operationId": "AdminDeleteGroupEvent",
-                "parameters": [
+                        "description": "group, post or reply",
+                        "name": "target_type",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
+                        "description": "target_id",
+                        "name": "target_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated list of open, reviewing, actioned or dismissed",
+                        "name": "statuses",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "event-id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/admin/group/{group-id}/events": {
-            "get": {
-                "security": [
-                    {
-                        "AppUserAuth": []
-                    }
-                ],
-                "description": "Gives the group events.",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin"
-                ],
-                "operationId": "AdminGetGroupEvents",
-                "parameters": [
+                        "description": "assignee_id",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
                     {
-                        "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -178,31 +109,25 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/AbuseReport"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/admin/group/{group-id}/events/v3": {
-            "put": {
+        "/api/admin/abuse-reports/{id}": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a calendar event and for a single group id",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets an abuse case with all of its reports",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminUpdateCalendarEventSingleGroup",
+                "operationId": "AdminGetAbuseReport",
                 "parameters": [
                     {
                         "type": "string",
@@ -213,54 +138,35 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "group id",
+                        "description": "ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/AbuseReport"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Create a calendar event and link it to a single group id",
+                "description": "Assigns, reviews or resolves an abuse case. Only the provided fields are updated. An empty assignee_id unassigns the case. The closed (actioned or dismissed) cases cannot be updated.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminCreateCalendarEventSingleGroup",
+                "operationId": "AdminUpdateAbuseReport",
                 "parameters": [
                     {
                         "type": "string",
@@ -271,7 +177,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "group id",
+                        "description": "ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -282,45 +188,35 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/updateAbuseReportRequestBody"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/AbuseReport"
                         }
                     }
                 }
             }
         },
-        "/api/admin/group/{group-id}/events/v3/load": {
-            "post": {
+        "/api/admin/content-filters": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gets the group calendar events",
+                "description": "Gets the content filters. If group_id is provided only the client wide filters and the filters of the group are returned.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupCalendarEventsV3",
+                "operationId": "AdminGetContentFilters",
                 "parameters": [
                     {
                         "type": "string",
@@ -331,45 +227,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/GroupEventFilter"
-                        }
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ContentFilter"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/admin/group/{group-id}/members": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets the list of group members.",
+                "description": "Updates an existing content filter",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupMembers",
+                "operationId": "AdminUpdateContentFilter",
                 "parameters": [
                     {
                         "description": "body data",
@@ -377,7 +265,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/MembershipFilter"
+                            "$ref": "#/definitions/ContentFilter"
                         }
                     },
                     {
@@ -386,43 +274,28 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GroupMembership"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
-            }
-        },
-        "/api/admin/group/{group-id}/members/v2": {
+            },
             "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets the list of group members.",
+                "description": "Creates a new content filter. The filter is applied for all groups of the client if group_id is missing.",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupMembersV2",
+                "operationId": "AdminCreateContentFilter",
                 "parameters": [
                     {
                         "description": "body data",
@@ -430,7 +303,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/MembershipFilter"
+                            "$ref": "#/definitions/ContentFilter"
                         }
                     },
                     {
@@ -439,43 +312,30 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GroupMembership"
-                            }
+                            "$ref": "#/definitions/ContentFilter"
                         }
                     }
                 }
             }
         },
-        "/api/admin/group/{group-id}/stats": {
-            "get": {
+        "/api/admin/content-filters/{id}": {
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieves stats for a group by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a content filter",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupStats",
+                "operationId": "AdminDeleteContentFilter",
                 "parameters": [
                     {
                         "type": "string",
@@ -486,37 +346,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GroupStats"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/admin/group/{groupID}/posts": {
-            "get": {
+        "/api/admin/group/events/v3": {
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "gets all posts for the desired group.",
+                "description": "Create a calendar event and link it to multiple group ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupPosts",
+                "operationId": "AdminCreateCalendarEventMultiGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -526,68 +386,50 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "groupID",
-                        "name": "groupID",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Values: message|post",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "asc|desc",
-                        "name": "order",
-                        "in": "query"
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Post"
-                            }
+                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
                         }
                     }
                 }
             }
         },
-        "/api/admin/group/{groupId}/posts/{postId}": {
+        "/api/admin/group/{group-id}/event/{event-id}": {
             "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Updates a post within the desired group.",
+                "description": "Deletes a group event",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminDeleteGroupPost",
+                "operationId": "AdminDeleteGroupEvent",
                 "parameters": [
                     {
                         "type": "string",
@@ -595,33 +437,47 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/api/admin/group/{id}": {
-            "delete": {
+        "/api/admin/group/{group-id}/events": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a group.",
+                "description": "Gives the group events.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminDeleteGroup",
+                "operationId": "AdminGetGroupEvents",
                 "parameters": [
                     {
                         "type": "string",
@@ -632,8 +488,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Group ID",
+                        "name": "group-id",
                         "in": "path",
                         "required": true
                     }
@@ -642,30 +498,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/admin/groups": {
-            "get": {
+        "/api/admin/group/{group-id}/events/v3": {
+            "put": {
                 "security": [
-                    {
-                        "APIKeyAuth": []
-                    },
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the groups list. It can be filtered by category",
+                "description": "Updates a calendar event and for a single group id",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetAllGroups",
+                "operationId": "AdminUpdateCalendarEventSingleGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -676,39 +535,68 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
-                    },
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Create a calendar event and link it to a single group id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminCreateCalendarEventSingleGroup",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "description": "body data",
@@ -716,38 +604,45 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
+                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Group"
-                            }
+                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
                         }
                     }
                 }
             }
         },
-        "/api/admin/managed-group-configs": {
-            "get": {
+        "/api/admin/group/{group-id}/events/v3/load": {
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gets managed group configs",
+                "description": "Gets the group calendar events",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetManagedGroupConfigs",
+                "operationId": "AdminGetGroupCalendarEventsV3",
                 "parameters": [
                     {
                         "type": "string",
@@ -755,34 +650,48 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/GroupEventFilter"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ManagedGroupConfig"
-                            }
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/group/{group-id}/members": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates an existing managed group config",
+                "description": "Gets the list of group members.",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminUpdateManagedGroupConfig",
+                "operationId": "AdminGetGroupMembers",
                 "parameters": [
                     {
                         "description": "body data",
@@ -790,7 +699,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ManagedGroupConfig"
+                            "$ref": "#/definitions/MembershipFilter"
                         }
                     },
                     {
@@ -802,32 +711,40 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Group ID",
+                        "name": "group-id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    }
-                }
-            },
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupMembership"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/group/{group-id}/members/v2": {
             "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Creates a new managed group config",
+                "description": "Gets the list of group members.",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminCreateManagedGroupConfig",
+                "operationId": "AdminGetGroupMembersV2",
                 "parameters": [
                     {
                         "description": "body data",
@@ -835,7 +752,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ManagedGroupConfig"
+                            "$ref": "#/definitions/MembershipFilter"
                         }
                     },
                     {
@@ -844,30 +761,43 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ManagedGroupConfig"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupMembership"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/admin/managed-group-configs/{id}": {
-            "delete": {
+        "/api/admin/group/{group-id}/stats": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a managed group config",
+                "description": "Retrieves stats for a group by id",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminDeleteManagedGroupConfig",
+                "operationId": "AdminGetGroupStats",
                 "parameters": [
                     {
                         "type": "string",
@@ -878,37 +808,37 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Group ID",
+                        "name": "group-id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupStats"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/admin/memberships/{membership-id}": {
-            "put": {
+        "/api/admin/group/{groupID}/attendance/report": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a membership. Only the status can be changed.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets the attendance report of the desired attendance group with the attendance rate per session and per member. Only group admins are allowed to do it.",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminUpdateMembership",
+                "operationId": "AdminGetAttendanceReport",
                 "parameters": [
                     {
                         "type": "string",
@@ -917,46 +847,36 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/updateMembershipRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "membership-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AttendanceReport"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/group/{groupID}/attendance/sessions": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a membership",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets the attendance sessions of the desired attendance group ordered by date. Only group admins are allowed to do it.",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminDeleteMembership",
+                "operationId": "AdminGetAttendanceSessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -967,34 +887,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "membership-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AttendanceSession"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/api/admin/sync-configs": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets sync config",
+                "description": "Creates an attendance session within the desired attendance group. The session may be linked to an event of the group. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetSyncConfigs",
+                "operationId": "AdminCreateAttendanceSession",
                 "parameters": [
                     {
                         "type": "string",
@@ -1002,50 +926,119 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AttendanceSession"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.SyncConfig"
-                            }
+                            "$ref": "#/definitions/AttendanceSession"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}": {
             "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Saves sync config",
+                "description": "Updates the title, the date and the linked event of an attendance session. Only group admins are allowed to do it.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminSaveSyncConfig",
+                "operationId": "AdminUpdateAttendanceSession",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SyncConfig"
+                            "$ref": "#/definitions/AttendanceSession"
                         }
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes an attendance session and its attendance records. Only group admins are allowed to do it.",
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminDeleteAttendanceSession",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "APP",
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1055,18 +1048,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/user/event/{event-id}/groups": {
-            "get": {
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates the group mappings for an event with id",
+                "description": "Checks in group members to an attendance session. Checking in a member who has checked out checks them in again. Only group admins are allowed to do it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateGroupMappingsEventID",
+                "operationId": "AdminCheckInAttendance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1077,34 +1073,47 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "event-id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendanceUsersRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/getPutAdminGroupIDsForEventIDRequestAndResponse"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/admin/user/event/{event_id}/groups": {
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in-code": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Get all group IDs where the current user is an admin",
+                "description": "Gets the current check-in code of an attendance session together with its QR payload. The admins display it to the members and fetch it again when it expires. Only group admins are allowed to do it.",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetAdminGroupIDsForEventID",
+                "operationId": "AdminGetAttendanceCheckInCode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1115,8 +1124,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "event-id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
                         "in": "path",
                         "required": true
                     }
@@ -1125,30 +1141,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/getPutAdminGroupIDsForEventIDRequestAndResponse"
+                            "$ref": "#/definitions/AttendanceCheckInCode"
                         }
                     }
                 }
             }
         },
-        "/api/admin/user/groups": {
-            "get": {
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in-window": {
+            "put": {
                 "security": [
-                    {
-                        "APIKeyAuth": []
-                    },
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the groups list. It can be filtered by category",
+                "description": "Opens the check-in window of an attendance session so that the members can check in themselves with a rotating 6-digit code. The window lasts at most 24 hours. Non-members who check in are added as pending members if auto_add_non_members is set. Opening the window again rotates its secret. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetUserGroups",
+                "operationId": "AdminOpenAttendanceCheckInWindow",
                 "parameters": [
                     {
                         "type": "string",
@@ -1159,69 +1172,93 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "category - filter by category",
-                        "name": "category",
-                        "in": "query"
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/openAttendanceCheckInWindowRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AttendanceSession"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Closes the check-in window of an attendance session. Only group admins are allowed to do it.",
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminCloseAttendanceCheckInWindow",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Group"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/admin/v2/groups": {
-            "get": {
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-out": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the groups list. It can be filtered by category, title and privacy. V2",
+                "description": "Checks out group members from an attendance session. Only checked in members are checked out. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroupsV2",
+                "operationId": "AdminCheckOutAttendance",
                 "parameters": [
                     {
                         "type": "string",
@@ -1232,39 +1269,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "description": "body data",
@@ -1272,38 +1287,29 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
+                            "$ref": "#/definitions/attendanceUsersRequestBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Group"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/admin/v2/groups/{id}": {
+        "/api/admin/group/{groupID}/attendance/sessions/{sessionID}/records": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives a group. V2",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the attendance records of an attendance session. Only group admins are allowed to do it.",
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetGroup",
+                "operationId": "AdminGetAttendanceRecords",
                 "parameters": [
                     {
                         "type": "string",
@@ -1314,8 +1320,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
                         "in": "path",
                         "required": true
                     }
@@ -1324,30 +1337,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Group"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AttendanceRecord"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/admin/v2/user/groups": {
-            "get": {
+        "/api/admin/group/{groupID}/member-labels": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gives the user groups. It can be filtered by category, title and privacy. V2.",
+                "description": "Replaces the member labels of the desired group. The new labels come without id and get one generated. The removed labels are unassigned from the members. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "operationId": "AdminGetUserGroupsV2",
+                "operationId": "AdminUpdateGroupMemberLabels",
                 "parameters": [
                     {
                         "type": "string",
@@ -1358,39 +1371,10 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "description": "body data",
@@ -1398,7 +1382,10 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MemberLabel"
+                            }
                         }
                     }
                 ],
@@ -1408,168 +1395,195 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Group"
+                                "$ref": "#/definitions/MemberLabel"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/analytics/groups": {
-            "get": {
+        "/api/admin/group/{groupID}/member-notes/{noteID}": {
+            "put": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Gets groups",
+                "description": "Updates the text of a private admin note on a member. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Analytics"
+                    "Admin"
                 ],
-                "operationId": "AnalyticsGetGroups",
+                "operationId": "AdminUpdateMemberNote",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date string - RFC3339 encoded",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End date string - RFC3339 encoded",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "noteID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/memberNoteRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/rest.analyticsGetGroupsResponse"
-                            }
+                            "$ref": "#/definitions/MemberNote"
                         }
                     }
                 }
-            }
-        },
-        "/api/analytics/members": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Gets groups members",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a private admin note on a member. Only group admins are allowed to do it.",
                 "tags": [
-                    "Analytics"
+                    "Admin"
                 ],
-                "operationId": "AnalyticsGetGroupsMembers",
+                "operationId": "AdminDeleteMemberNote",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "query"
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Start date string - RFC3339 encoded",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End date string - RFC3339 encoded",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "noteID",
+                        "name": "noteID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/rest.analyticsGetGroupsMembersResponse"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/analytics/posts": {
-            "get": {
+        "/api/admin/group/{groupID}/memberships/{membershipID}/labels": {
+            "put": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Gets posts",
+                "description": "Replaces the member labels assigned to a member of the desired group. The posts, events and notifications addressed to any of the labels reach the member. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Analytics"
+                    "Admin"
                 ],
-                "operationId": "AnalyticsGetPosts",
+                "operationId": "AdminUpdateMembershipLabels",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "query"
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Start date string - RFC3339 encoded",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End date string - RFC3339 encoded",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "membershipID",
+                        "name": "membershipID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/membershipLabelsRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/rest.analyticsGetPostsResponse"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/bbs/groups/{user_id}/memberships": {
+        "/api/admin/group/{groupID}/memberships/{membershipID}/notes": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets all related group memberships status and group title using userID",
+                "description": "Gets the private admin notes on a member ordered by date. The retained notes on a previous membership of the same user are included. Only group admins are allowed to do it.",
                 "tags": [
-                    "BBS"
+                    "Admin"
                 ],
-                "operationId": "GetGroupMemberships",
+                "operationId": "AdminGetMemberNotes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "membershipID",
+                        "name": "membershipID",
                         "in": "path",
                         "required": true
                     }
@@ -1580,34 +1594,26 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/GetGroupMembershipsResponse"
-                                }
+                                "$ref": "#/definitions/MemberNote"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/api/group/events/v3": {
+            },
             "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Create a calendar event and link it to multiple group ids",
+                "description": "Creates a private admin note on a member. The notes are never visible to the members. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateCalendarEventMultiGroup",
+                "operationId": "AdminCreateMemberNote",
                 "parameters": [
                     {
                         "type": "string",
@@ -1616,48 +1622,55 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "membershipID",
+                        "name": "membershipID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
+                            "$ref": "#/definitions/memberNoteRequestBody"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
+                            "$ref": "#/definitions/MemberNote"
                         }
                     }
                 }
             }
         },
-        "/api/group/{group-id}/authman/synchronize": {
-            "post": {
+        "/api/admin/group/{groupID}/memberships/{membershipID}/profile-values": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Synchronizes Authman group. Only admin of the group could initiate the operation",
+                "description": "Updates the profile values of a member of the desired group. A null value removes the value. Only group admins are allowed to do it.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "SynchAuthmanGroup",
+                "operationId": "AdminUpdateMemberProfileValues",
                 "parameters": [
                     {
                         "type": "string",
@@ -1668,10 +1681,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "membershipID",
+                        "name": "membershipID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "profile values by field key",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 ],
                 "responses": {
@@ -1681,24 +1711,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/group/{group-id}/event/{event-id}": {
-            "delete": {
+        "/api/admin/group/{groupID}/posts": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a group event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "gets all posts for the desired group.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeleteGroupEvent",
+                "operationId": "AdminGetGroupPosts",
                 "parameters": [
                     {
                         "type": "string",
@@ -1709,47 +1733,61 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "event-id",
-                        "in": "path",
-                        "required": true
+                        "description": "Values: message|post",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc|desc",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/group/{group-id}/events": {
+        "/api/admin/group/{groupID}/posts/expired": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gives the group events.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the expired top level posts within the desired group. The expired posts and their replies are hidden for the members and they are purged after a grace period. Only group admins are allowed to do it.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupEvents",
+                "operationId": "AdminGetGroupExpiredPosts",
                 "parameters": [
                     {
                         "type": "string",
@@ -1760,10 +1798,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1772,29 +1822,25 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/model.Post"
                             }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/group/{groupID}/posts/pending": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a group event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets the posts which wait for review within the desired group. Only group admins are allowed to do it.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateGroupEvent",
+                "operationId": "AdminGetGroupPendingPosts",
                 "parameters": [
                     {
                         "type": "string",
@@ -1803,49 +1849,51 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/groupEventRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/group/{groupID}/posts/series": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Creates a group event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets the recurring posts of the desired group. Only group admins are allowed to do it.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateGroupEvent",
+                "operationId": "AdminGetGroupPostSeries",
                 "parameters": [
                     {
                         "type": "string",
@@ -1854,48 +1902,46 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/groupEventRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of active, paused, canceled or completed",
+                        "name": "statuses",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PostSeries"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/group/{group-id}/events/v2": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the group events.",
+                "description": "Creates a recurring post within the desired group. The scheduler publishes each occurrence as a separate post. The recurrence is daily, weekly on given weekdays, monthly or a RRULE subset (FREQ, INTERVAL, BYDAY, COUNT, UNTIL) and it requires an end date or an occurrences count. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupEventsV2",
+                "operationId": "AdminCreateGroupPostSeries",
                 "parameters": [
                     {
                         "type": "string",
@@ -1906,43 +1952,46 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PostSeries"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Event"
-                            }
+                            "$ref": "#/definitions/PostSeries"
                         }
                     }
                 }
             }
         },
-        "/api/group/{group-id}/events/v3": {
+        "/api/admin/group/{groupID}/posts/series/{seriesID}/status": {
             "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a calendar event and for a single group id",
+                "description": "Pauses, resumes or cancels a recurring post within the desired group. The occurrences missed while the series is paused are skipped. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateCalendarEventSingleGroup",
+                "operationId": "AdminUpdateGroupPostSeriesStatus",
                 "parameters": [
                     {
                         "type": "string",
@@ -1953,8 +2002,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "group id",
-                        "name": "id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "seriesID",
+                        "name": "seriesID",
                         "in": "path",
                         "required": true
                     },
@@ -1964,43 +2020,35 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/updatePostSeriesStatusRequestBody"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/PostSeries"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/group/{groupID}/posts/{postID}/review": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Create a calendar event and link it to a single group id",
+                "description": "Approves or rejects a post which waits for review within the desired group. The group members are notified for the post only when it's approved. The creator is notified for the decision.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateCalendarEventSingleGroup",
+                "operationId": "AdminReviewGroupPost",
                 "parameters": [
                     {
                         "type": "string",
@@ -2011,8 +2059,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "group id",
-                        "name": "id",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
                     },
@@ -2022,45 +2077,35 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/reviewGroupPostRequestBody"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
+                            "$ref": "#/definitions/model.Post"
                         }
                     }
                 }
             }
         },
-        "/api/group/{group-id}/events/v3/load": {
-            "post": {
+        "/api/admin/group/{groupID}/profile-fields": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gets the group calendar events",
+                "description": "Replaces the custom member profile fields of the desired group. Every field has a type (text, number, boolean, select or date), a visibility (admins, members or public) and says whether the members can edit it. The values of the removed fields are removed from the memberships. Only group admins are allowed to do it.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupCalendarEventsV3",
+                "operationId": "AdminUpdateGroupProfileFields",
                 "parameters": [
                     {
                         "type": "string",
@@ -2071,8 +2116,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
                     },
@@ -2080,8 +2125,12 @@ const docTemplate = `{
                         "description": "body data",
                         "name": "data",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupEventFilter"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MemberProfileField"
+                            }
                         }
                     }
                 ],
@@ -2089,37 +2138,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MemberProfileField"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/group/{group-id}/members": {
+        "/api/admin/group/{groupID}/roster": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets the list of group members. The result would be empty if the current user doesn't belong to the requested group.",
-                "consumes": [
-                    "text/plain"
-                ],
+                "description": "Exports the members of the group together with the private admin notes on them. Only group admins are allowed to do it.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateMember",
+                "operationId": "AdminGetMemberRoster",
                 "parameters": [
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MembershipFilter"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "APP",
@@ -2129,8 +2169,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "groupID",
+                        "name": "groupID",
                         "in": "path",
                         "required": true
                     }
@@ -2141,49 +2181,38 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/GroupMembership"
+                                "$ref": "#/definitions/MemberRosterEntry"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/group/{groupId}/posts/{postId}": {
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Adds a member to a group. The current user is required to be an admin of the group",
+                "description": "Updates a post within the desired group.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateMember",
+                "operationId": "AdminDeleteGroupPost",
                 "parameters": [
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/createMemberRequest"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "APP",
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2191,21 +2220,26 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/group/{id}": {
             "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a member membership from a group",
+                "description": "Deletes a group.",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeleteMember",
+                "operationId": "AdminDeleteGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -2216,15 +2250,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfuly deleted",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
@@ -2232,31 +2266,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/group/{group-id}/members/multi-update": {
-            "put": {
+        "/api/admin/groups": {
+            "get": {
                 "security": [
+                    {
+                        "APIKeyAuth": []
+                    },
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates multiple members in a group at once with status and other details",
+                "description": "Gives the groups list. It can be filtered by category",
                 "consumes": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "MultiUpdateMembers",
+                "operationId": "AdminGetAllGroups",
                 "parameters": [
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MembershipMultiUpdate"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "APP",
@@ -2266,37 +2294,78 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/api/group/{group-id}/pending-members": {
-            "post": {
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GroupsFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Group"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/managed-group-configs": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Creates a group pending member",
+                "description": "Gets managed group configs",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreatePendingMember",
+                "operationId": "AdminGetManagedGroupConfigs",
                 "parameters": [
                     {
                         "type": "string",
@@ -2304,54 +2373,44 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/createPendingMemberRequest"
-                        }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "423": {
-                        "description": "Locked",
-                        "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ManagedGroupConfig"
+                            }
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a group pending member",
+                "description": "Updates an existing managed group config",
                 "consumes": [
                     "text/plain"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeletePendingMember",
+                "operationId": "AdminUpdateManagedGroupConfig",
                 "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ManagedGroupConfig"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "APP",
@@ -2361,81 +2420,72 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
+                        "description": "ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfuly deleted",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "OK"
                     }
                 }
-            }
-        },
-        "/api/group/{group-id}/stats": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieves stats for a group by id",
+                "description": "Creates a new managed group config",
                 "consumes": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupStats",
+                "operationId": "AdminCreateManagedGroupConfig",
                 "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ManagedGroupConfig"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "APP",
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/GroupStats"
-                            }
+                            "$ref": "#/definitions/ManagedGroupConfig"
                         }
                     }
                 }
             }
         },
-        "/api/group/{groupID}/posts": {
-            "get": {
+        "/api/admin/managed-group-configs/{id}": {
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "gets all posts for the desired group.",
+                "description": "Deletes a managed group config",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupPosts",
+                "operationId": "AdminDeleteManagedGroupConfig",
                 "parameters": [
                     {
                         "type": "string",
@@ -2446,60 +2496,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "groupID",
-                        "name": "groupID",
-                        "in": "query",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Values: message|post",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "asc|desc",
-                        "name": "order",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Post"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/group/{groupId}/posts": {
-            "post": {
+        "/api/admin/memberships/{membership-id}": {
+            "put": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "creates a post within the desired group.",
+                "description": "Updates a membership. Only the status can be changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2507,9 +2524,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateGroupPost",
+                "operationId": "AdminUpdateMembership",
                 "parameters": [
                     {
                         "type": "string",
@@ -2517,36 +2534,47 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateMembershipRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Post"
-                        }
+                        "description": "OK"
                     }
                 }
-            }
-        },
-        "/api/group/{groupId}/posts/{postId}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gets a post within the desired group.",
+                "description": "Deletes a membership",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroupPost",
+                "operationId": "AdminDeleteMembership",
                 "parameters": [
                     {
                         "type": "string",
@@ -2554,34 +2582,34 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Post"
-                        }
+                        "description": "OK"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/memberships/{membership-id}/history": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Updates a post within the desired group.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the status transitions of a membership in chronological order",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateGroupPost",
+                "operationId": "AdminGetMembershipStatusHistory",
                 "parameters": [
                     {
                         "type": "string",
@@ -2589,34 +2617,92 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Post"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MembershipTransition"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/notification-outbox": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets the notification outbox items ordered by creation date descending",
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminGetOutboxNotifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "APIKeyAuth": []
+                        "type": "string",
+                        "description": "pending, sent or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
-                "description": "Updates a post within the desired group.",
-                "consumes": [
-                    "application/json"
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/OutboxNotification"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/notification-outbox/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
                 ],
+                "description": "Moves a dead-lettered notification back to the outbox for immediate delivery",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeleteGroupPost",
+                "operationId": "AdminReplayOutboxNotification",
                 "parameters": [
                     {
                         "type": "string",
@@ -2624,6 +2710,13 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2633,24 +2726,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/group/{groupId}/posts/{postId}/reactions": {
-            "put": {
+        "/api/admin/notification-templates": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Reacts to a post within the desired group.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the notification templates of the client by optional group and operation",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "ReactToGroupPost",
+                "operationId": "AdminGetNotificationTemplates",
                 "parameters": [
                     {
                         "type": "string",
@@ -2658,76 +2745,124 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "operation",
+                        "name": "operation",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/NotificationTemplate"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/group/{groupId}/posts/{postId}/report/abuse": {
+            },
             "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Reports an abusive group post",
+                "description": "Updates the title and the body of an existing notification template",
                 "consumes": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "ReportAbuseGroupPost",
+                "operationId": "AdminUpdateNotificationTemplate",
                 "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/NotificationTemplate"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "APP",
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Creates a new notification template. The template is applied for all groups of the client if group_id is missing and for all locales if locale is empty.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminCreateNotificationTemplate",
+                "parameters": [
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reportAbuseGroupPostRequestBody"
+                            "$ref": "#/definitions/NotificationTemplate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/NotificationTemplate"
+                        }
                     }
                 }
             }
         },
-        "/api/group/{id}": {
-            "delete": {
+        "/api/admin/notification-templates/defaults": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a group.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Gets the built-in notification templates mapped by operation. They are used when neither the client nor the group has its own template.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeleteGroup",
+                "operationId": "AdminGetDefaultNotificationTemplates",
                 "parameters": [
                     {
                         "type": "string",
@@ -2735,40 +2870,33 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/NotificationTemplate"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/group/{id}/report/abuse": {
-            "put": {
+        "/api/admin/notification-templates/{id}": {
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Reports an abusive group",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a notification template. The less specific or the built-in template is used afterwards.",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "ReportAbuseGroup",
+                "operationId": "AdminDeleteNotificationTemplate",
                 "parameters": [
                     {
                         "type": "string",
@@ -2778,13 +2906,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reportAbuseGroupRequestBody"
-                        }
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2794,24 +2920,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/groups": {
+        "/api/admin/reactions-configs": {
             "get": {
                 "security": [
-                    {
-                        "APIKeyAuth": []
-                    },
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the groups list. It can be filtered by category",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the reactions config of the client",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetGroups",
+                "operationId": "AdminGetReactionsConfig",
                 "parameters": [
                     {
                         "type": "string",
@@ -2819,82 +2939,71 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ReactionsConfig"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
-                    },
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Saves the reactions config of the client. Empty allowed_reactions means that any reaction is allowed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminSaveReactionsConfig",
+                "parameters": [
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
+                            "$ref": "#/definitions/ReactionsConfig"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Group"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/sync-configs": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Creates a group. The user must be part of urn:mace:uiuc.edu:urbana:authman:app-rokwire-service-policy-rokwire groups access. Title must be a unique. Category must be one of the categories list. Privacy can be public or private",
+                "description": "Gets sync config",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "CreateGroup",
+                "operationId": "AdminGetSyncConfigs",
                 "parameters": [
                     {
                         "type": "string",
@@ -2902,45 +3011,71 @@ const docTemplate = `{
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SyncConfig"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Saves sync config",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminSaveSyncConfig",
+                "parameters": [
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/createGroupRequest"
+                            "$ref": "#/definitions/model.SyncConfig"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/createResponse"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/groups/{id}": {
+        "/api/admin/user/event/{event-id}/groups": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gives a group",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Updates the group mappings for an event with id",
                 "tags": [
                     "Client"
                 ],
-                "operationId": "GetGroup",
+                "operationId": "UpdateGroupMappingsEventID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2951,8 +3086,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Event ID",
+                        "name": "event-id",
                         "in": "path",
                         "required": true
                     }
@@ -2961,28 +3096,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/getGroupResponse"
+                            "$ref": "#/definitions/getPutAdminGroupIDsForEventIDRequestAndResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/user/event/{event_id}/groups": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a group.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Get all group IDs where the current user is an admin",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateGroup",
+                "operationId": "AdminGetAdminGroupIDsForEventID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2991,19 +3122,10 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/updateGroupRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Event ID",
+                        "name": "event-id",
                         "in": "path",
                         "required": true
                     }
@@ -3012,46 +3134,73 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/getPutAdminGroupIDsForEventIDRequestAndResponse"
                         }
                     }
                 }
             }
         },
-        "/api/int/group/title/{title}/members": {
+        "/api/admin/user/groups": {
             "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "APIKeyAuth": []
+                    },
+                    {
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieves group members by  title",
+                "description": "Gives the groups list. It can be filtered by category",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "IntGetGroupMembersByGroupTitle",
+                "operationId": "AdminGetUserGroups",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Title",
-                        "name": "identifier",
-                        "in": "path",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Offsetting result",
+                        "description": "Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "offset - skip number of records",
                         "name": "offset",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Limiting the result",
+                        "description": "limit - limit the result",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3060,31 +3209,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/ShortMemberRecord"
+                                "$ref": "#/definitions/Group"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/int/group/{group-id}/date_updated": {
-            "post": {
+        "/api/admin/v2/groups": {
+            "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Updates the date updated field of the desired group",
+                "description": "Gives the groups list. It can be filtered by category, title and privacy. V2",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "IntUpdateGroupDateUpdated",
+                "operationId": "AdminGetGroupsV2",
                 "parameters": [
                     {
                         "type": "string",
@@ -3095,44 +3241,39 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/api/int/group/{group-id}/events": {
-            "post": {
-                "security": [
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
                     {
-                        "IntAPIKeyAuth": []
-                    }
-                ],
-                "description": "Creates a group event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Internal"
-                ],
-                "operationId": "IntCreateGroupEvent",
-                "parameters": [
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
                     },
                     {
                         "description": "body data",
@@ -3140,36 +3281,38 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intCreateGroupEventRequestBody"
+                            "$ref": "#/definitions/GroupsFilter"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Group"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/int/group/{group-id}/events/{event-id}": {
-            "delete": {
+        "/api/admin/v2/groups/{id}": {
+            "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a group event",
+                "description": "Gives a group. V2",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "IntDeleteGroupEvent",
+                "operationId": "AdminGetGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -3180,41 +3323,40 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "event-id",
+                        "description": "ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Group"
+                        }
                     }
                 }
             }
         },
-        "/api/int/group/{group-id}/notification": {
-            "post": {
+        "/api/admin/v2/user/groups": {
+            "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Sends a notification to members of a group",
+                "description": "Gives the user groups. It can be filtered by category, title and privacy. V2.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "SendGroupNotification",
+                "operationId": "AdminGetUserGroupsV2",
                 "parameters": [
                     {
                         "type": "string",
@@ -3225,8 +3367,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "The name of the service which invokes the API",
-                        "name": "service",
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
                         "in": "query"
                     },
                     {
@@ -3235,79 +3407,76 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/sendGroupNotificationRequestBody"
+                            "$ref": "#/definitions/GroupsFilter"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Group ID",
-                        "name": "group-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Group"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/int/group/{identifier}": {
-            "get": {
+        "/api/admin/webhook-deliveries/{id}/replay": {
+            "post": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieves group details and members",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sends a delivered or dead-lettered delivery again with the same payload and delivery id",
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "IntGetGroup",
+                "operationId": "AdminReplayWebhookDelivery",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identifier",
-                        "name": "identifier",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "delivery id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Group"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/int/user/{identifier}/groups": {
+        "/api/admin/webhook-subscriptions": {
             "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the user groups memberships",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the webhook subscriptions of the client. The secrets are not returned.",
                 "tags": [
-                    "Internal"
+                    "Admin"
                 ],
-                "operationId": "IntGetUserGroupMemberships",
+                "operationId": "AdminGetWebhookSubscriptions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identifier",
-                        "name": "identifier",
-                        "in": "path",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -3315,125 +3484,106 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.userGroupShortDetail"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebhookSubscription"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/memberships/{membership-id}": {
+            },
             "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a membership. Only admin can update the status and date_attended fields of a membership record. Member is allowed to update only his/her notification preferences.",
+                "description": "Updates an existing webhook subscription. The secret is changed only if it is provided.",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "UpdateMembership",
+                "operationId": "AdminUpdateWebhookSubscription",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/updateMembershipRequest"
+                            "$ref": "#/definitions/WebhookSubscription"
                         }
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "membership-id",
-                        "in": "path",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "OK"
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Deletes a membership",
+                "description": "Creates a new webhook subscription. A secret is generated if it is missing. The secret is returned only in this response.\nEvery delivery is signed with the X-Groups-Signature header - \"sha256=\" followed by the hex encoded HMAC-SHA256 of \"\u003cX-Groups-Timestamp\u003e.\u003cbody\u003e\".",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "DeleteMembership",
+                "operationId": "AdminCreateWebhookSubscription",
                 "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WebhookSubscription"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "APP",
                         "name": "APP",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Membership ID",
-                        "name": "membership-id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/WebhookSubscription"
                         }
                     }
                 }
             }
         },
-        "/api/memberships/{membership-id}/approval": {
-            "put": {
+        "/api/admin/webhook-subscriptions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Аpprove/Deny a membership",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Deletes a webhook subscription together with its delivery log",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "MembershipApproval",
+                "operationId": "AdminDeleteWebhookSubscription",
                 "parameters": [
                     {
                         "type": "string",
@@ -3442,48 +3592,33 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/membershipApprovalRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "membership-id",
+                        "description": "ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/api/research-profile/user-count": {
-            "post": {
+        "/api/admin/webhook-subscriptions/{id}/deliveries": {
+            "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieves the user count matching the provided research profile",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets the delivery log of a webhook subscription ordered by creation date descending",
                 "tags": [
-                    "Client"
+                    "Admin"
                 ],
-                "operationId": "GetResearchProfileUserCount",
+                "operationId": "AdminGetWebhookDeliveries",
                 "parameters": [
                     {
                         "type": "string",
@@ -3493,160 +3628,167 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Research profile",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "object",
-                                "additionalProperties": {
-                                    "type": "array",
-                                    "items": {
-                                        "type": "string"
-                                    }
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "subscription id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, delivered or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebhookDelivery"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/user": {
-            "delete": {
+        "/api/analytics/groups": {
+            "get": {
                 "security": [
                     {
-                        "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
+                        "IntAPIKeyAuth": []
                     }
                 ],
-                "description": "Deletes a user with all the involved information from the Notifications BB (this includes - group membership \u0026 posts (and child posts - no matter of the creator))",
+                "description": "Gets groups",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Client"
+                    "Analytics"
+                ],
+                "operationId": "AnalyticsGetGroups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date string - RFC3339 encoded",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date string - RFC3339 encoded",
+                        "name": "end_date",
+                        "in": "query"
+                    }
                 ],
-                "operationId": "DeleteUser",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.analyticsGetGroupsResponse"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/user/group-memberships": {
+        "/api/analytics/members": {
             "get": {
                 "security": [
                     {
-                        "AppUserAuth": []
+                        "IntAPIKeyAuth": []
                     }
                 ],
-                "description": "Gives the user groups memberships",
+                "description": "Gets groups members",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Analytics"
                 ],
-                "operationId": "GetUserGroupMemberships",
+                "operationId": "AnalyticsGetGroupsMembers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identifier",
-                        "name": "identifier",
-                        "in": "path",
-                        "required": true
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date string - RFC3339 encoded",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date string - RFC3339 encoded",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.userGroupShortDetail"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.analyticsGetGroupsMembersResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/user/groups": {
+        "/api/analytics/posts": {
             "get": {
                 "security": [
                     {
-                        "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
+                        "IntAPIKeyAuth": []
                     }
                 ],
-                "description": "Gives the user groups.",
+                "description": "Gets posts",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Analytics"
                 ],
-                "operationId": "GetUserGroups",
+                "operationId": "AnalyticsGetPosts",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
+                        "description": "Group ID",
+                        "name": "group_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
+                        "description": "Start date string - RFC3339 encoded",
+                        "name": "start_date",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
+                        "description": "End date string - RFC3339 encoded",
+                        "name": "end_date",
                         "in": "query"
-                    },
-                    {
-                        "description": "body data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
-                        }
                     }
                 ],
                 "responses": {
@@ -3655,81 +3797,178 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/getUserGroupsResponse"
+                                "$ref": "#/definitions/rest.analyticsGetPostsResponse"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/user/login": {
+        "/api/bbs/groups": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
-                    },
-                    {
-                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Logs in the user and refactor the user record and linked data if need",
+                "description": "Gets all related groups by groupIDs",
                 "tags": [
-                    "Client"
+                    "BBS"
+                ],
+                "operationId": "GetGroupsbyGroupsIDs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated groupIDs",
+                        "name": "group-ids",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "operationId": "LoginUser",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/Group"
+                                }
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/user/stats": {
+        "/api/bbs/groups/events": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gets user stat information. Responds with {\"posts_count\": xxx}",
+                "description": "Gets all related eventID and groupID using eventIDs",
                 "tags": [
-                    "Client"
+                    "BBS"
                 ],
-                "operationId": "GetUserStats",
+                "operationId": "GetGroupsEvents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "APP",
-                        "name": "APP",
-                        "in": "header",
-                        "required": true
+                        "description": "comma separated eventIDs",
+                        "name": "events-ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/getUserStatsResponse"
-                        }
-                    }
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/GetGroupsEvents"
+                                }
+                            }
+                        }
+                    }
                 }
             }
         },
-        "/api/v2/groups": {
+        "/api/bbs/groups/{group_id}/group-memberships": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives the groups list. It can be filtered by category, title and privacy. V2",
+                "description": "Gets all related group memberships status and group title using groupID",
+                "tags": [
+                    "BBS"
+                ],
+                "operationId": "GetGroupMembershipsByGroupID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/bbs/groups/{user_id}/memberships": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets all related group memberships status and group title using userID",
+                "tags": [
+                    "BBS"
+                ],
+                "operationId": "GetGroupMemberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/GetGroupMembershipsResponse"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/events/v3": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Create a calendar event and link it to multiple group ids",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Client"
                 ],
-                "operationId": "GetGroupsV2",
+                "operationId": "CreateCalendarEventMultiGroup",
                 "parameters": [
                     {
                         "type": "string",
@@ -3738,80 +3977,89 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! category - filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
-                        "name": "privacy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! limit - limit the result",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
-                        "name": "include_hidden",
-                        "in": "query"
-                    },
                     {
                         "description": "body data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GroupsFilter"
+                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Group"
-                            }
+                            "$ref": "#/definitions/rest.createCalendarEventMultiGroupData"
                         }
                     }
                 }
             }
         },
-        "/api/v2/groups/{id}": {
-            "get": {
+        "/api/group/{group-id}/authman/synchronize": {
+            "post": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Gives a group. V2",
+                "description": "Synchronizes Authman group. Only admin of the group could initiate the operation",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "SynchAuthmanGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/group/{group-id}/event/{event-id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes a group event",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Client"
                 ],
-                "operationId": "GetGroupV2",
+                "operationId": "DeleteGroupEvent",
                 "parameters": [
                     {
                         "type": "string",
@@ -3822,8 +4070,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event-id",
                         "in": "path",
                         "required": true
                     }
@@ -3832,29 +4087,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Group"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/v2/user/groups": {
+        "/api/group/{group-id}/events": {
             "get": {
                 "security": [
                     {
                         "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Gets all related group users linked for the described event id",
+                "description": "Gives the group events.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "BBS"
+                    "Client"
                 ],
-                "operationId": "BBSGetEventUsers",
+                "operationId": "GetGroupEvents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "event_id",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
                         "in": "path",
                         "required": true
                     }
@@ -3865,21 +4133,19 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/getEventUserIDsResponse"
+                                "type": "string"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/group/{group-id}/members": {
+            },
             "put": {
                 "security": [
                     {
                         "AppUserAuth": []
                     }
                 ],
-                "description": "Updates a membership. Only the status can be changed.",
+                "description": "Updates a group event",
                 "consumes": [
                     "application/json"
                 ],
@@ -3887,9 +4153,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Client"
                 ],
-                "operationId": "AdminCreateMemberships",
+                "operationId": "UpdateGroupEvent",
                 "parameters": [
                     {
                         "type": "string",
@@ -3904,10 +4170,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.MembershipStatus"
-                            }
+                            "$ref": "#/definitions/groupEventRequest"
                         }
                     },
                     {
@@ -3920,726 +4183,4812 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            }
-        },
-        "/int/authman/synchronize": {
+            },
             "post": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Synchronizes Authman groups membership",
+                "description": "Creates a group event",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Internal"
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateGroupEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/groupEventRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "operationId": "SynchronizeAuthman",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/int/stats": {
+        "/api/group/{group-id}/events/v2": {
             "get": {
                 "security": [
                     {
-                        "IntAPIKeyAuth": []
+                        "AppUserAuth": []
                     }
                 ],
-                "description": "Retrieve group stats",
+                "description": "Gives the group events.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Internal"
+                    "Client"
+                ],
+                "operationId": "GetGroupEventsV2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "operationId": "IntGroupStats",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/GroupsStats"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Event"
+                            }
                         }
                     }
                 }
             }
         },
-        "/version": {
-            "get": {
-                "description": "Gives the service version.",
+        "/api/group/{group-id}/events/v3": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates a calendar event and for a single group id",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "Client"
                 ],
-                "operationId": "Version",
+                "operationId": "UpdateCalendarEventSingleGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/rest.updateCalendarEventSingleGroupData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Create a calendar event and link it to a single group id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateCalendarEventSingleGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.createCalendarEventSingleGroupData"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "AccountIdentifiers": {
-            "type": "object",
-            "properties": {
-                "account_id": {
-                    "type": "string"
-                },
-                "external_id": {
-                    "type": "string"
+        },
+        "/api/group/{group-id}/events/v3/load": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets the group calendar events",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupCalendarEventsV3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/GroupEventFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "Creator": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "user_id"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+        "/api/group/{group-id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets the list of group members. The result would be empty if the current user doesn't belong to the requested group.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateMember",
+                "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MembershipFilter"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupMembership"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Adds a member to a group. The current user is required to be an admin of the group",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateMember",
+                "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes a member membership from a group",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeleteMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfuly deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{group-id}/members/multi-update": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates multiple members in a group at once with status and other details",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "MultiUpdateMembers",
+                "parameters": [
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MembershipMultiUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/group/{group-id}/members/search": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Searches the members of the desired group by the prefixes of the name words, the NetID and the email. The fuzzy match tolerates typos. The query is matched only against the member fields which the group settings allow the current user to see. Only the group members are allowed to do it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "SearchGroupMembers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MemberSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MemberSearchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{group-id}/pending-members": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Creates a group pending member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreatePendingMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createPendingMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes a group pending member",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeletePendingMember",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfuly deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{group-id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Retrieves stats for a group by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupStats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupStats"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/attendance/check-in": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Checks in the current user to the attendance session whose check-in window accepts the code. Non-members are added as pending members if the window allows it. Too many invalid codes block the check-in for a while.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "SelfCheckInAttendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/selfCheckInAttendanceRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AttendanceRecord"
+                        }
+                    },
+                    "403": {
+                        "description": "the code is invalid or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "too many invalid codes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/feed": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Streams the real-time events of the group as Server-Sent Events: post.created, post.updated, post.deleted, post.reactions and membership.approved.\nEvery event is delivered only to the members who can see it. Reconnect and reload the posts if the stream is closed.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupFeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/FeedEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "gets all posts for the desired group.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPosts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Values: message|post",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc|desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "paging only - load only the latest direct replies of the top level posts",
+                        "name": "replies_limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/cross-post": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a post within the desired group and copies of it within the groups from group_ids. The post preferences of every group are validated separately. The copies are linked to the origin post with origin_id and follow its edits and deletion. Every user is notified only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateGroupCrossPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createCrossPostRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/pinned": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets the pinned posts and the active announcements within the desired group. Expired announcements are not returned.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPinnedPosts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/{postID}/pin": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Pins or unpins a top level post within the desired group. Optionally marks it as an announcement. Only group admins are allowed to do it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "PinGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pinGroupPostRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/{postID}/reactions": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets who has reacted to a post within the desired group with what. The reactions are ordered by reaction and then by reaction time.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPostReactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reaction - only the users who have reacted with it",
+                        "name": "reaction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PostReaction"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/{postID}/replies": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets a page of the direct replies of a post or a reply within the desired group. The nested replies are loaded up to the provided depth (1 to 5) with the same limit on every level. Every reply contains its replies_count so the deeper levels can be paged with the same API.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPostReplies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit - 20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "depth - 1 by default",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc|desc - desc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/posts/{postID}/seen": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets who has seen a post within the desired group. Only the creator of the post and the group admins are allowed to do it.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPostSeenRecords",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PostSeen"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Records that the current user has seen a post within the desired group",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "MarkGroupPostSeen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "postID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/profile-values": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates the profile values of the current user within the desired group. Only the fields which the members can edit are allowed. A null value removes the value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "UpdateOwnProfileValues",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "profile values by field key",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/group/{groupID}/read": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Moves forward the last read marker of the current user within the desired group. The current time is used if date_last_read is missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "MarkGroupAsRead",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groupID",
+                        "name": "groupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/markGroupAsReadRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupId}/posts": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "creates a post within the desired group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupId}/posts/{postId}": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets a post within the desired group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Updates a post within the desired group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "UpdateGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Updates a post within the desired group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeleteGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/group/{groupId}/posts/{postId}/reactions": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Reacts to a post within the desired group. Reacting again with the same reaction removes it. The reaction must be one of the allowed reactions of the group or the client if they are defined.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "ReactToGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reactToGroupPostRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{groupId}/posts/{postId}/report/abuse": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Reports an abusive group post",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "ReportAbuseGroupPost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reportAbuseGroupPostRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/group/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes a group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeleteGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{id}/report/abuse": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Reports an abusive group",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "ReportAbuseGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reportAbuseGroupRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/groups": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    },
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gives the groups list. It can be filtered by category",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GroupsFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Group"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Creates a group. The user must be part of urn:mace:uiuc.edu:urbana:authman:app-rokwire-service-policy-rokwire groups access. Title must be a unique. Category must be one of the categories list. Privacy can be public or private",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "CreateGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/createResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gives a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getGroupResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates a group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "UpdateGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateGroupRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/int/changes": {
+            "get": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Reads the normalized changes of the groups, the memberships and the posts of the client. The sensitive fields are redacted.\nThe request waits up to \"wait\" seconds for the first change (long-poll). Continue reading with the returned next_token as \"since\".\nWithout \"since\" the feed starts from now. 410 Gone means that the feed cannot be resumed from the token and the consumer must resynchronize.\nSend \"Accept: text/event-stream\" to receive the changes as Server-Sent Events instead. The event id is the token to resume from.",
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntGetChanges",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the token to continue from",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the max count of the changes - default 100, max 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the max wait in seconds for the first change - default 20, max 60",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangeBatch"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/int/group/title/{title}/members": {
+            "get": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves group members by  title",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntGetGroupMembersByGroupTitle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Title",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Offsetting result",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limiting the result",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ShortMemberRecord"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/int/group/{group-id}/date_updated": {
+            "post": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Updates the date updated field of the desired group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntUpdateGroupDateUpdated",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/int/group/{group-id}/events": {
+            "post": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a group event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntCreateGroupEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intCreateGroupEventRequestBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/int/group/{group-id}/events/{event-id}": {
+            "delete": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a group event",
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntDeleteGroupEvent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/int/group/{group-id}/notification": {
+            "post": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Sends a notification to members of a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "SendGroupNotification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the service which invokes the API",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sendGroupNotificationRequestBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/int/group/{identifier}": {
+            "get": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves group details and members",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntGetGroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifier",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Group"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/int/user/{identifier}/groups": {
+            "get": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Gives the user groups memberships",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntGetUserGroupMemberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifier",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.userGroupShortDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/memberships/{membership-id}": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates a membership. Only admin can update the status and date_attended fields of a membership record. Member is allowed to update only his/her notification preferences.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "UpdateMembership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateMembershipRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Deletes a membership",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeleteMembership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/memberships/{membership-id}/approval": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Аpprove/Deny a membership",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "MembershipApproval",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/membershipApprovalRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/memberships/{membership-id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets the status transitions of a membership in chronological order. Only the group admins can get them.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetMembershipStatusHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membership-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MembershipTransition"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reactions-config": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gets the reactions allowed for the client. Empty allowed_reactions means that any reaction is allowed. The groups may narrow them with the allowed_reactions of their post preferences.",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetReactionsConfig",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ReactionsConfig"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/research-profile/user-count": {
+            "post": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Retrieves the user count matching the provided research profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetResearchProfileUserCount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Research profile",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user": {
+            "delete": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a user with all the involved information from the Notifications BB (this includes - group membership \u0026 posts (and child posts - no matter of the creator))",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "DeleteUser",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/user/feed": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Streams the real-time events of all groups in which the current user is a member as Server-Sent Events. See the group feed for the events.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetUserFeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/FeedEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/group-memberships": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gives the user groups memberships",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetUserGroupMemberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifier",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.userGroupShortDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/groups": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Gives the user groups.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetUserGroups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GroupsFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/getUserGroupsResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Logs in the user and refactor the user record and linked data if need",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "LoginUser",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/user/stats": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets user stat information. Responds with {\"posts_count\": xxx}",
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetUserStats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getUserStatsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/groups": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gives the groups list. It can be filtered by category, title and privacy. V2",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupsV2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! Filtering by group's title (case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! category - filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! privacy - filter by privacy",
+                        "name": "privacy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! offset - skip number of records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! limit - limit the result",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated - instead use request body filter! include_hidden - Includes hidden groups if a search by title is performed. Possible value is true. Default false.",
+                        "name": "include_hidden",
+                        "in": "query"
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GroupsFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Group"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gives a group. V2",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "GetGroupV2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Group"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/user/groups": {
+            "get": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Gets all related group users linked for the described event id",
+                "tags": [
+                    "BBS"
+                ],
+                "operationId": "BBSGetEventUsers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/getEventUserIDsResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/group/{group-id}/members": {
+            "put": {
+                "security": [
+                    {
+                        "AppUserAuth": []
+                    }
+                ],
+                "description": "Updates a membership. Only the status can be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "operationId": "AdminCreateMemberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "APP",
+                        "name": "APP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "body data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MembershipStatus"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/int/authman/synchronize": {
+            "post": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Synchronizes Authman groups membership",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "SynchronizeAuthman",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/int/stats": {
+            "get": {
+                "security": [
+                    {
+                        "IntAPIKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve group stats",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "operationId": "IntGroupStats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/GroupsStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Gives the service version.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "operationId": "Version",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AbuseReport": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_resolved": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AbuseReportEntry"
+                    }
+                },
+                "reports_count": {
+                    "type": "integer"
+                },
+                "resolution_notes": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "send_to_dean": {
+                    "type": "boolean"
+                },
+                "send_to_group_admins": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "open, reviewing, actioned or dismissed",
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_title": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "description": "group, post or reply",
+                    "type": "string"
+                }
+            }
+        },
+        "AbuseReportEntry": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "reporter_external_id": {
+                    "type": "string"
+                },
+                "reporter_id": {
+                    "type": "string"
+                },
+                "reporter_name": {
+                    "type": "string"
+                },
+                "send_to_dean": {
+                    "type": "boolean"
+                },
+                "send_to_group_admins": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "AccountIdentifiers": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceCheckInCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "date_closes": {
+                    "description": "when the check-in window closes",
+                    "type": "string"
+                },
+                "date_expires": {
+                    "type": "string"
+                },
+                "qr_payload": {
+                    "description": "the content of the QR code which the app scans",
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceCheckInWindow": {
+            "type": "object",
+            "properties": {
+                "auto_add_non_members": {
+                    "type": "boolean"
+                },
+                "code_period": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "date_closes": {
+                    "type": "string"
+                },
+                "date_opens": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceMemberReport": {
+            "type": "object",
+            "properties": {
+                "attended_count": {
+                    "type": "integer"
+                },
+                "date_last_attended": {
+                    "type": "string"
+                },
+                "membership_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "attended count / sessions count",
+                    "type": "number"
+                },
+                "sessions_count": {
+                    "description": "the sessions since the member has joined",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceRecord": {
+            "type": "object",
+            "properties": {
+                "checked_in_by": {
+                    "type": "string"
+                },
+                "checked_out_by": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "date_checked_in": {
+                    "type": "string"
+                },
+                "date_checked_out": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "membership_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "how the member has been checked in",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceReport": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttendanceMemberReport"
+                    }
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttendanceSessionReport"
+                    }
+                }
+            }
+        },
+        "AttendanceSession": {
+            "type": "object",
+            "properties": {
+                "check_in_window": {
+                    "description": "set while the members may check in themselves",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/AttendanceCheckInWindow"
+                        }
+                    ]
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "the linked group calendar event",
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttendanceSessionReport": {
+            "type": "object",
+            "properties": {
+                "attendees_count": {
+                    "type": "integer"
+                },
+                "members_count": {
+                    "description": "the current members who have joined by the session date",
+                    "type": "integer"
+                },
+                "rate": {
+                    "description": "attendees count / members count",
+                    "type": "number"
+                },
+                "session": {
+                    "$ref": "#/definitions/AttendanceSession"
+                }
+            }
+        },
+        "ContentFilter": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patterns": {
+                    "description": "regular expressions, case insensitive",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "words": {
+                    "description": "matched as whole words, case insensitive",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Creator": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "user_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Event": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "creator": {
+                    "$ref": "#/definitions/Creator"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "to_label_ids": {
+                    "description": "the member labels the event is addressed to in addition to to_members",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to_members": {
+                    "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ToMember"
+                    }
+                }
+            }
+        },
+        "FeedEvent": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "member": {
+                    "description": "membership.approved only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/FeedMember"
+                        }
+                    ]
+                },
+                "post": {
+                    "description": "post.created and post.updated only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    ]
+                },
+                "post_id": {
+                    "type": "string"
+                },
+                "reaction_counts": {
+                    "description": "post.reactions only",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "FeedMember": {
+            "type": "object",
+            "properties": {
+                "membership_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetGroupMembershipsResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "group_title": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetGroupsEvents": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Group": {
+            "type": "object",
+            "properties": {
+                "attendance_group": {
+                    "type": "boolean"
+                },
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "authman_enabled": {
+                    "type": "boolean"
+                },
+                "authman_group": {
+                    "type": "string"
+                },
+                "block_new_membership_requests": {
+                    "type": "boolean"
+                },
+                "can_join_automatically": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "one of the enums categories list",
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "current_member": {
+                    "description": "this is indicative and it's not required for update APIs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/GroupMembership"
+                        }
+                    ]
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_managed_membership_updated": {
+                    "type": "string"
+                },
+                "date_membership_updated": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_format": {
+                    "description": "plain or markdown. Empty means plain",
+                    "type": "string"
+                },
+                "description_html": {
+                    "description": "the description rendered as safe HTML. This is constructed by the code",
+                    "type": "string"
+                },
+                "hidden_for_search": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_abuse": {
+                    "type": "boolean"
+                },
+                "member_labels": {
+                    "description": "the labels which the admins assign to the members. They are updated separately from the group",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberLabel"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Member"
+                    }
+                },
+                "membership_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "only_admins_can_create_polls": {
+                    "type": "boolean"
+                },
+                "privacy": {
+                    "description": "public or private",
+                    "type": "string"
+                },
+                "profile_fields": {
+                    "description": "the custom member profile fields. They are updated separately from the group",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberProfileField"
+                    }
+                },
+                "research_consent_details": {
+                    "type": "string"
+                },
+                "research_consent_statement": {
+                    "type": "string"
+                },
+                "research_description": {
+                    "type": "string"
+                },
+                "research_group": {
+                    "type": "boolean"
+                },
+                "research_open": {
+                    "type": "boolean"
+                },
+                "research_profile": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "settings": {
+                    "description": "TODO: Remove the pointer once the backward support is not needed any more!",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/GroupSettings"
+                        }
+                    ]
+                },
+                "stats": {
+                    "$ref": "#/definitions/GroupStats"
+                },
+                "sync_end_time": {
+                    "type": "string"
+                },
+                "sync_start_time": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unread_posts_count": {
+                    "description": "this is indicative and it's constructed only for the user groups",
+                    "type": "integer"
+                },
+                "web_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "GroupEventFilter": {
+            "type": "object",
+            "properties": {
+                "end_time_after": {
+                    "type": "integer"
+                },
+                "end_time_before": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "start_time_after": {
+                    "type": "integer"
+                },
+                "start_time_after_null_end_time": {
+                    "type": "integer"
+                },
+                "start_time_before": {
+                    "type": "integer"
+                },
+                "start_time_before_null_end_time": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GroupMembership": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "date_attended": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_digested": {
+                    "description": "the last time when the group activity has been included in an email digest for the member",
+                    "type": "string"
+                },
+                "date_last_read": {
+                    "description": "the last time when the member has read the group posts",
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "label_ids": {
+                    "description": "the group member labels assigned by the admins",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "member_answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberAnswer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "net_id": {
+                    "type": "string"
+                },
+                "notifications_preferences": {
+                    "$ref": "#/definitions/NotificationsPreferences"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "profile_values": {
+                    "description": "the values of the group profile fields by key",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "admin, pending, member, rejected",
+                    "type": "string"
+                },
+                "sync_id": {
+                    "description": "ID of sync that last updated this membership",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GroupSettings": {
+            "type": "object",
+            "properties": {
+                "member_info_preferences": {
+                    "$ref": "#/definitions/MemberInfoPreferences"
+                },
+                "member_notes_preferences": {
+                    "$ref": "#/definitions/MemberNotesPreferences"
+                },
+                "post_preferences": {
+                    "$ref": "#/definitions/PostPreferences"
+                }
+            }
+        },
+        "GroupStat": {
+            "type": "object",
+            "properties": {
+                "authman_enabled": {
+                    "type": "boolean"
+                },
+                "privacy": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/GroupStats"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "GroupStats": {
+            "type": "object",
+            "properties": {
+                "admins_count": {
+                    "type": "integer"
+                },
+                "attendance_count": {
+                    "type": "integer"
+                },
+                "member_count": {
+                    "type": "integer"
+                },
+                "pending_count": {
+                    "type": "integer"
+                },
+                "rejected_count": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "description": "pending and rejected are excluded",
+                    "type": "integer"
+                }
+            }
+        },
+        "GroupsFilter": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "authman_enabled": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "group category",
+                    "type": "string"
+                },
+                "exclude_my_groups": {
+                    "description": "Exclude My groups",
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "description": "Filter by hidden flag. Values: true (show only hidden), false (show only not hidden), missing - don't do any filtering on this field.",
+                    "type": "boolean"
+                },
+                "ids": {
+                    "description": "membership id",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "include_hidden": {
+                    "description": "Include hidden groups",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "result limit",
+                    "type": "integer"
+                },
+                "member_external_id": {
+                    "description": "member user external id",
+                    "type": "string"
+                },
+                "member_id": {
+                    "description": "member id",
+                    "type": "string"
+                },
+                "member_user_id": {
+                    "description": "member user id",
+                    "type": "string"
+                },
+                "offset": {
+                    "description": "result offset",
+                    "type": "integer"
+                },
+                "order": {
+                    "description": "order by category \u0026 name (asc desc)",
+                    "type": "string"
+                },
+                "privacy": {
+                    "description": "group privacy",
+                    "type": "string"
+                },
+                "research_answers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "research_group": {
+                    "type": "boolean"
+                },
+                "research_open": {
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "group tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "description": "group title",
+                    "type": "string"
+                }
+            }
+        },
+        "GroupsStats": {
+            "type": "object",
+            "properties": {
+                "groups_count": {
+                    "type": "integer"
+                },
+                "groups_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GroupStat"
+                    }
+                }
+            }
+        },
+        "ManagedGroupConfig": {
+            "type": "object",
+            "properties": {
+                "admin_uins": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "authman_stems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "Member": {
+            "type": "object",
+            "properties": {
+                "date_attended": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberAnswer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "net_id": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, member, admin, rejected",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberAnswer": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberInfoPreferences": {
+            "type": "object",
+            "properties": {
+                "allow_member_info": {
+                    "type": "boolean"
+                },
+                "can_view_member_email": {
+                    "type": "boolean"
+                },
+                "can_view_member_name": {
+                    "type": "boolean"
+                },
+                "can_view_member_net_id": {
+                    "type": "boolean"
+                },
+                "can_view_member_phone": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "MemberLabel": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "empty for a new label. It is generated by the code",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberNote": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/Creator"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "membership_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "$ref": "#/definitions/Creator"
+                },
+                "user_id": {
+                    "description": "the member",
+                    "type": "string"
+                }
+            }
+        },
+        "MemberNotesPreferences": {
+            "type": "object",
+            "properties": {
+                "retention": {
+                    "description": "delete or retain. Empty means delete",
+                    "type": "string"
+                }
+            }
+        },
+        "MemberProfileField": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "lowercase letters, digits and underscores",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "member_editable": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "description": "the allowed values of a select field",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "text, number, boolean, select or date",
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "admins, members or public",
+                    "type": "string"
+                }
+            }
+        },
+        "MemberRosterEntry": {
+            "type": "object",
+            "properties": {
+                "membership": {
+                    "$ref": "#/definitions/GroupMembership"
                 },
-                "user_id": {
-                    "type": "string"
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberNote"
+                    }
                 }
             }
         },
-        "Event": {
+        "MemberSearch": {
             "type": "object",
             "properties": {
-                "client_id": {
+                "label_ids": {
+                    "description": "member label ids. Matches the members with any of the labels. Admins only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "match": {
+                    "description": "prefix or fuzzy. Prefix if not set",
                     "type": "string"
                 },
-                "creator": {
-                    "$ref": "#/definitions/Creator"
+                "offset": {
+                    "type": "integer"
                 },
-                "date_created": {
+                "profile_values": {
+                    "description": "profile field values by key. A list value matches any of its items",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "query": {
                     "type": "string"
                 },
-                "event_id": {
+                "sort_by": {
+                    "description": "name, date_joined or last_activity. Name if not set",
                     "type": "string"
                 },
-                "group_id": {
+                "sort_order": {
+                    "description": "asc or desc. Asc for the name and desc for the dates if not set",
                     "type": "string"
                 },
-                "to_members": {
-                    "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
+                "statuses": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ToMember"
+                        "type": "string"
                     }
                 }
             }
         },
-        "GetGroupMembershipsResponse": {
+        "MemberSearchResult": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GroupMembership"
+                    }
                 },
-                "title": {
-                    "type": "string"
+                "total": {
+                    "description": "the number of all matching members",
+                    "type": "integer"
                 }
             }
         },
-        "Group": {
+        "MembershipFilter": {
             "type": "object",
             "properties": {
-                "attendance_group": {
-                    "type": "boolean"
-                },
-                "attributes": {
-                    "type": "object",
-                    "additionalProperties": true
+                "external_id": {
+                    "description": "core user external id",
+                    "type": "string"
                 },
-                "authman_enabled": {
-                    "type": "boolean"
+                "group_ids": {
+                    "description": "list of group ids",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "authman_group": {
+                "id": {
+                    "description": "membership id",
                     "type": "string"
                 },
-                "block_new_membership_requests": {
-                    "type": "boolean"
+                "label_ids": {
+                    "description": "member label ids. Matches the members with any of the labels",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "can_join_automatically": {
-                    "type": "boolean"
+                "limit": {
+                    "description": "result limit",
+                    "type": "integer"
                 },
-                "category": {
-                    "description": "one of the enums categories list",
+                "name": {
+                    "description": "member's name",
                     "type": "string"
                 },
-                "client_id": {
+                "net_id": {
+                    "description": "core user net id",
                     "type": "string"
                 },
-                "current_member": {
-                    "description": "this is indicative and it's not required for update APIs",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/GroupMembership"
-                        }
-                    ]
+                "net_ids": {
+                    "description": "core user net ids",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "date_created": {
-                    "type": "string"
+                "offset": {
+                    "description": "result offset",
+                    "type": "integer"
                 },
-                "date_managed_membership_updated": {
-                    "type": "string"
+                "profile_values": {
+                    "description": "profile field values by key. A list value matches any of its items",
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "date_membership_updated": {
-                    "type": "string"
+                "statuses": {
+                    "description": "lest of membership statuses",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "date_updated": {
+                "user_id": {
+                    "description": "core user id",
                     "type": "string"
                 },
-                "description": {
+                "user_ids": {
+                    "description": "core user ids",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "MembershipMultiUpdate": {
+            "type": "object",
+            "properties": {
+                "date_attended": {
+                    "description": "date attended",
                     "type": "string"
                 },
-                "hidden_for_search": {
-                    "type": "boolean"
-                },
-                "id": {
+                "reason": {
+                    "description": "reason",
                     "type": "string"
                 },
-                "image_url": {
+                "status": {
+                    "description": "new status",
                     "type": "string"
                 },
-                "is_abuse": {
-                    "type": "boolean"
-                },
-                "members": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Member"
-                    }
-                },
-                "membership_questions": {
+                "user_ids": {
+                    "description": "list of user ids",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "MembershipTransition": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "empty for the system",
+                    "type": "string"
                 },
-                "only_admins_can_create_polls": {
-                    "type": "boolean"
-                },
-                "privacy": {
-                    "description": "public or private",
+                "actor_name": {
                     "type": "string"
                 },
-                "research_consent_details": {
+                "date": {
                     "type": "string"
                 },
-                "research_consent_statement": {
+                "from_status": {
+                    "description": "empty for a new membership",
                     "type": "string"
                 },
-                "research_description": {
+                "reason": {
                     "type": "string"
                 },
-                "research_group": {
-                    "type": "boolean"
+                "source": {
+                    "description": "request, admin or sync",
+                    "type": "string"
                 },
-                "research_open": {
-                    "type": "boolean"
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "NotificationTemplate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
                 },
-                "research_profile": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "object",
-                        "additionalProperties": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "client_id": {
+                    "type": "string"
                 },
-                "settings": {
-                    "description": "TODO: Remove the pointer once the backward support is not needed any more!",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/GroupSettings"
-                        }
-                    ]
+                "date_created": {
+                    "type": "string"
                 },
-                "stats": {
-                    "$ref": "#/definitions/GroupStats"
+                "date_updated": {
+                    "type": "string"
                 },
-                "sync_end_time": {
+                "group_id": {
+                    "description": "nil means the template applies to all groups of the client",
                     "type": "string"
                 },
-                "sync_start_time": {
+                "id": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "locale": {
+                    "description": "empty means the template applies to all locales",
+                    "type": "string"
                 },
-                "title": {
+                "operation": {
                     "type": "string"
                 },
-                "web_url": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "GroupEventFilter": {
+        "NotificationsPreferences": {
             "type": "object",
             "properties": {
-                "end_time_after": {
-                    "type": "integer"
+                "all_mute": {
+                    "type": "boolean"
                 },
-                "end_time_before": {
-                    "type": "integer"
+                "digest_frequency": {
+                    "description": "daily or weekly email digest of the group activity. Empty means no digest",
+                    "type": "string"
                 },
-                "limit": {
-                    "type": "integer"
+                "events_mute": {
+                    "type": "boolean"
                 },
-                "offset": {
-                    "type": "integer"
+                "invitations_mute": {
+                    "type": "boolean"
                 },
-                "start_time_after": {
-                    "type": "integer"
+                "locale": {
+                    "description": "locale of the notification templates. Empty means the default locale",
+                    "type": "string"
                 },
-                "start_time_after_null_end_time": {
-                    "type": "integer"
+                "override_preferences": {
+                    "type": "boolean"
                 },
-                "start_time_before": {
-                    "type": "integer"
+                "polls_mute": {
+                    "type": "boolean"
                 },
-                "start_time_before_null_end_time": {
-                    "type": "integer"
+                "posts_mute": {
+                    "type": "boolean"
                 }
             }
         },
-        "GroupMembership": {
+        "OutboxNotification": {
             "type": "object",
             "properties": {
-                "client_id": {
+                "app_id": {
                     "type": "string"
                 },
-                "date_attended": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "client_id": {
                     "type": "string"
                 },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "date_created": {
                     "type": "string"
                 },
-                "date_updated": {
+                "date_next_attempt": {
                     "type": "string"
                 },
-                "email": {
+                "date_scheduled": {
                     "type": "string"
                 },
-                "external_id": {
+                "date_sent": {
                     "type": "string"
                 },
-                "group_id": {
+                "date_updated": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "member_answers": {
+                "last_error": {
+                    "type": "string"
+                },
+                "org_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "recipients": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/MemberAnswer"
+                        "$ref": "#/definitions/notifications.Recipient"
                     }
                 },
-                "name": {
+                "status": {
+                    "description": "pending, sent or dead",
                     "type": "string"
                 },
-                "net_id": {
+                "text": {
                     "type": "string"
                 },
-                "notifications_preferences": {
-                    "$ref": "#/definitions/NotificationsPreferences"
-                },
-                "photo_url": {
+                "title": {
                     "type": "string"
                 },
-                "reject_reason": {
+                "topic": {
                     "type": "string"
+                }
+            }
+        },
+        "PostPreferences": {
+            "type": "object",
+            "properties": {
+                "allow_send_post": {
+                    "type": "boolean"
                 },
-                "status": {
-                    "description": "admin, pending, member, rejected",
-                    "type": "string"
+                "allowed_reactions": {
+                    "description": "empty means the reactions allowed by the client config",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "sync_id": {
-                    "description": "ID of sync that last updated this membership",
-                    "type": "string"
+                "can_send_post_reactions": {
+                    "type": "boolean"
                 },
-                "user_id": {
+                "can_send_post_replies": {
+                    "type": "boolean"
+                },
+                "can_send_post_to_admins": {
+                    "type": "boolean"
+                },
+                "can_send_post_to_all": {
+                    "type": "boolean"
+                },
+                "can_send_post_to_specific_members": {
+                    "type": "boolean"
+                },
+                "content_filter_action": {
+                    "description": "reject, hold or mask. Empty means reject",
                     "type": "string"
+                },
+                "max_pinned_posts": {
+                    "description": "0 means DefaultMaxPinnedPosts",
+                    "type": "integer"
+                },
+                "require_post_approval": {
+                    "description": "posts from non-admins wait for a group admin approval",
+                    "type": "boolean"
                 }
             }
         },
-        "GroupSettings": {
+        "PostReaction": {
             "type": "object",
             "properties": {
-                "member_info_preferences": {
-                    "$ref": "#/definitions/MemberInfoPreferences"
+                "reaction": {
+                    "type": "string"
                 },
-                "post_preferences": {
-                    "$ref": "#/definitions/PostPreferences"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "GroupStat": {
+        "PostRecurrence": {
             "type": "object",
             "properties": {
-                "authman_enabled": {
-                    "type": "boolean"
+                "count": {
+                    "description": "max count of occurrences. 0 means until the end date",
+                    "type": "integer"
                 },
-                "privacy": {
+                "date_end": {
                     "type": "string"
                 },
-                "stats": {
-                    "$ref": "#/definitions/GroupStats"
+                "frequency": {
+                    "description": "daily, weekly or monthly",
+                    "type": "string"
                 },
-                "title": {
+                "interval": {
+                    "description": "0 means 1",
+                    "type": "integer"
+                },
+                "rrule": {
+                    "description": "optional RRULE with FREQ, INTERVAL, BYDAY, COUNT and UNTIL parts. It overrides the other fields",
                     "type": "string"
+                },
+                "weekdays": {
+                    "description": "weekly only - 0 (Sunday) to 6 (Saturday). Empty means the weekday of the start date",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "GroupStats": {
+        "PostSeen": {
             "type": "object",
             "properties": {
-                "admins_count": {
-                    "type": "integer"
+                "client_id": {
+                    "type": "string"
                 },
-                "attendance_count": {
-                    "type": "integer"
+                "date_seen": {
+                    "type": "string"
                 },
-                "member_count": {
-                    "type": "integer"
+                "group_id": {
+                    "type": "string"
                 },
-                "pending_count": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "rejected_count": {
-                    "type": "integer"
+                "name": {
+                    "description": "this is constructed by the code according to the group settings",
+                    "type": "string"
                 },
-                "total_count": {
-                    "description": "pending and rejected are excluded",
-                    "type": "integer"
+                "post_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "GroupsFilter": {
+        "PostSeries": {
             "type": "object",
             "properties": {
-                "attributes": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "authman_enabled": {
-                    "type": "boolean"
+                "body": {
+                    "type": "string"
                 },
-                "category": {
-                    "description": "group category",
+                "client_id": {
                     "type": "string"
                 },
-                "exclude_my_groups": {
-                    "description": "Exclude My groups",
-                    "type": "boolean"
+                "date_created": {
+                    "type": "string"
                 },
-                "hidden": {
-                    "description": "Filter by hidden flag. Values: true (show only hidden), false (show only not hidden), missing - don't do any filtering on this field.",
-                    "type": "boolean"
+                "date_last_occurrence": {
+                    "type": "string"
                 },
-                "ids": {
-                    "description": "membership id",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "date_next_occurrence": {
+                    "type": "string"
                 },
-                "include_hidden": {
-                    "description": "Include hidden groups",
-                    "type": "boolean"
+                "date_start": {
+                    "type": "string"
                 },
-                "limit": {
-                    "description": "result limit",
-                    "type": "integer"
+                "date_updated": {
+                    "type": "string"
                 },
-                "member_external_id": {
-                    "description": "member user external id",
+                "group_id": {
                     "type": "string"
                 },
-                "member_id": {
-                    "description": "member id",
+                "id": {
                     "type": "string"
                 },
-                "member_user_id": {
-                    "description": "member user id",
+                "image_url": {
                     "type": "string"
                 },
-                "offset": {
-                    "description": "result offset",
+                "member": {
+                    "$ref": "#/definitions/Creator"
+                },
+                "occurrences_count": {
                     "type": "integer"
                 },
-                "order": {
-                    "description": "order by category \u0026 name (asc desc)",
+                "private": {
+                    "type": "boolean"
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/PostRecurrence"
+                },
+                "status": {
+                    "description": "active, paused, canceled or completed",
                     "type": "string"
                 },
-                "privacy": {
-                    "description": "group privacy",
+                "subject": {
                     "type": "string"
                 },
-                "research_answers": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "object",
-                        "additionalProperties": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                "time_zone": {
+                    "description": "IANA time zone of the occurrences. Empty means UTC",
+                    "type": "string"
+                },
+                "to_label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
-                "research_group": {
-                    "type": "boolean"
+                "to_members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ToMember"
+                    }
                 },
-                "research_open": {
+                "use_as_notification": {
                     "type": "boolean"
-                },
-                "tags": {
-                    "description": "group tags",
+                }
+            }
+        },
+        "ReactionsConfig": {
+            "type": "object",
+            "properties": {
+                "allowed_reactions": {
+                    "description": "empty means any reaction is allowed",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "title": {
-                    "description": "group title",
+                "client_id": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "GroupsStats": {
+        "Sender": {
             "type": "object",
             "properties": {
-                "groups_count": {
-                    "type": "integer"
+                "type": {
+                    "description": "user or system",
+                    "type": "string"
                 },
-                "groups_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/GroupStat"
-                    }
+                "user": {
+                    "$ref": "#/definitions/UserRef"
                 }
             }
         },
-        "ManagedGroupConfig": {
+        "ShortMemberRecord": {
             "type": "object",
             "properties": {
-                "admin_uins": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "email": {
+                    "type": "string"
                 },
-                "authman_stems": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "external_id": {
+                    "type": "string"
                 },
-                "client_id": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "net_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, member, admin, rejected",
                     "type": "string"
                 },
-                "date_created": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ToMember": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "date_updated": {
+                "external_id": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "type": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "Member": {
+        "UserRef": {
             "type": "object",
             "properties": {
-                "date_attended": {
+                "name": {
                     "type": "string"
                 },
-                "date_created": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
                 },
-                "date_updated": {
+                "client_id": {
                     "type": "string"
                 },
-                "email": {
+                "date_created": {
                     "type": "string"
                 },
-                "external_id": {
+                "date_delivered": {
                     "type": "string"
                 },
-                "id": {
+                "date_next_attempt": {
                     "type": "string"
                 },
-                "member_answers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/MemberAnswer"
-                    }
-                },
-                "name": {
+                "date_updated": {
                     "type": "string"
                 },
-                "net_id": {
+                "event_id": {
                     "type": "string"
                 },
-                "photo_url": {
+                "event_type": {
                     "type": "string"
                 },
-                "reject_reason": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "pending, member, admin, rejected",
+                "last_error": {
                     "type": "string"
                 },
-                "user_id": {
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "payload": {
                     "type": "string"
-                }
-            }
-        },
-        "MemberAnswer": {
-            "type": "object",
-            "properties": {
-                "answer": {
+                },
+                "status": {
+                    "description": "pending, delivered or dead",
                     "type": "string"
                 },
-                "question": {
+                "subscription_id": {
                     "type": "string"
                 }
             }
         },
-        "MemberInfoPreferences": {
+        "WebhookSubscription": {
             "type": "object",
             "properties": {
-                "allow_member_info": {
+                "active": {
                     "type": "boolean"
                 },
-                "can_view_member_email": {
-                    "type": "boolean"
+                "client_id": {
+                    "type": "string"
                 },
-                "can_view_member_name": {
-                    "type": "boolean"
+                "created_by": {
+                    "type": "string"
                 },
-                "can_view_member_net_id": {
-                    "type": "boolean"
+                "date_created": {
+                    "type": "string"
                 },
-                "can_view_member_phone": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "MembershipFilter": {
-            "type": "object",
-            "properties": {
-                "external_id": {
-                    "description": "core user external id",
+                "date_updated": {
                     "type": "string"
                 },
-                "group_ids": {
-                    "description": "list of group ids",
+                "event_types": {
+                    "description": "empty means all event types",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
                 "id": {
-                    "description": "membership id",
                     "type": "string"
                 },
-                "limit": {
-                    "description": "result limit",
-                    "type": "integer"
-                },
                 "name": {
-                    "description": "member's name",
                     "type": "string"
                 },
-                "net_id": {
-                    "description": "core user net id",
+                "secret": {
+                    "description": "returned only when the subscription is created",
                     "type": "string"
                 },
-                "net_ids": {
-                    "description": "core user net ids",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "offset": {
-                    "description": "result offset",
-                    "type": "integer"
-                },
-                "statuses": {
-                    "description": "lest of membership statuses",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "user_id": {
-                    "description": "core user id",
+                "url": {
                     "type": "string"
-                },
-                "user_ids": {
-                    "description": "core user ids",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "MembershipMultiUpdate": {
+        "attendanceUsersRequestBody": {
             "type": "object",
+            "required": [
+                "user_ids"
+            ],
             "properties": {
-                "date_attended": {
-                    "description": "date attended",
-                    "type": "string"
-                },
-                "reason": {
-                    "description": "reason",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "new status",
-                    "type": "string"
-                },
                 "user_ids": {
-                    "description": "list of user ids",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -4647,116 +8996,154 @@ const docTemplate = `{
                 }
             }
         },
-        "NotificationsPreferences": {
+        "createCrossPostRequestBody": {
             "type": "object",
             "properties": {
-                "all_mute": {
-                    "type": "boolean"
+                "body": {
+                    "type": "string"
                 },
-                "events_mute": {
-                    "type": "boolean"
+                "body_format": {
+                    "description": "plain or markdown. Empty means plain",
+                    "type": "string"
                 },
-                "invitations_mute": {
-                    "type": "boolean"
+                "body_html": {
+                    "description": "the body rendered as safe HTML. This is constructed by the code",
+                    "type": "string"
                 },
-                "override_preferences": {
-                    "type": "boolean"
+                "client_id": {
+                    "type": "string"
                 },
-                "polls_mute": {
-                    "type": "boolean"
+                "date_announcement_expires": {
+                    "type": "string"
                 },
-                "posts_mute": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "PostPreferences": {
-            "type": "object",
-            "properties": {
-                "allow_send_post": {
-                    "type": "boolean"
+                "date_created": {
+                    "type": "string"
                 },
-                "can_send_post_reactions": {
-                    "type": "boolean"
+                "date_expired": {
+                    "description": "the date when the post and its replies have been hidden",
+                    "type": "string"
                 },
-                "can_send_post_replies": {
-                    "type": "boolean"
+                "date_expires": {
+                    "description": "top level posts only. The post and its replies are hidden after this date",
+                    "type": "string"
                 },
-                "can_send_post_to_admins": {
-                    "type": "boolean"
+                "date_notified": {
+                    "type": "string"
                 },
-                "can_send_post_to_all": {
-                    "type": "boolean"
+                "date_pinned": {
+                    "type": "string"
                 },
-                "can_send_post_to_specific_members": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "Sender": {
-            "type": "object",
-            "properties": {
-                "type": {
-                    "description": "user or system",
+                "date_reviewed": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/UserRef"
-                }
-            }
-        },
-        "ShortMemberRecord": {
-            "type": "object",
-            "properties": {
-                "email": {
+                "date_scheduled": {
                     "type": "string"
                 },
-                "external_id": {
+                "date_updated": {
+                    "type": "string"
+                },
+                "group_id": {
                     "type": "string"
                 },
+                "group_ids": {
+                    "description": "the other groups which get a copy of the post",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "image_url": {
                     "type": "string"
                 },
-                "net_id": {
+                "is_abuse": {
+                    "type": "boolean"
+                },
+                "is_announcement": {
+                    "type": "boolean"
+                },
+                "member": {
+                    "$ref": "#/definitions/Creator"
+                },
+                "my_reactions": {
+                    "description": "the reactions of the current user. This is constructed by the code",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "origin_id": {
+                    "description": "the cross-posted post from which this copy has been created. The copies follow the edits and the deletion of the origin",
                     "type": "string"
                 },
-                "status": {
-                    "description": "pending, member, admin, rejected",
+                "parent_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "pinned": {
+                    "type": "boolean"
+                },
+                "pinned_by": {
                     "type": "string"
-                }
-            }
-        },
-        "ToMember": {
-            "type": "object",
-            "properties": {
-                "email": {
+                },
+                "private": {
+                    "type": "boolean"
+                },
+                "reaction_counts": {
+                    "description": "denormalized count of the users for every reaction",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reject_reason": {
                     "type": "string"
                 },
-                "external_id": {
+                "replies": {
+                    "description": "This is constructed by the code (ParentID)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Post"
+                    }
+                },
+                "replies_count": {
+                    "description": "count of the direct replies",
+                    "type": "integer"
+                },
+                "reviewed_by": {
                     "type": "string"
                 },
-                "name": {
+                "series_id": {
+                    "description": "the recurring post series which has published the post",
                     "type": "string"
                 },
-                "user_id": {
+                "status": {
+                    "description": "empty means published without moderation; pending_review, approved or rejected",
                     "type": "string"
-                }
-            }
-        },
-        "UserRef": {
-            "type": "object",
-            "properties": {
-                "name": {
+                },
+                "subject": {
                     "type": "string"
                 },
-                "user_id": {
+                "to_label_ids": {
+                    "description": "the member labels the post is addressed to in addition to to_members. Resolved whenever the visibility is checked",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to_members": {
+                    "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ToMember"
+                    }
+                },
+                "top_parent_id": {
                     "type": "string"
+                },
+                "use_as_notification": {
+                    "type": "boolean"
                 }
             }
         },
@@ -5157,6 +9544,13 @@ const docTemplate = `{
                 "event_id": {
                     "type": "string"
                 },
+                "to_label_ids": {
+                    "description": "the member labels the event is addressed to in addition to to_members",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "to_members": {
                     "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
                     "type": "array",
@@ -5178,6 +9572,13 @@ const docTemplate = `{
                 "event_id": {
                     "type": "string"
                 },
+                "to_label_ids": {
+                    "description": "the member labels the event is addressed to in addition to to_members",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "to_members": {
                     "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
                     "type": "array",
@@ -5187,6 +9588,25 @@ const docTemplate = `{
                 }
             }
         },
+        "markGroupAsReadRequestBody": {
+            "type": "object",
+            "properties": {
+                "date_last_read": {
+                    "type": "string"
+                }
+            }
+        },
+        "memberNoteRequestBody": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
         "membershipApprovalRequest": {
             "type": "object",
             "required": [
@@ -5201,6 +9621,73 @@ const docTemplate = `{
                 }
             }
         },
+        "membershipLabelsRequestBody": {
+            "type": "object",
+            "properties": {
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ChangeBatch": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChangeEvent"
+                    }
+                },
+                "next_token": {
+                    "description": "pass it as \"since\" to continue reading the feed",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChangeEvent": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "the current redacted document. Empty for the deleted entities",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "date": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "description": "group, membership or post",
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "the token from which the feed continues after this change",
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "created, updated or deleted",
+                    "type": "string"
+                },
+                "updated_fields": {
+                    "description": "the changed fields for the partial updates",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.MembershipStatus": {
             "type": "object",
             "properties": {
@@ -5219,15 +9706,40 @@ const docTemplate = `{
                 "body": {
                     "type": "string"
                 },
+                "body_format": {
+                    "description": "plain or markdown. Empty means plain",
+                    "type": "string"
+                },
+                "body_html": {
+                    "description": "the body rendered as safe HTML. This is constructed by the code",
+                    "type": "string"
+                },
                 "client_id": {
                     "type": "string"
                 },
+                "date_announcement_expires": {
+                    "type": "string"
+                },
                 "date_created": {
                     "type": "string"
                 },
+                "date_expired": {
+                    "description": "the date when the post and its replies have been hidden",
+                    "type": "string"
+                },
+                "date_expires": {
+                    "description": "top level posts only. The post and its replies are hidden after this date",
+                    "type": "string"
+                },
                 "date_notified": {
                     "type": "string"
                 },
+                "date_pinned": {
+                    "type": "string"
+                },
+                "date_reviewed": {
+                    "type": "string"
+                },
                 "date_scheduled": {
                     "type": "string"
                 },
@@ -5246,24 +9758,45 @@ const docTemplate = `{
                 "is_abuse": {
                     "type": "boolean"
                 },
+                "is_announcement": {
+                    "type": "boolean"
+                },
                 "member": {
                     "$ref": "#/definitions/Creator"
                 },
+                "my_reactions": {
+                    "description": "the reactions of the current user. This is constructed by the code",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "origin_id": {
+                    "description": "the cross-posted post from which this copy has been created. The copies follow the edits and the deletion of the origin",
+                    "type": "string"
+                },
                 "parent_id": {
                     "type": "string"
                 },
+                "pinned": {
+                    "type": "boolean"
+                },
+                "pinned_by": {
+                    "type": "string"
+                },
                 "private": {
                     "type": "boolean"
                 },
-                "reactions": {
+                "reaction_counts": {
+                    "description": "denormalized count of the users for every reaction",
                     "type": "object",
                     "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        }
+                        "type": "integer"
                     }
                 },
+                "reject_reason": {
+                    "type": "string"
+                },
                 "replies": {
                     "description": "This is constructed by the code (ParentID)",
                     "type": "array",
@@ -5271,9 +9804,31 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.Post"
                     }
                 },
+                "replies_count": {
+                    "description": "count of the direct replies",
+                    "type": "integer"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "description": "the recurring post series which has published the post",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "empty means published without moderation; pending_review, approved or rejected",
+                    "type": "string"
+                },
                 "subject": {
                     "type": "string"
                 },
+                "to_label_ids": {
+                    "description": "the member labels the post is addressed to in addition to to_members. Resolved whenever the visibility is checked",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "to_members": {
                     "description": "nil or empty means everyone; non-empty means visible to those user ids and admins",
                     "type": "array",
@@ -5315,6 +9870,64 @@ const docTemplate = `{
                 }
             }
         },
+        "notifications.Recipient": {
+            "type": "object",
+            "properties": {
+                "mute": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "openAttendanceCheckInWindowRequestBody": {
+            "type": "object",
+            "required": [
+                "date_closes"
+            ],
+            "properties": {
+                "auto_add_non_members": {
+                    "type": "boolean"
+                },
+                "code_period": {
+                    "description": "in seconds, 30 if not set",
+                    "type": "integer"
+                },
+                "date_closes": {
+                    "type": "string"
+                },
+                "date_opens": {
+                    "description": "now if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "pinGroupPostRequestBody": {
+            "type": "object",
+            "properties": {
+                "date_announcement_expires": {
+                    "type": "string"
+                },
+                "is_announcement": {
+                    "type": "boolean"
+                },
+                "pinned": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "reactToGroupPostRequestBody": {
+            "type": "object",
+            "properties": {
+                "reaction": {
+                    "type": "string"
+                }
+            }
+        },
         "reportAbuseGroupPostRequestBody": {
             "type": "object",
             "properties": {
@@ -5421,6 +10034,15 @@ const docTemplate = `{
                 },
                 "member_user_id": {
                     "type": "string"
+                },
+                "reaction_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reactions_count": {
+                    "type": "integer"
                 }
             }
         },
@@ -5452,6 +10074,12 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "to_label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "to_members": {
                     "type": "array",
                     "items": {
@@ -5467,6 +10095,12 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "to_label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "to_members": {
                     "type": "array",
                     "items": {
@@ -5498,6 +10132,32 @@ const docTemplate = `{
                 }
             }
         },
+        "reviewGroupPostRequestBody": {
+            "type": "object",
+            "properties": {
+                "approve": {
+                    "type": "boolean"
+                },
+                "reject_reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "selfCheckInAttendanceRequestBody": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "description": "optional, it is set when the code is scanned from a QR code",
+                    "type": "string"
+                }
+            }
+        },
         "sendGroupNotificationRequestBody": {
             "type": "object",
             "required": [
@@ -5538,6 +10198,20 @@ const docTemplate = `{
                 }
             }
         },
+        "updateAbuseReportRequestBody": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "resolution_notes": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "updateGroupRequest": {
             "type": "object",
             "required": [
@@ -5656,6 +10330,22 @@ const docTemplate = `{
                     ]
                 }
             }
+        },
+        "updatePostSeriesStatusRequestBody": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "paused",
+                        "canceled"
+                    ]
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -27,6 +27,13 @@ import (
 	"github.com/rokwire/core-auth-library-go/v3/authservice"
 )
 
+const (
+	// DefaultPriority is the priority of the regular group notifications
+	DefaultPriority = 10
+	// AnnouncementPriority is the priority of the group announcement notifications
+	AnnouncementPriority = 50
+)
+
 // Adapter implements the Notifications interface
 type Adapter struct {
 	baseURL               string
@@ -35,9 +42,9 @@ type Adapter struct {
 
 // Recipient struct
 type Recipient struct {
-	UserID string `json:"user_id"`
-	Name   string `json:"name"`
-	Mute   bool   `json:"mute"`
+	UserID string `json:"user_id" bson:"user_id"`
+	Name   string `json:"name" bson:"name"`
+	Mute   bool   `json:"mute" bson:"mute"`
 }
 
 // NewNotificationsAdapter creates a new Notifications BB adapter instance
@@ -52,10 +59,15 @@ func NewNotificationsAdapter(baseURL string, serviceAccountManager *authservice.
 
 // SendNotification sends notification to a user
 func (na *Adapter) SendNotification(recipients []Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time) error {
-	return na.sendNotification(recipients, topic, title, text, data, appID, orgID, dateScheduled)
+	return na.sendNotification(recipients, topic, title, text, data, appID, orgID, dateScheduled, DefaultPriority)
+}
+
+// SendNotificationWithPriority sends notification to a user with the desired priority
+func (na *Adapter) SendNotificationWithPriority(recipients []Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time, priority int) error {
+	return na.sendNotification(recipients, topic, title, text, data, appID, orgID, dateScheduled, priority)
 }
 
-func (na *Adapter) sendNotification(recipients []Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time) error {
+func (na *Adapter) sendNotification(recipients []Recipient, topic *string, title string, text string, data map[string]string, appID string, orgID string, dateScheduled *time.Time, priority int) error {
 	if len(recipients) > 0 {
 		url := fmt.Sprintf("%s/api/bbs/message", na.baseURL)
 
@@ -63,7 +75,7 @@ func (na *Adapter) sendNotification(recipients []Recipient, topic *string, title
 		message := map[string]interface{}{
 			"org_id":     orgID,
 			"app_id":     appID,
-			"priority":   10,
+			"priority":   priority,
 			"recipients": recipients,
 			"topic":      topic,
 			"subject":    title,
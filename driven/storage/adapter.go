@@ -47,6 +47,9 @@ type Adapter struct {
 
 	cachedManagedGroupConfigs *syncmap.Map
 	managedGroupConfigsLock   *sync.RWMutex
+
+	cachedContentFilters *syncmap.Map
+	contentFiltersLock   *sync.RWMutex
 }
 
 // Start starts the storage
@@ -70,6 +73,11 @@ func (sa *Adapter) Start() error {
 		return errors.New("error caching managed group configs")
 	}
 
+	err = sa.cacheContentFilters()
+	if err != nil {
+		return errors.New("error caching content filters")
+	}
+
 	return err
 }
 
@@ -355,11 +363,12 @@ func (sa *Adapter) CreateGroup(context TransactionContext, clientID string, curr
 				membership.GroupID = insertedID
 				membership.DateCreated = now
 				membership.ClientID = clientID
+				membership.SetSearchFields()
 				castedMemberships = append(castedMemberships, membership)
 			}
 		}
 		if current != nil && !contaignCurrentUser {
-			castedMemberships = append(castedMemberships, model.GroupMembership{
+			creatorMembership := model.GroupMembership{
 				ID:          uuid.NewString(),
 				GroupID:     insertedID,
 				UserID:      current.ID,
@@ -370,7 +379,13 @@ func (sa *Adapter) CreateGroup(context TransactionContext, clientID string, curr
 				Name:        current.Name,
 				Status:      "admin",
 				DateCreated: now,
-			})
+			}
+			err = creatorMembership.RecordCreation(model.MembershipTransitionSourceAdmin, current, "")
+			if err != nil {
+				return err
+			}
+			creatorMembership.SetSearchFields()
+			castedMemberships = append(castedMemberships, creatorMembership)
 		}
 
 		if len(castedMemberships) > 0 {
@@ -430,6 +445,8 @@ func (sa *Adapter) updateGroup(context TransactionContext, clientID string, curr
 			primitive.E{Key: "privacy", Value: group.Privacy},
 			primitive.E{Key: "hidden_for_search", Value: group.HiddenForSearch},
 			primitive.E{Key: "description", Value: group.Description},
+			primitive.E{Key: "description_format", Value: group.DescriptionFormat},
+			primitive.E{Key: "description_html", Value: group.DescriptionHTML},
 			primitive.E{Key: "image_url", Value: group.ImageURL},
 			primitive.E{Key: "web_url", Value: group.WebURL},
 			primitive.E{Key: "membership_questions", Value: group.MembershipQuestions},
@@ -510,6 +527,7 @@ func (sa *Adapter) updateGroup(context TransactionContext, clientID string, curr
 
 		if len(memberships) > 0 {
 			for _, membership := range memberships {
+				membership.SetSearchFields()
 				if membership.ID == "" {
 					membership.ID = uuid.NewString()
 					membership.DateCreated = time.Now()
@@ -607,7 +625,16 @@ func (sa *Adapter) DeleteGroup(ctx TransactionContext, clientID string, id strin
 			return err
 		}
 
-		// 4. delete the group
+		// 4. delete the admin notes on the members
+		_, err = sa.db.memberNotes.DeleteManyWithContext(context, bson.D{
+			primitive.E{Key: "group_id", Value: id},
+			primitive.E{Key: "client_id", Value: clientID},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		// 5. delete the group
 		_, err = sa.db.groups.DeleteOneWithContext(context, bson.D{
 			primitive.E{Key: "_id", Value: id},
 			primitive.E{Key: "client_id", Value: clientID},
@@ -991,12 +1018,12 @@ func (sa *Adapter) FindEvents(clientID string, current *model.User, groupID stri
 		primitive.E{Key: "client_id", Value: clientID},
 	}
 	if filterByToMembers && current != nil {
-		filter = append(filter, primitive.E{Key: "$or", Value: []primitive.M{
-			{"to_members": primitive.Null{}},
-			{"to_members": primitive.M{"$exists": true, "$size": 0}},
-			{"to_members.user_id": current.ID},
-			{"member.user_id": current.ID},
-		}})
+		labelIDs, err := sa.findMemberLabelIDs(nil, clientID, groupID, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		audienceFilter := append(postAudienceFilter(&current.ID, labelIDs), primitive.M{"member.user_id": current.ID})
+		filter = append(filter, primitive.E{Key: "$or", Value: audienceFilter})
 	}
 
 	var result []model.Event
@@ -1005,13 +1032,14 @@ func (sa *Adapter) FindEvents(clientID string, current *model.User, groupID stri
 }
 
 // CreateEvent creates a group event
-func (sa *Adapter) CreateEvent(context TransactionContext, clientID string, eventID string, groupID string, toMemberList []model.ToMember, creator *model.Creator) (*model.Event, error) {
+func (sa *Adapter) CreateEvent(context TransactionContext, clientID string, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string, creator *model.Creator) (*model.Event, error) {
 	event := model.Event{
 		ClientID:      clientID,
 		EventID:       eventID,
 		GroupID:       groupID,
 		DateCreated:   time.Now().UTC(),
 		ToMembersList: toMemberList,
+		ToLabelIDs:    toLabelIDs,
 		Creator:       creator,
 	}
 
@@ -1035,7 +1063,7 @@ func (sa *Adapter) CreateEvent(context TransactionContext, clientID string, even
 }
 
 // UpdateEvent updates a group event
-func (sa *Adapter) UpdateEvent(clientID string, eventID string, groupID string, toMemberList []model.ToMember) error {
+func (sa *Adapter) UpdateEvent(clientID string, eventID string, groupID string, toMemberList []model.ToMember, toLabelIDs []string) error {
 	return sa.PerformTransaction(func(context TransactionContext) error {
 		filter := bson.D{
 			primitive.E{Key: "event_id", Value: eventID},
@@ -1046,6 +1074,7 @@ func (sa *Adapter) UpdateEvent(clientID string, eventID string, groupID string,
 			primitive.E{Key: "$set", Value: bson.D{
 				primitive.E{Key: "date_updated", Value: time.Now()},
 				primitive.E{Key: "to_members", Value: toMemberList},
+				primitive.E{Key: "to_label_ids", Value: toLabelIDs},
 			}},
 		}
 		_, err := sa.db.events.UpdateOneWithContext(context, filter, change, nil)
@@ -1101,6 +1130,11 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 			return errGr
 		}
 
+		var currentMemberLabelIDs []string
+		if group.CurrentMember != nil {
+			currentMemberLabelIDs = group.CurrentMember.LabelIDs
+		}
+
 		mongoFilter := bson.D{
 			primitive.E{Key: "client_id", Value: clientID},
 			primitive.E{Key: "group_id", Value: filter.GroupID},
@@ -1110,7 +1144,10 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 			if *filter.PostType == "message" {
 				mongoFilter = append(mongoFilter, bson.E{Key: "$or", Value: []bson.M{
 					{"$and": []bson.M{
-						{"to_members": bson.M{"$ne": primitive.Null{}}},
+						{"$or": []bson.M{
+							{"to_members": bson.M{"$ne": primitive.Null{}}},
+							{"to_label_ids.0": bson.M{"$exists": true}},
+						}},
 						{"parent_id": primitive.Null{}},
 					}},
 					{"parent_id": bson.M{"$ne": primitive.Null{}}},
@@ -1119,6 +1156,7 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 				mongoFilter = append(mongoFilter, bson.E{Key: "$or", Value: []bson.M{
 					{"$and": []bson.M{
 						{"to_members": primitive.Null{}},
+						{"to_label_ids.0": bson.M{"$exists": false}},
 						{"parent_id": primitive.Null{}},
 					}},
 					{"parent_id": bson.M{"$ne": primitive.Null{}}},
@@ -1136,15 +1174,9 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 		}
 
 		if filterByToMembers {
-			innerFilter := []primitive.M{
-				{"to_members": primitive.Null{}},
-				{"to_members": primitive.M{"$exists": true, "$size": 0}},
-			}
+			innerFilter := postAudienceFilter(userID, currentMemberLabelIDs)
 			if current != nil {
-				innerFilter = append(innerFilter, []primitive.M{
-					{"to_members.user_id": current.ID},
-					{"member.user_id": current.ID},
-				}...)
+				innerFilter = append(innerFilter, primitive.M{"member.user_id": current.ID})
 			}
 			mongoFilter = append(mongoFilter, primitive.E{Key: "$or", Value: innerFilter})
 		}
@@ -1153,6 +1185,21 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 			mongoFilter = append(mongoFilter, primitive.E{Key: "private", Value: *filterPrivatePostsValue})
 		}
 
+		// Expired posts are available only within the expired posts view of the group admins
+		mongoFilter = append(mongoFilter, primitive.E{Key: "date_expired", Value: nil})
+
+		// Posts waiting for review or rejected are visible only for the creator and the group admins
+		isAdmin := group.CurrentMember != nil && group.CurrentMember.IsAdmin()
+		if !isAdmin {
+			moderationFilter := []bson.M{
+				{"status": bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
+			}
+			if current != nil {
+				moderationFilter = append(moderationFilter, bson.M{"member.user_id": current.ID})
+			}
+			mongoFilter = append(mongoFilter, primitive.E{Key: "$and", Value: []bson.M{{"$or": moderationFilter}}})
+		}
+
 		paging := false
 		findOptions := options.Find()
 		if filter.Order != nil && "desc" == *filter.Order {
@@ -1179,12 +1226,26 @@ func (sa *Adapter) FindPosts(clientID string, current *model.User, filter model.
 			return err
 		}
 
-		if paging && len(list) > 0 {
+		if paging && len(list) > 0 && filter.RepliesLimit != nil {
+			// Only the latest direct replies are loaded. The rest are available through the replies pagination
+			for _, post := range list {
+				replies, err := sa.FindReplies(ctx, clientID, current.ID, isAdmin, filter.GroupID, post.ID, nil, filter.RepliesLimit, 1, nil)
+				if err != nil {
+					return err
+				}
+				if filter.Order == nil || "desc" != *filter.Order {
+					for i, j := 0, len(replies)-1; i < j; i, j = i+1, j-1 {
+						replies[i], replies[j] = replies[j], replies[i]
+					}
+				}
+				list = append(list, replies...)
+			}
+		} else if paging && len(list) > 0 {
 			for _, post := range list {
 				childPosts, err := sa.FindPostsByTopParentID(ctx, clientID, current, filter.GroupID, post.ID, true, filter.Order)
 				if err == nil && childPosts != nil {
 					for _, childPost := range childPosts {
-						if childPost.UserCanSeePost(current.ID) {
+						if childPost.UserCanSeePost(current.ID, currentMemberLabelIDs) && (isAdmin || childPost.IsPublished() || childPost.Creator.UserID == current.ID) {
 							list = append(list, childPost)
 						}
 					}
@@ -1285,15 +1346,17 @@ func (sa *Adapter) findPostWithContext(context TransactionContext, clientID stri
 	}
 
 	if filterByToMembers {
-		innerFilter := []primitive.M{
-			{"to_members": primitive.Null{}},
-			{"to_members": primitive.M{"$exists": true, "$size": 0}},
+		var labelIDs []string
+		if userID != nil {
+			var err error
+			labelIDs, err = sa.findMemberLabelIDs(context, clientID, groupID, *userID)
+			if err != nil {
+				return nil, err
+			}
 		}
+		innerFilter := postAudienceFilter(userID, labelIDs)
 		if userID != nil {
-			innerFilter = append(innerFilter, []primitive.M{
-				{"to_members.user_id": *userID},
-				{"member.user_id": *userID},
-			}...)
+			innerFilter = append(innerFilter, primitive.M{"member.user_id": *userID})
 		}
 		filter = append(filter, primitive.E{Key: "$or", Value: innerFilter})
 	}
@@ -1426,7 +1489,7 @@ func (sa *Adapter) FindPostsByTopParentID(context TransactionContext, clientID s
 }
 
 // CreatePost Created a post
-func (sa *Adapter) CreatePost(clientID string, current *model.User, post *model.Post) (*model.Post, error) {
+func (sa *Adapter) CreatePost(context TransactionContext, clientID string, current *model.User, post *model.Post) (*model.Post, error) {
 
 	if current != nil && post != nil {
 		membership, err := sa.FindGroupMembership(clientID, post.GroupID, current.ID)
@@ -1443,6 +1506,7 @@ func (sa *Adapter) CreatePost(clientID string, current *model.User, post *model.
 		if post.Replies != nil { // This is constructed only for GET all for group
 			post.Replies = nil
 		}
+		post.RepliesCount = 0
 
 		if post.ParentID != nil {
 			topPost, _ := sa.FindTopPostByParentID(clientID, current, post.GroupID, *post.ParentID, false)
@@ -1466,19 +1530,31 @@ func (sa *Adapter) CreatePost(clientID string, current *model.User, post *model.
 			Name:   current.Name,
 		}
 
-		err = sa.PerformTransaction(func(context TransactionContext) error {
+		wrapper := func(context TransactionContext) error {
 			_, err := sa.db.posts.InsertOneWithContext(context, post)
 			if err != nil {
 				return err
 			}
 
+			if post.ParentID != nil {
+				err = sa.incrementPostRepliesCount(context, clientID, *post.ParentID, 1)
+				if err != nil {
+					return err
+				}
+			}
+
 			err = sa.UpdateGroupStats(context, clientID, post.GroupID, true, false, false, false)
 			if err != nil {
 				return err
 			}
 
 			return nil
-		})
+		}
+		if context != nil {
+			err = wrapper(context)
+		} else {
+			err = sa.PerformTransaction(wrapper)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1489,9 +1565,9 @@ func (sa *Adapter) CreatePost(clientID string, current *model.User, post *model.
 }
 
 // UpdatePost Updates a post
-func (sa *Adapter) UpdatePost(clientID string, userID string, post *model.Post) (*model.Post, error) {
+func (sa *Adapter) UpdatePost(context TransactionContext, clientID string, userID string, post *model.Post) (*model.Post, error) {
 	if post != nil {
-		originalPost, _ := sa.FindPost(nil, clientID, &userID, post.GroupID, post.ID, true, true)
+		originalPost, _ := sa.FindPost(context, clientID, &userID, post.GroupID, post.ID, true, true)
 		if originalPost == nil {
 			return nil, fmt.Errorf("unable to find post with id (%s) ", post.ID)
 		}
@@ -1520,6 +1596,8 @@ func (sa *Adapter) UpdatePost(clientID string, userID string, post *model.Post)
 			primitive.E{Key: "$set", Value: bson.D{
 				primitive.E{Key: "subject", Value: post.Subject},
 				primitive.E{Key: "body", Value: post.Body},
+				primitive.E{Key: "body_format", Value: post.BodyFormat},
+				primitive.E{Key: "body_html", Value: post.BodyHTML},
 				primitive.E{Key: "private", Value: post.Private},
 				primitive.E{Key: "use_as_notification", Value: post.UseAsNotification},
 				primitive.E{Key: "is_abuse", Value: post.IsAbuse},
@@ -1527,18 +1605,27 @@ func (sa *Adapter) UpdatePost(clientID string, userID string, post *model.Post)
 				primitive.E{Key: "date_updated", Value: post.DateUpdated},
 				primitive.E{Key: "date_scheduled", Value: post.DateScheduled},
 				primitive.E{Key: "to_members", Value: post.ToMembersList},
+				primitive.E{Key: "to_label_ids", Value: post.ToLabelIDs},
+				primitive.E{Key: "date_expires", Value: post.DateExpires},
 			},
 			},
 		}
 
-		err := sa.PerformTransaction(func(context TransactionContext) error {
-			_, err := sa.db.posts.UpdateOneWithContext(context, filter, update, nil)
+		wrapper := func(ctx TransactionContext) error {
+			_, err := sa.db.posts.UpdateOneWithContext(ctx, filter, update, nil)
 			if err != nil {
 				return err
 			}
 
-			return sa.UpdateGroupStats(context, clientID, post.GroupID, true, false, false, false)
-		})
+			return sa.UpdateGroupStats(ctx, clientID, post.GroupID, true, false, false, false)
+		}
+
+		var err error
+		if context != nil {
+			err = wrapper(context)
+		} else {
+			err = sa.PerformTransaction(wrapper)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1571,7 +1658,7 @@ func (sa *Adapter) ReportGroupAsAbuse(clientID string, userID string, group *mod
 }
 
 // ReportPostAsAbuse Report post as abuse
-func (sa *Adapter) ReportPostAsAbuse(clientID string, userID string, group *model.Group, post *model.Post) error {
+func (sa *Adapter) ReportPostAsAbuse(context TransactionContext, clientID string, userID string, group *model.Group, post *model.Post) error {
 	if post != nil {
 		filter := bson.D{primitive.E{Key: "client_id", Value: clientID}, primitive.E{Key: "_id", Value: post.ID}}
 
@@ -1582,7 +1669,7 @@ func (sa *Adapter) ReportPostAsAbuse(clientID string, userID string, group *mode
 			},
 			},
 		}
-		_, err := sa.db.posts.UpdateOne(filter, update, nil)
+		_, err := sa.db.posts.UpdateOneWithContext(context, filter, update, nil)
 
 		return err
 	}
@@ -1591,16 +1678,26 @@ func (sa *Adapter) ReportPostAsAbuse(clientID string, userID string, group *mode
 
 // ReactToPost React to a post
 func (sa *Adapter) ReactToPost(context TransactionContext, userID string, postID string, reaction string, on bool) error {
-	filter := bson.D{primitive.E{Key: "_id", Value: postID}}
+	// match only if the reaction really changes so that the counts stay in sync with the reacting users
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: postID},
+		primitive.E{Key: "reactions." + reaction, Value: userID},
+	}
 
 	updateOperation := "$pull"
+	countDelta := -1
 	if on {
+		filter[1] = primitive.E{Key: "reactions." + reaction, Value: bson.M{"$ne": userID}}
 		updateOperation = "$push"
+		countDelta = 1
 	}
 	update := bson.D{
 		primitive.E{Key: updateOperation, Value: bson.D{
 			primitive.E{Key: "reactions." + reaction, Value: userID},
 		}},
+		primitive.E{Key: "$inc", Value: bson.D{
+			primitive.E{Key: "reaction_counts." + reaction, Value: countDelta},
+		}},
 	}
 
 	res, err := sa.db.posts.UpdateOneWithContext(context, filter, update, nil)
@@ -1611,6 +1708,24 @@ func (sa *Adapter) ReactToPost(context TransactionContext, userID string, postID
 		return fmt.Errorf("updated %d posts with reaction %s for %s, but expected 1", res.ModifiedCount, reaction, userID)
 	}
 
+	if !on {
+		// remove the reaction from the counts once nobody uses it
+		unsetFilter := bson.D{
+			primitive.E{Key: "_id", Value: postID},
+			primitive.E{Key: "reaction_counts." + reaction, Value: bson.M{"$lte": 0}},
+		}
+		unsetUpdate := bson.D{
+			primitive.E{Key: "$unset", Value: bson.D{
+				primitive.E{Key: "reactions." + reaction, Value: ""},
+				primitive.E{Key: "reaction_counts." + reaction, Value: ""},
+			}},
+		}
+		_, err = sa.db.posts.UpdateOneWithContext(context, unsetFilter, unsetUpdate, nil)
+		if err != nil {
+			return fmt.Errorf("error cleaning reaction %s of post %s: %v", reaction, postID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -1667,6 +1782,18 @@ func (sa *Adapter) DeletePost(ctx TransactionContext, clientID string, userID st
 			return err
 		}
 
+		_, err = sa.db.postsSeen.DeleteManyWithContext(transactionContext, bson.D{primitive.E{Key: "post_id", Value: postID}}, nil)
+		if err != nil {
+			return err
+		}
+
+		if originalPost.ParentID != nil {
+			err = sa.incrementPostRepliesCount(transactionContext, clientID, *originalPost.ParentID, -1)
+			if err != nil {
+				return err
+			}
+		}
+
 		return sa.UpdateGroupStats(transactionContext, clientID, groupID, true, false, false, false)
 	}
 
@@ -1684,6 +1811,7 @@ func (sa *Adapter) FindScheduledPosts(context TransactionContext) ([]model.Post,
 	err := sa.db.posts.FindWithContext(context, bson.D{
 		{Key: "date_scheduled", Value: bson.M{"$lt": time.Now()}},
 		{Key: "date_notified", Value: nil},
+		{Key: "status", Value: bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
 	}, &posts, nil)
 	if err != nil {
 		return nil, err
@@ -2026,6 +2154,12 @@ func (sa *Adapter) DeleteGroupMembershipsByAccountsIDs(log *logs.Logger, context
 		primitive.E{Key: "user_id", Value: primitive.M{"$in": accountsIDs}},
 	}
 	_, err := sa.db.groupMemberships.DeleteManyWithContext(context, filter, nil)
+	if err != nil {
+		return err
+	}
+
+	// the notes on the deleted accounts are removed regardless of the retention of the groups
+	_, err = sa.db.memberNotes.DeleteManyWithContext(context, filter, nil)
 	return err
 }
 
@@ -2111,8 +2245,12 @@ func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout stri
 
 	cachedManagedGroupConfigs := &syncmap.Map{}
 	managedGroupConfigsLock := &sync.RWMutex{}
+
+	cachedContentFilters := &syncmap.Map{}
+	contentFiltersLock := &sync.RWMutex{}
 	return &Adapter{db: db, cachedSyncConfigs: cachedSyncConfigs, syncConfigsLock: syncConfigsLock,
-		cachedManagedGroupConfigs: cachedManagedGroupConfigs, managedGroupConfigsLock: managedGroupConfigsLock}
+		cachedManagedGroupConfigs: cachedManagedGroupConfigs, managedGroupConfigsLock: managedGroupConfigsLock,
+		cachedContentFilters: cachedContentFilters, contentFiltersLock: contentFiltersLock}
 }
 
 func abortTransaction(sessionContext mongo.SessionContext) {
@@ -2135,10 +2273,15 @@ func (sl *storageListener) OnManagedGroupConfigsChanged() {
 	sl.adapter.cacheManagedGroupConfigs()
 }
 
+func (sl *storageListener) OnContentFiltersChanged() {
+	sl.adapter.cacheContentFilters()
+}
+
 // Listener  listens for change data storage events
 type Listener interface {
 	OnConfigsChanged()
 	OnManagedGroupConfigsChanged()
+	OnContentFiltersChanged()
 }
 
 // DefaultListenerImpl default listener implementation
@@ -2150,6 +2293,9 @@ func (d *DefaultListenerImpl) OnConfigsChanged() {}
 // OnManagedGroupConfigsChanged notifies managed group configs have been updated
 func (d *DefaultListenerImpl) OnManagedGroupConfigsChanged() {}
 
+// OnContentFiltersChanged notifies content filters have been updated
+func (d *DefaultListenerImpl) OnContentFiltersChanged() {}
+
 // TransactionContext wraps mongo.SessionContext for use by external packages
 type TransactionContext interface {
 	mongo.SessionContext
@@ -0,0 +1,151 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddAbuseReport Adds the report to the active case of the target. A new case is created if the target has no active case.
+// The unique index of the active cases makes the concurrent reports of the same target join one case.
+func (sa *Adapter) AddAbuseReport(context TransactionContext, report model.AbuseReport, entry model.AbuseReportEntry) (*model.AbuseReport, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: report.ClientID},
+		primitive.E{Key: "target_id", Value: report.TargetID},
+		primitive.E{Key: "active", Value: true},
+	}
+
+	now := time.Now()
+	set := bson.D{
+		primitive.E{Key: "date_updated", Value: now},
+	}
+	if entry.SendToDean {
+		set = append(set, primitive.E{Key: "send_to_dean", Value: true})
+	}
+	if entry.SendToGroupAdmins {
+		set = append(set, primitive.E{Key: "send_to_group_admins", Value: true})
+	}
+	update := bson.D{
+		primitive.E{Key: "$push", Value: bson.D{primitive.E{Key: "reports", Value: entry}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "reports_count", Value: 1}}},
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$setOnInsert", Value: bson.D{
+			primitive.E{Key: "_id", Value: report.ID},
+			primitive.E{Key: "group_id", Value: report.GroupID},
+			primitive.E{Key: "target_type", Value: report.TargetType},
+			primitive.E{Key: "target_title", Value: report.TargetTitle},
+			primitive.E{Key: "status", Value: model.AbuseReportStatusOpen},
+			primitive.E{Key: "date_created", Value: now},
+		}},
+	}
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result model.AbuseReport
+	err := sa.db.abuseReports.FindOneAndUpdateWithContext(context, filter, update, &result, findOptions)
+	if mongo.IsDuplicateKeyError(err) && context == nil {
+		// a concurrent report has just created the case. Within a transaction the conflict aborts the transaction instead
+		err = sa.db.abuseReports.FindOneAndUpdateWithContext(context, filter, update, &result, findOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FindAbuseReports Finds the abuse cases ordered by last update
+func (sa *Adapter) FindAbuseReports(clientID string, filter model.AbuseReportsFilter) ([]model.AbuseReport, error) {
+	query := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+	}
+	if filter.GroupID != nil {
+		query = append(query, primitive.E{Key: "group_id", Value: *filter.GroupID})
+	}
+	if filter.TargetType != nil {
+		query = append(query, primitive.E{Key: "target_type", Value: *filter.TargetType})
+	}
+	if filter.TargetID != nil {
+		query = append(query, primitive.E{Key: "target_id", Value: *filter.TargetID})
+	}
+	if len(filter.Statuses) > 0 {
+		query = append(query, primitive.E{Key: "status", Value: bson.M{"$in": filter.Statuses}})
+	}
+	if filter.AssigneeID != nil {
+		query = append(query, primitive.E{Key: "assignee_id", Value: *filter.AssigneeID})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_updated", Value: -1}, {Key: "date_created", Value: -1}})
+	if filter.Offset != nil {
+		findOptions.SetSkip(*filter.Offset)
+	}
+	if filter.Limit != nil {
+		findOptions.SetLimit(*filter.Limit)
+	}
+
+	reports := make([]model.AbuseReport, 0)
+	err := sa.db.abuseReports.Find(query, &reports, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// FindAbuseReport Finds an abuse case by id
+func (sa *Adapter) FindAbuseReport(context TransactionContext, clientID string, id string) (*model.AbuseReport, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "_id", Value: id},
+	}
+
+	var reports []model.AbuseReport
+	err := sa.db.abuseReports.FindWithContext(context, filter, &reports, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, nil
+	}
+
+	return &reports[0], nil
+}
+
+// UpdateAbuseReport Updates the status, the assignee and the resolution notes of an abuse case
+func (sa *Adapter) UpdateAbuseReport(context TransactionContext, report model.AbuseReport) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: report.ClientID},
+		primitive.E{Key: "_id", Value: report.ID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: report.Status},
+			primitive.E{Key: "active", Value: !report.IsClosed()},
+			primitive.E{Key: "assignee_id", Value: report.AssigneeID},
+			primitive.E{Key: "resolution_notes", Value: report.ResolutionNotes},
+			primitive.E{Key: "resolved_by", Value: report.ResolvedBy},
+			primitive.E{Key: "date_updated", Value: report.DateUpdated},
+			primitive.E{Key: "date_resolved", Value: report.DateResolved},
+		}},
+	}
+
+	_, err := sa.db.abuseReports.UpdateOneWithContext(context, filter, update, nil)
+	return err
+}
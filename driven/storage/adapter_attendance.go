@@ -0,0 +1,276 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindAttendanceSessions Finds the attendance sessions of a group ordered by date
+func (sa *Adapter) FindAttendanceSessions(clientID string, groupID string) ([]model.AttendanceSession, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "date", Value: 1}})
+
+	var sessions []model.AttendanceSession
+	err := sa.db.attendanceSessions.Find(filter, &sessions, opts)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []model.AttendanceSession{}
+	}
+	return sessions, nil
+}
+
+// FindAttendanceSession Finds an attendance session of a group. Returns nil if it does not exist.
+func (sa *Adapter) FindAttendanceSession(clientID string, groupID string, id string) (*model.AttendanceSession, error) {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+
+	var session model.AttendanceSession
+	err := sa.db.attendanceSessions.FindOne(filter, &session, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// InsertAttendanceSession Inserts an attendance session
+func (sa *Adapter) InsertAttendanceSession(session model.AttendanceSession) error {
+	_, err := sa.db.attendanceSessions.InsertOne(session)
+	return err
+}
+
+// UpdateAttendanceSession Updates the title, the date and the linked event of an attendance session
+func (sa *Adapter) UpdateAttendanceSession(session model.AttendanceSession) error {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: session.ID},
+		primitive.E{Key: "client_id", Value: session.ClientID},
+		primitive.E{Key: "group_id", Value: session.GroupID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "title", Value: session.Title},
+			primitive.E{Key: "date", Value: session.Date},
+			primitive.E{Key: "event_id", Value: session.EventID},
+			primitive.E{Key: "date_updated", Value: session.DateUpdated},
+		}},
+	}
+
+	res, err := sa.db.attendanceSessions.UpdateOne(filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the attendance session does not exist")
+	}
+	return nil
+}
+
+// DeleteAttendanceSession Deletes an attendance session together with its check-ins
+func (sa *Adapter) DeleteAttendanceSession(clientID string, groupID string, id string) error {
+	return sa.PerformTransaction(func(context TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: id},
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+		}
+		res, err := sa.db.attendanceSessions.DeleteOneWithContext(context, filter, nil)
+		if err != nil {
+			return err
+		}
+		if res.DeletedCount == 0 {
+			return errors.New("the attendance session does not exist")
+		}
+
+		_, err = sa.db.attendanceRecords.DeleteManyWithContext(context, bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "session_id", Value: id},
+		}, nil)
+		return err
+	})
+}
+
+// FindAttendanceRecords Finds the check-ins of a group. The session id narrows them to a single session.
+func (sa *Adapter) FindAttendanceRecords(clientID string, groupID string, sessionID *string) ([]model.AttendanceRecord, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	if sessionID != nil {
+		filter = append(filter, primitive.E{Key: "session_id", Value: *sessionID})
+	}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "date_checked_in", Value: 1}})
+
+	var records []model.AttendanceRecord
+	err := sa.db.attendanceRecords.Find(filter, &records, opts)
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []model.AttendanceRecord{}
+	}
+	return records, nil
+}
+
+// CheckInAttendance Saves the check-ins of the members for a session. The existing check-ins are kept and their check-outs are cleared.
+// The date attended of the memberships is moved to the check-in date so that it keeps the latest check-in.
+func (sa *Adapter) CheckInAttendance(clientID string, groupID string, records []model.AttendanceRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return sa.PerformTransaction(func(context TransactionContext) error {
+		membershipIDs := make([]string, len(records))
+		for i, record := range records {
+			membershipIDs[i] = record.MembershipID
+
+			filter := bson.D{
+				primitive.E{Key: "session_id", Value: record.SessionID},
+				primitive.E{Key: "membership_id", Value: record.MembershipID},
+			}
+			update := bson.D{
+				primitive.E{Key: "$set", Value: bson.D{
+					primitive.E{Key: "checked_out_by", Value: nil},
+					primitive.E{Key: "date_checked_out", Value: nil},
+				}},
+				primitive.E{Key: "$setOnInsert", Value: bson.D{
+					primitive.E{Key: "_id", Value: record.ID},
+					primitive.E{Key: "client_id", Value: record.ClientID},
+					primitive.E{Key: "group_id", Value: record.GroupID},
+					primitive.E{Key: "user_id", Value: record.UserID},
+					primitive.E{Key: "name", Value: record.Name},
+					primitive.E{Key: "source", Value: record.Source},
+					primitive.E{Key: "checked_in_by", Value: record.CheckedInBy},
+					primitive.E{Key: "date_checked_in", Value: record.DateCheckedIn},
+				}},
+			}
+			_, err := sa.db.attendanceRecords.UpdateOneWithContext(context, filter, update, options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := sa.db.groupMemberships.UpdateManyWithContext(context, bson.D{
+			primitive.E{Key: "_id", Value: bson.M{"$in": membershipIDs}},
+			primitive.E{Key: "client_id", Value: clientID},
+		}, bson.D{
+			primitive.E{Key: "$max", Value: bson.D{primitive.E{Key: "date_attended", Value: records[0].DateCheckedIn}}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(context, clientID, groupID, false, false, false, true)
+	})
+}
+
+// CheckOutAttendance Checks out the members of a session who are checked in
+func (sa *Adapter) CheckOutAttendance(clientID string, groupID string, sessionID string, membershipIDs []string, checkedOutBy string, dateCheckedOut time.Time) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "session_id", Value: sessionID},
+		primitive.E{Key: "membership_id", Value: bson.M{"$in": membershipIDs}},
+		primitive.E{Key: "date_checked_out", Value: nil},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "checked_out_by", Value: checkedOutBy},
+			primitive.E{Key: "date_checked_out", Value: dateCheckedOut},
+		}},
+	}
+	_, err := sa.db.attendanceRecords.UpdateMany(filter, update, nil)
+	return err
+}
+
+// SetAttendanceCheckInWindow Opens the check-in window of a session. A nil window closes it.
+func (sa *Adapter) SetAttendanceCheckInWindow(clientID string, groupID string, sessionID string, window *model.AttendanceCheckInWindow) error {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: sessionID},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	var update bson.D
+	if window != nil {
+		update = bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "check_in_window", Value: window}}}}
+	} else {
+		update = bson.D{primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "check_in_window", Value: ""}}}}
+	}
+
+	res, err := sa.db.attendanceSessions.UpdateOne(filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the attendance session does not exist")
+	}
+	return nil
+}
+
+// FindOpenAttendanceSessions Finds the sessions of a group whose check-in windows are open at the given time
+func (sa *Adapter) FindOpenAttendanceSessions(clientID string, groupID string, now time.Time) ([]model.AttendanceSession, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "check_in_window.date_opens", Value: bson.M{"$lte": now}},
+		primitive.E{Key: "check_in_window.date_closes", Value: bson.M{"$gt": now}},
+	}
+
+	var sessions []model.AttendanceSession
+	err := sa.db.attendanceSessions.Find(filter, &sessions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// InsertAttendanceCheckInFailure Records a wrong check-in code submitted by a user
+func (sa *Adapter) InsertAttendanceCheckInFailure(clientID string, groupID string, userID string, date time.Time) error {
+	_, err := sa.db.attendanceCheckInFailures.InsertOne(bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "user_id", Value: userID},
+		primitive.E{Key: "date_created", Value: date},
+	})
+	return err
+}
+
+// CountAttendanceCheckInFailures Counts the wrong check-in codes submitted by a user for a group since the given time
+func (sa *Adapter) CountAttendanceCheckInFailures(clientID string, groupID string, userID string, since time.Time) (int64, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "user_id", Value: userID},
+		primitive.E{Key: "date_created", Value: bson.M{"$gte": since}},
+	}
+	return sa.db.attendanceCheckInFailures.CountDocuments(filter)
+}
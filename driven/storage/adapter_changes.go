@@ -0,0 +1,240 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"groups/core/model"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// the collections exposed by the change feed and their entity types
+var changeFeedEntityTypes = map[string]string{
+	"groups":            model.ChangeEntityGroup,
+	"group_memberships": model.ChangeEntityMembership,
+	"posts":             model.ChangeEntityPost,
+}
+
+// the server error codes returned when a change stream cannot be resumed from the given token
+var changeStreamResumeErrorCodes = map[int32]bool{
+	260: true, // InvalidResumeToken
+	280: true, // ChangeStreamFatalError
+	286: true, // ChangeStreamHistoryLost
+}
+
+type changeStreamDocument struct {
+	ID            bson.Raw            `bson:"_id"`
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	Namespace     struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey struct {
+		ID interface{} `bson:"_id"`
+	} `bson:"documentKey"`
+	UpdateDescription *struct {
+		UpdatedFields bson.M   `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+	FullDocument             bson.M `bson:"fullDocument"`
+	FullDocumentBeforeChange bson.M `bson:"fullDocumentBeforeChange"`
+}
+
+// FindChanges reads the changes of the groups, the memberships and the posts of the client from the database change stream.
+// It starts after the since token or from now if it is not provided and waits up to maxWait for the first change.
+// Returns model.ErrChangeTokenExpired if the change stream cannot be resumed from the since token.
+func (sa *Adapter) FindChanges(clientID string, since *string, limit int, maxWait time.Duration) (*model.ChangeBatch, error) {
+	cur, err := sa.openChangeStream(&clientID, since, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), maxWait)
+	defer cancelWait()
+
+	changes := []model.ChangeEvent{}
+	for len(changes) < limit {
+		// wait for the first change only, then take what is already available
+		var hasNext bool
+		if len(changes) == 0 {
+			hasNext = cur.Next(waitCtx)
+		} else {
+			hasNext = cur.TryNext(waitCtx)
+		}
+		if !hasNext {
+			break
+		}
+
+		var doc changeStreamDocument
+		err = cur.Decode(&doc)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, doc.toChangeEvent(resumeTokenData(cur.ResumeToken())))
+	}
+	err = cur.Err()
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, sa.mapChangeStreamError(err)
+	}
+
+	return &model.ChangeBatch{Changes: changes, NextToken: resumeTokenData(cur.ResumeToken())}, nil
+}
+
+// WatchChanges watches the changes of the groups, the memberships and the posts of all clients and calls the handler for every change.
+// It starts after the since token or from now if it is not provided. It blocks until the change stream fails.
+func (sa *Adapter) WatchChanges(since *string, handler func(change model.ChangeEvent)) error {
+	cur, err := sa.openChangeStream(nil, since, 0)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(context.Background())
+
+	for cur.Next(context.Background()) {
+		var doc changeStreamDocument
+		err = cur.Decode(&doc)
+		if err != nil {
+			log.Printf("error decoding change - %s", err)
+			continue
+		}
+		handler(doc.toChangeEvent(resumeTokenData(cur.ResumeToken())))
+	}
+	return sa.mapChangeStreamError(cur.Err())
+}
+
+func (sa *Adapter) openChangeStream(clientID *string, since *string, batchSize int32) (*mongo.ChangeStream, error) {
+	collections := bson.A{}
+	for collection := range changeFeedEntityTypes {
+		collections = append(collections, collection)
+	}
+	match := bson.D{
+		primitive.E{Key: "ns.coll", Value: bson.M{"$in": collections}},
+		primitive.E{Key: "operationType", Value: bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}},
+	}
+	if clientID != nil {
+		match = append(match, primitive.E{Key: "$or", Value: bson.A{
+			bson.M{"fullDocument.client_id": *clientID},
+			bson.M{"fullDocumentBeforeChange.client_id": *clientID},
+		}})
+	}
+	pipeline := mongo.Pipeline{bson.D{primitive.E{Key: "$match", Value: match}}}
+
+	opts := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if batchSize > 0 {
+		opts.SetBatchSize(batchSize)
+	}
+	if since != nil {
+		opts.SetStartAfter(bson.M{"_data": *since})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sa.db.mongoTimeout)
+	defer cancel()
+	cur, err := sa.db.db.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, sa.mapChangeStreamError(err)
+	}
+	return cur, nil
+}
+
+func (sa *Adapter) mapChangeStreamError(err error) error {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && changeStreamResumeErrorCodes[commandErr.Code] {
+		return model.ErrChangeTokenExpired
+	}
+	return err
+}
+
+func resumeTokenData(token bson.Raw) string {
+	if token == nil {
+		return ""
+	}
+	data, ok := token.Lookup("_data").StringValueOK()
+	if !ok {
+		return ""
+	}
+	return data
+}
+
+func (doc changeStreamDocument) toChangeEvent(token string) model.ChangeEvent {
+	entityType := changeFeedEntityTypes[doc.Namespace.Collection]
+	entityID, _ := doc.DocumentKey.ID.(string)
+
+	event := model.ChangeEvent{ID: token, EntityType: entityType, EntityID: entityID,
+		Date: time.Unix(int64(doc.ClusterTime.T), 0).UTC()}
+
+	switch doc.OperationType {
+	case "insert":
+		event.Operation = model.ChangeOperationCreated
+	case "delete":
+		event.Operation = model.ChangeOperationDeleted
+	default:
+		event.Operation = model.ChangeOperationUpdated
+	}
+
+	if doc.UpdateDescription != nil {
+		updatedFields := []string{}
+		for field := range doc.UpdateDescription.UpdatedFields {
+			if !model.IsRedactedChangeField(entityType, field) {
+				updatedFields = append(updatedFields, field)
+			}
+		}
+		for _, field := range doc.UpdateDescription.RemovedFields {
+			if !model.IsRedactedChangeField(entityType, field) {
+				updatedFields = append(updatedFields, field)
+			}
+		}
+		event.UpdatedFields = updatedFields
+	}
+
+	// the document may be missing for an update if it has been deleted meanwhile
+	if doc.OperationType != "delete" {
+		event.Data = normalizeChangeDocument(entityType, doc.FullDocument)
+	}
+	event.PreviousData = normalizeChangeDocument(entityType, doc.FullDocumentBeforeChange)
+
+	document := event.Data
+	if document == nil {
+		document = event.PreviousData
+	}
+	if document != nil {
+		event.ClientID, _ = document["client_id"].(string)
+		event.GroupID, _ = document["group_id"].(string)
+	}
+	if entityType == model.ChangeEntityGroup {
+		event.GroupID = entityID
+	}
+
+	return event
+}
+
+func normalizeChangeDocument(entityType string, document bson.M) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	data := map[string]interface{}(document)
+	data["id"] = data["_id"]
+	delete(data, "_id")
+	model.RedactChangeData(entityType, data)
+	return data
+}
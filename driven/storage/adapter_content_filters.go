@@ -0,0 +1,164 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"groups/core/model"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/sync/syncmap"
+)
+
+// cacheContentFilters caches the content filters from the DB
+func (sa *Adapter) cacheContentFilters() error {
+	log.Println("cacheContentFilters..")
+
+	filters, err := sa.LoadContentFilters()
+	if err != nil {
+		return err
+	}
+
+	sa.setCachedContentFilters(&filters)
+
+	return nil
+}
+
+func (sa *Adapter) setCachedContentFilters(filters *[]model.ContentFilter) {
+	sa.contentFiltersLock.Lock()
+	defer sa.contentFiltersLock.Unlock()
+
+	sa.cachedContentFilters = &syncmap.Map{}
+	for _, filter := range *filters {
+		sa.cachedContentFilters.Store(filter.ID, filter)
+	}
+}
+
+func (sa *Adapter) getCachedContentFilter(id string) (*model.ContentFilter, error) {
+	sa.contentFiltersLock.RLock()
+	defer sa.contentFiltersLock.RUnlock()
+
+	item, _ := sa.cachedContentFilters.Load(id)
+	if item != nil {
+		filter, ok := item.(model.ContentFilter)
+		if !ok {
+			return nil, fmt.Errorf("missing content filter with id: %s", id)
+		}
+		return &filter, nil
+	}
+	return nil, nil
+}
+
+func (sa *Adapter) getCachedContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error) {
+	sa.contentFiltersLock.RLock()
+	defer sa.contentFiltersLock.RUnlock()
+
+	var err error
+	filterList := make([]model.ContentFilter, 0)
+	sa.cachedContentFilters.Range(func(key, item interface{}) bool {
+		if item == nil {
+			return false
+		}
+
+		filter, ok := item.(model.ContentFilter)
+		if !ok {
+			err = fmt.Errorf("error casting content filter with id: %s", key)
+			return false
+		}
+		if filter.ClientID == clientID && (groupID == nil || filter.AppliesToGroup(*groupID)) {
+			filterList = append(filterList, filter)
+		}
+		return true
+	})
+
+	return filterList, err
+}
+
+// LoadContentFilters loads all content filters
+func (sa *Adapter) LoadContentFilters() ([]model.ContentFilter, error) {
+	var list []model.ContentFilter
+	err := sa.db.contentFilters.Find(bson.M{}, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// FindContentFilter finds a content filter by ID
+func (sa *Adapter) FindContentFilter(id string, clientID string) (*model.ContentFilter, error) {
+	filter, err := sa.getCachedContentFilter(id)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil || filter.ClientID != clientID {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// FindContentFilters finds the content filters for the specified clientID.
+// If the groupID is provided only the filters which are applied on this group are returned - the client wide filters and the filters of the group.
+func (sa *Adapter) FindContentFilters(clientID string, groupID *string) ([]model.ContentFilter, error) {
+	return sa.getCachedContentFilters(clientID, groupID)
+}
+
+// InsertContentFilter inserts a new content filter
+func (sa *Adapter) InsertContentFilter(filter model.ContentFilter) error {
+	_, err := sa.db.contentFilters.InsertOne(filter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateContentFilter updates an existing content filter
+func (sa *Adapter) UpdateContentFilter(filter model.ContentFilter) error {
+	query := bson.M{"_id": filter.ID, "client_id": filter.ClientID}
+	update := bson.M{"$set": bson.M{
+		"group_id":     filter.GroupID,
+		"name":         filter.Name,
+		"words":        filter.Words,
+		"patterns":     filter.Patterns,
+		"date_updated": time.Now().UTC(),
+	}}
+
+	res, err := sa.db.contentFilters.UpdateOne(query, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return fmt.Errorf("content filter could not be found for id: %s", filter.ID)
+	}
+
+	return nil
+}
+
+// DeleteContentFilter deletes an existing content filter
+func (sa *Adapter) DeleteContentFilter(id string, clientID string) error {
+	query := bson.M{"_id": id, "client_id": clientID}
+
+	res, err := sa.db.contentFilters.DeleteOne(query, nil)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount != 1 {
+		return fmt.Errorf("content filter could not be found for id: %s", id)
+	}
+	return nil
+}
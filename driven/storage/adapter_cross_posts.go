@@ -0,0 +1,76 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindCrossPostCopies Finds the copies of a cross-posted post within the other groups
+// This method doesn't construct tree hierarchy!
+func (sa *Adapter) FindCrossPostCopies(context TransactionContext, clientID string, originID string) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "origin_id", Value: originID},
+	}
+
+	posts := make([]model.Post, 0)
+	err := sa.db.posts.FindWithContext(context, filter, &posts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// UpdateCrossPostCopy Updates the content of a cross-posted copy after its origin post has been updated
+func (sa *Adapter) UpdateCrossPostCopy(context TransactionContext, clientID string, postCopy model.Post) error {
+	wrapper := func(ctx TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: postCopy.ID},
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "origin_id", Value: bson.M{"$ne": nil}},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "subject", Value: postCopy.Subject},
+				primitive.E{Key: "body", Value: postCopy.Body},
+				primitive.E{Key: "body_format", Value: postCopy.BodyFormat},
+				primitive.E{Key: "body_html", Value: postCopy.BodyHTML},
+				primitive.E{Key: "private", Value: postCopy.Private},
+				primitive.E{Key: "use_as_notification", Value: postCopy.UseAsNotification},
+				primitive.E{Key: "image_url", Value: postCopy.ImageURL},
+				primitive.E{Key: "date_updated", Value: time.Now()},
+				primitive.E{Key: "date_scheduled", Value: postCopy.DateScheduled},
+				primitive.E{Key: "date_expires", Value: postCopy.DateExpires},
+			}},
+		}
+		_, err := sa.db.posts.UpdateOneWithContext(ctx, filter, update, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, postCopy.GroupID, true, false, false, false)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindDigestMemberships Finds the memberships for which an email digest is due at the provided time
+func (sa *Adapter) FindDigestMemberships(context TransactionContext, now time.Time) ([]model.GroupMembership, error) {
+	dueFilter := func(frequency string, period time.Duration) bson.M {
+		return bson.M{
+			"notifications_preferences.digest_frequency": frequency,
+			"$or": []bson.M{
+				{"date_digested": nil},
+				{"date_digested": bson.M{"$lte": now.Add(-period)}},
+			},
+		}
+	}
+
+	filter := bson.D{
+		primitive.E{Key: "status", Value: bson.M{"$in": []string{"admin", "member"}}},
+		primitive.E{Key: "$or", Value: []bson.M{
+			dueFilter(model.DigestFrequencyDaily, 24*time.Hour),
+			dueFilter(model.DigestFrequencyWeekly, 7*24*time.Hour),
+		}},
+	}
+
+	memberships := make([]model.GroupMembership, 0)
+	err := sa.db.groupMemberships.FindWithContext(context, filter, &memberships, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// UpdateMembershipsDateDigested Sets the last digest date of the provided memberships
+func (sa *Adapter) UpdateMembershipsDateDigested(context TransactionContext, ids []string, dateDigested time.Time) error {
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.M{"$in": ids}}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "date_digested", Value: dateDigested},
+		}},
+	}
+
+	_, err := sa.db.groupMemberships.UpdateManyWithContext(context, filter, update, nil)
+	return err
+}
+
+// FindDigestPosts Finds the published and not expired posts and replies of the group created in the provided period ordered by creation date
+// This method doesn't construct tree hierarchy!
+func (sa *Adapter) FindDigestPosts(clientID string, groupID string, since time.Time, until time.Time) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "date_created", Value: bson.M{"$gt": since, "$lte": until}},
+		primitive.E{Key: "status", Value: bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
+		primitive.E{Key: "date_expired", Value: nil},
+		primitive.E{Key: "$or", Value: []bson.M{
+			{"date_scheduled": nil},
+			{"date_scheduled": bson.M{"$lte": until}},
+		}},
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_created", Value: 1}})
+
+	posts := make([]model.Post, 0)
+	err := sa.db.posts.Find(filter, &posts, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FindDigestEvents Finds the events of the group created in the provided period
+func (sa *Adapter) FindDigestEvents(clientID string, groupID string, since time.Time, until time.Time) ([]model.Event, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "date_created", Value: bson.M{"$gt": since, "$lte": until}},
+	}
+
+	events := make([]model.Event, 0)
+	err := sa.db.events.Find(filter, &events, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// CountPendingMemberships Counts the membership requests of the group which wait for an admin approval
+func (sa *Adapter) CountPendingMemberships(clientID string, groupID string) (int64, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "status", Value: "pending"},
+	}
+	return sa.db.groupMemberships.CountDocuments(filter)
+}
+
+// CountPendingPosts Counts the posts of the group which wait for an admin review
+func (sa *Adapter) CountPendingPosts(clientID string, groupID string) (int64, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "status", Value: model.PostStatusPendingReview},
+	}
+	return sa.db.posts.CountDocuments(filter)
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateGroupMemberLabels Updates the member labels of a group and removes the deleted labels from the memberships
+func (sa *Adapter) UpdateGroupMemberLabels(clientID string, groupID string, labels []model.MemberLabel) error {
+	return sa.PerformTransaction(func(context TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: groupID},
+			primitive.E{Key: "client_id", Value: clientID},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "member_labels", Value: labels},
+				primitive.E{Key: "date_updated", Value: time.Now()},
+			}},
+		}
+		res, err := sa.db.groups.UpdateOneWithContext(context, filter, update, nil)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return errors.New("the group does not exist")
+		}
+
+		labelIDs := make([]string, len(labels))
+		for i, label := range labels {
+			labelIDs[i] = label.ID
+		}
+		membershipsFilter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "label_ids", Value: bson.M{"$elemMatch": bson.M{"$nin": labelIDs}}},
+		}
+		membershipsUpdate := bson.D{
+			primitive.E{Key: "$pull", Value: bson.D{
+				primitive.E{Key: "label_ids", Value: bson.M{"$nin": labelIDs}},
+			}},
+		}
+		_, err = sa.db.groupMemberships.UpdateManyWithContext(context, membershipsFilter, membershipsUpdate, nil)
+		return err
+	})
+}
+
+// UpdateMembershipLabels Updates the member labels of a membership
+func (sa *Adapter) UpdateMembershipLabels(clientID string, membershipID string, labelIDs []string) error {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: membershipID},
+		primitive.E{Key: "client_id", Value: clientID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "label_ids", Value: labelIDs},
+			primitive.E{Key: "date_updated", Value: time.Now()},
+		}},
+	}
+
+	res, err := sa.db.groupMemberships.UpdateOne(filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the membership does not exist")
+	}
+	return nil
+}
+
+// findMemberLabelIDs gives the member labels of the user within the group. Returns nil if the user is not a member.
+func (sa *Adapter) findMemberLabelIDs(context TransactionContext, clientID string, groupID string, userID string) ([]string, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "user_id", Value: userID},
+	}
+	opts := options.FindOne().SetProjection(bson.D{primitive.E{Key: "label_ids", Value: 1}})
+
+	var membership model.GroupMembership
+	err := sa.db.groupMemberships.FindOneWithContext(context, filter, &membership, opts)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return membership.LabelIDs, nil
+}
+
+// postAudienceFilter gives the conditions of which one must match for a post or an event to be addressed to the user:
+// it is addressed to everybody, to the user or to one of the member labels of the user. Nil user matches only the ones addressed to everybody.
+func postAudienceFilter(userID *string, labelIDs []string) []primitive.M {
+	filter := []primitive.M{
+		{"to_members.0": primitive.M{"$exists": false}, "to_label_ids.0": primitive.M{"$exists": false}},
+	}
+	if userID != nil {
+		filter = append(filter, primitive.M{"to_members.user_id": *userID})
+	}
+	if len(labelIDs) > 0 {
+		filter = append(filter, primitive.M{"to_label_ids": primitive.M{"$in": labelIDs}})
+	}
+	return filter
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"groups/core/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindMemberNotes Finds the admin notes on a membership ordered by date. The retained notes on a previous membership of the same user are included.
+func (sa *Adapter) FindMemberNotes(clientID string, membership model.GroupMembership) ([]model.MemberNote, error) {
+	owners := bson.A{bson.M{"membership_id": membership.ID}}
+	if len(membership.UserID) > 0 {
+		owners = append(owners, bson.M{"user_id": membership.UserID})
+	}
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: membership.GroupID},
+		primitive.E{Key: "$or", Value: owners},
+	}
+	return sa.findMemberNotes(filter)
+}
+
+// FindGroupMemberNotes Finds the admin notes on all the members of a group ordered by date
+func (sa *Adapter) FindGroupMemberNotes(clientID string, groupID string) ([]model.MemberNote, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	return sa.findMemberNotes(filter)
+}
+
+func (sa *Adapter) findMemberNotes(filter bson.D) ([]model.MemberNote, error) {
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: 1}})
+
+	var notes []model.MemberNote
+	err := sa.db.memberNotes.Find(filter, &notes, opts)
+	if err != nil {
+		return nil, err
+	}
+	if notes == nil {
+		notes = []model.MemberNote{}
+	}
+	return notes, nil
+}
+
+// FindMemberNote Finds an admin note of a group. Returns nil if it does not exist.
+func (sa *Adapter) FindMemberNote(clientID string, groupID string, id string) (*model.MemberNote, error) {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+
+	var note model.MemberNote
+	err := sa.db.memberNotes.FindOne(filter, &note, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// InsertMemberNote Inserts an admin note on a member
+func (sa *Adapter) InsertMemberNote(note model.MemberNote) error {
+	_, err := sa.db.memberNotes.InsertOne(note)
+	return err
+}
+
+// UpdateMemberNote Updates the text of an admin note
+func (sa *Adapter) UpdateMemberNote(note model.MemberNote) error {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: note.ID},
+		primitive.E{Key: "client_id", Value: note.ClientID},
+		primitive.E{Key: "group_id", Value: note.GroupID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "text", Value: note.Text},
+			primitive.E{Key: "date_updated", Value: note.DateUpdated},
+			primitive.E{Key: "updated_by", Value: note.UpdatedBy},
+		}},
+	}
+
+	res, err := sa.db.memberNotes.UpdateOne(filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the member note does not exist")
+	}
+	return nil
+}
+
+// DeleteMemberNote Deletes an admin note
+func (sa *Adapter) DeleteMemberNote(clientID string, groupID string, id string) error {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	res, err := sa.db.memberNotes.DeleteOne(filter, nil)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("the member note does not exist")
+	}
+	return nil
+}
+
+// deleteMemberNotesOfMemberships deletes the notes on the deleted memberships of a group unless the group retains them
+func (sa *Adapter) deleteMemberNotesOfMemberships(context TransactionContext, clientID string, groupID string, membershipIDs []string) error {
+	if len(membershipIDs) == 0 {
+		return nil
+	}
+
+	var group model.Group
+	err := sa.db.groups.FindOneWithContext(context, bson.D{
+		primitive.E{Key: "_id", Value: groupID},
+		primitive.E{Key: "client_id", Value: clientID},
+	}, &group, options.FindOne().SetProjection(bson.D{primitive.E{Key: "settings", Value: 1}}))
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	if err == nil && group.Settings != nil && group.Settings.MemberNotesPreferences.GetRetention() == model.MemberNotesRetentionRetain {
+		return nil
+	}
+
+	_, err = sa.db.memberNotes.DeleteManyWithContext(context, bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "membership_id", Value: bson.M{"$in": membershipIDs}},
+	}, nil)
+	return err
+}
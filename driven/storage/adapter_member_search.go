@@ -0,0 +1,157 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchGroupMemberships Finds a page of the group memberships which start with the search query and gives the number of all matching memberships
+func (sa *Adapter) SearchGroupMemberships(clientID string, groupID string, search model.MemberSearch) ([]model.GroupMembership, int64, error) {
+	filter := memberSearchQueryFilter(clientID, groupID, search)
+	total, err := sa.db.groupMemberships.CountDocuments(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(memberSearchSort(search)).SetSkip(search.Offset).SetLimit(search.Limit)
+	var result []model.GroupMembership
+	err = sa.db.groupMemberships.Find(filter, &result, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+// FindMemberSearchUserIDs Finds the user ids of all the group memberships which start with the search query
+func (sa *Adapter) FindMemberSearchUserIDs(clientID string, groupID string, search model.MemberSearch) ([]string, error) {
+	filter := memberSearchQueryFilter(clientID, groupID, search)
+	opts := options.Find().SetProjection(bson.D{primitive.E{Key: "user_id", Value: 1}})
+
+	var memberships []model.GroupMembership
+	err := sa.db.groupMemberships.Find(filter, &memberships, opts)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, len(memberships))
+	for i, membership := range memberships {
+		userIDs[i] = membership.UserID
+	}
+	return userIDs, nil
+}
+
+// FindMemberSearchCandidates Finds a page of the memberships which match the search except its query. Only the fields which the query is matched against are loaded.
+func (sa *Adapter) FindMemberSearchCandidates(clientID string, groupID string, search model.MemberSearch, offset int64, limit int64) ([]model.GroupMembership, error) {
+	filter := memberSearchFilter(clientID, groupID, search)
+	opts := options.Find().SetSort(memberSearchSort(search)).SetSkip(offset).SetLimit(limit).
+		SetProjection(bson.D{
+			primitive.E{Key: "user_id", Value: 1},
+			primitive.E{Key: "name", Value: 1},
+			primitive.E{Key: "net_id", Value: 1},
+			primitive.E{Key: "email", Value: 1},
+		})
+
+	var result []model.GroupMembership
+	err := sa.db.groupMemberships.Find(filter, &result, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func memberSearchFilter(clientID string, groupID string, search model.MemberSearch) bson.D {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	if len(search.Statuses) > 0 {
+		filter = append(filter, primitive.E{Key: "status", Value: bson.M{"$in": search.Statuses}})
+	}
+	if search.IDs != nil {
+		filter = append(filter, primitive.E{Key: "_id", Value: bson.M{"$in": search.IDs}})
+	}
+	if len(search.ExcludedUserIDs) > 0 {
+		filter = append(filter, primitive.E{Key: "user_id", Value: bson.M{"$nin": search.ExcludedUserIDs}})
+	}
+	if len(search.LabelIDs) > 0 {
+		filter = append(filter, primitive.E{Key: "label_ids", Value: bson.M{"$in": search.LabelIDs}})
+	}
+	return appendProfileValuesFilter(filter, search.ProfileValues)
+}
+
+// memberSearchFields are the lower-cased copies of the searchable fields. The name copy has an item for every word of the name.
+var memberSearchFields = map[string]string{
+	model.MemberSearchFieldName:  "search_name",
+	model.MemberSearchFieldNetID: "search_net_id",
+	model.MemberSearchFieldEmail: "search_email",
+}
+
+// memberSearchQueryFilter gives the search filter which also matches the query as a prefix of the searchable fields.
+// The anchored case-sensitive regexes against the lower-cased fields use the {client_id, group_id, field} indexes.
+func memberSearchQueryFilter(clientID string, groupID string, search model.MemberSearch) bson.D {
+	filter := memberSearchFilter(clientID, groupID, search)
+	if len(search.Query) > 0 {
+		pattern := "^" + regexp.QuoteMeta(strings.ToLower(search.Query))
+		or := bson.A{}
+		for _, field := range search.Fields {
+			or = append(or, bson.D{primitive.E{Key: memberSearchFields[field], Value: primitive.Regex{Pattern: pattern}}})
+		}
+		filter = append(filter, primitive.E{Key: "$or", Value: or})
+	}
+	return filter
+}
+
+func memberSearchSort(search model.MemberSearch) bson.D {
+	order := 1
+	if search.SortOrder == "desc" {
+		order = -1
+	}
+	key := "name"
+	switch search.SortBy {
+	case model.MemberSearchSortDateJoined:
+		key = "date_created"
+	case model.MemberSearchSortLastActivity:
+		key = "date_last_read"
+	}
+	return bson.D{primitive.E{Key: key, Value: order}, primitive.E{Key: "_id", Value: order}}
+}
+
+// appendProfileValuesFilter appends the filters by the profile field values. A list value matches any of its items.
+// Only string, number and boolean values are matched so that a value can never be taken as a query operator.
+func appendProfileValuesFilter(filter bson.D, values map[string]interface{}) bson.D {
+	for key, value := range values {
+		if !model.IsValidMemberProfileFieldKey(key) {
+			continue
+		}
+		if list, ok := value.([]interface{}); ok {
+			items := make([]interface{}, 0, len(list))
+			for _, item := range list {
+				if model.IsValidProfileFilterValue(item) {
+					items = append(items, item)
+				}
+			}
+			filter = append(filter, primitive.E{Key: "profile_values." + key, Value: bson.M{"$in": items}})
+		} else if model.IsValidProfileFilterValue(value) {
+			filter = append(filter, primitive.E{Key: "profile_values." + key, Value: bson.M{"$eq": value}})
+		}
+	}
+	return filter
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertOutboxNotification Inserts a notification intent into the notification outbox
+func (sa *Adapter) InsertOutboxNotification(context TransactionContext, notification model.OutboxNotification) error {
+	_, err := sa.db.notificationOutbox.InsertOneWithContext(context, notification)
+	return err
+}
+
+// ClaimOutboxNotification Claims a pending notification which is due for delivery. The next attempt date of the
+// claimed notification is moved forward with the provided lease so that it is not delivered concurrently.
+// Returns nil if there is no due notification.
+func (sa *Adapter) ClaimOutboxNotification(now time.Time, lease time.Duration) (*model.OutboxNotification, error) {
+	filter := bson.D{
+		primitive.E{Key: "status", Value: model.OutboxStatusPending},
+		primitive.E{Key: "date_next_attempt", Value: bson.M{"$lte": now}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "date_next_attempt", Value: now.Add(lease)},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{primitive.E{Key: "date_next_attempt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var notification model.OutboxNotification
+	err := sa.db.notificationOutbox.FindOneAndUpdate(filter, update, &notification, opts)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// MarkOutboxNotificationSent Marks the notification as delivered
+func (sa *Adapter) MarkOutboxNotificationSent(id string, attempts int) error {
+	now := time.Now().UTC()
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: model.OutboxStatusSent},
+			primitive.E{Key: "attempts", Value: attempts},
+			primitive.E{Key: "last_error", Value: ""},
+			primitive.E{Key: "date_sent", Value: now},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+
+	_, err := sa.db.notificationOutbox.UpdateOne(filter, update, nil)
+	return err
+}
+
+// MarkOutboxNotificationFailed Records a failed delivery attempt of the notification
+func (sa *Adapter) MarkOutboxNotificationFailed(id string, attempts int, lastError string, status string, dateNextAttempt time.Time) error {
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: status},
+			primitive.E{Key: "attempts", Value: attempts},
+			primitive.E{Key: "last_error", Value: lastError},
+			primitive.E{Key: "date_next_attempt", Value: dateNextAttempt},
+			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+		}},
+	}
+
+	_, err := sa.db.notificationOutbox.UpdateOne(filter, update, nil)
+	return err
+}
+
+// FindOutboxNotifications Finds the outbox notifications of the client by optional status ordered by creation date descending
+func (sa *Adapter) FindOutboxNotifications(clientID string, status *string, offset *int64, limit *int64) ([]model.OutboxNotification, error) {
+	filter := bson.D{primitive.E{Key: "client_id", Value: clientID}}
+	if status != nil {
+		filter = append(filter, primitive.E{Key: "status", Value: *status})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}})
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	notifications := make([]model.OutboxNotification, 0)
+	err := sa.db.notificationOutbox.Find(filter, &notifications, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ReplayOutboxNotification Moves a dead-lettered notification back to the pending state for immediate delivery.
+// Returns false if there is no such dead-lettered notification.
+func (sa *Adapter) ReplayOutboxNotification(clientID string, id string) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "status", Value: model.OutboxStatusDead},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: model.OutboxStatusPending},
+			primitive.E{Key: "attempts", Value: 0},
+			primitive.E{Key: "date_next_attempt", Value: now},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+
+	result, err := sa.db.notificationOutbox.UpdateOne(filter, update, nil)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindNotificationTemplates finds the notification templates of the client by optional group and operation
+func (sa *Adapter) FindNotificationTemplates(clientID string, groupID *string, operation *string) ([]model.NotificationTemplate, error) {
+	filter := bson.M{"client_id": clientID}
+	if groupID != nil {
+		filter["group_id"] = *groupID
+	}
+	if operation != nil {
+		filter["operation"] = *operation
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "operation", Value: 1}, {Key: "group_id", Value: 1}, {Key: "locale", Value: 1}})
+
+	list := make([]model.NotificationTemplate, 0)
+	err := sa.db.notificationTemplates.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindApplicableNotificationTemplates finds the client wide and the group templates of the operation in all locales
+func (sa *Adapter) FindApplicableNotificationTemplates(context TransactionContext, clientID string, groupID *string, operation string) ([]model.NotificationTemplate, error) {
+	groupIDs := bson.A{nil}
+	if groupID != nil {
+		groupIDs = append(groupIDs, *groupID)
+	}
+	filter := bson.M{"client_id": clientID, "operation": operation, "group_id": bson.M{"$in": groupIDs}}
+
+	var list []model.NotificationTemplate
+	err := sa.db.notificationTemplates.FindWithContext(context, filter, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindNotificationTemplate finds a notification template by ID
+func (sa *Adapter) FindNotificationTemplate(clientID string, id string) (*model.NotificationTemplate, error) {
+	filter := bson.M{"_id": id, "client_id": clientID}
+
+	var list []model.NotificationTemplate
+	err := sa.db.notificationTemplates.Find(filter, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &list[0], nil
+}
+
+// InsertNotificationTemplate inserts a new notification template
+func (sa *Adapter) InsertNotificationTemplate(template model.NotificationTemplate) error {
+	_, err := sa.db.notificationTemplates.InsertOne(template)
+	return err
+}
+
+// UpdateNotificationTemplate updates the title and the body of an existing notification template
+func (sa *Adapter) UpdateNotificationTemplate(template model.NotificationTemplate) error {
+	query := bson.M{"_id": template.ID, "client_id": template.ClientID}
+	update := bson.M{"$set": bson.M{
+		"title":        template.Title,
+		"body":         template.Body,
+		"date_updated": time.Now().UTC(),
+	}}
+
+	res, err := sa.db.notificationTemplates.UpdateOne(query, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return fmt.Errorf("notification template could not be found for id: %s", template.ID)
+	}
+	return nil
+}
+
+// DeleteNotificationTemplate deletes an existing notification template
+func (sa *Adapter) DeleteNotificationTemplate(clientID string, id string) error {
+	query := bson.M{"_id": id, "client_id": clientID}
+
+	res, err := sa.db.notificationTemplates.DeleteOne(query, nil)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount != 1 {
+		return fmt.Errorf("notification template could not be found for id: %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// activePinnedPostsFilter constructs a filter for the published pinned top level posts of a group.
+// Expired announcements and the posts waiting for review or rejected are excluded.
+func activePinnedPostsFilter(clientID string, groupID string) bson.D {
+	now := time.Now()
+	return bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "pinned", Value: true},
+		primitive.E{Key: "parent_id", Value: nil},
+		primitive.E{Key: "date_expired", Value: nil},
+		primitive.E{Key: "status", Value: bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
+		primitive.E{Key: "$and", Value: []bson.M{
+			{"$or": []bson.M{
+				{"date_scheduled": nil},
+				{"date_scheduled": bson.M{"$lt": now}},
+			}},
+			{"$or": []bson.M{
+				{"is_announcement": bson.M{"$ne": true}},
+				{"date_announcement_expires": nil},
+				{"date_announcement_expires": bson.M{"$gt": now}},
+			}},
+		}},
+	}
+}
+
+// FindPinnedPosts Finds the pinned posts of a group ordered by pin date (the latest first)
+// This method doesn't construct tree hierarchy!
+func (sa *Adapter) FindPinnedPosts(context TransactionContext, clientID string, userID *string, groupID string, filterByToMembers bool) ([]model.Post, error) {
+	filter := activePinnedPostsFilter(clientID, groupID)
+
+	if filterByToMembers {
+		var labelIDs []string
+		if userID != nil {
+			var err error
+			labelIDs, err = sa.findMemberLabelIDs(context, clientID, groupID, *userID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		innerFilter := postAudienceFilter(userID, labelIDs)
+		if userID != nil {
+			innerFilter = append(innerFilter, primitive.M{"member.user_id": *userID})
+		}
+		filter = append(filter, primitive.E{Key: "$or", Value: innerFilter})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_pinned", Value: -1}})
+
+	posts := make([]model.Post, 0)
+	err := sa.db.posts.FindWithContext(context, filter, &posts, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// CountPinnedPosts Counts the pinned posts of a group. Expired announcements are not counted.
+func (sa *Adapter) CountPinnedPosts(context TransactionContext, clientID string, groupID string) (int64, error) {
+	return sa.db.posts.CountDocumentsWithContext(context, activePinnedPostsFilter(clientID, groupID))
+}
+
+// UpdatePostPinned Pins or unpins a post and sets its announcement flags
+func (sa *Adapter) UpdatePostPinned(context TransactionContext, clientID string, groupID string, postID string, pinned bool, pinnedBy *string,
+	isAnnouncement bool, dateAnnouncementExpires *time.Time) error {
+
+	wrapper := func(ctx TransactionContext) error {
+		var datePinned *time.Time
+		if pinned {
+			now := time.Now()
+			datePinned = &now
+		} else {
+			pinnedBy = nil
+			isAnnouncement = false
+			dateAnnouncementExpires = nil
+		}
+
+		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "_id", Value: postID},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "pinned", Value: pinned},
+				primitive.E{Key: "pinned_by", Value: pinnedBy},
+				primitive.E{Key: "date_pinned", Value: datePinned},
+				primitive.E{Key: "is_announcement", Value: isAnnouncement},
+				primitive.E{Key: "date_announcement_expires", Value: dateAnnouncementExpires},
+			}},
+		}
+
+		_, err := sa.db.posts.UpdateOneWithContext(ctx, filter, update, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, groupID, true, false, false, false)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
+}
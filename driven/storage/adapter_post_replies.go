@@ -0,0 +1,110 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// incrementPostRepliesCount updates the denormalized count of the direct replies of a post
+func (sa *Adapter) incrementPostRepliesCount(context TransactionContext, clientID string, postID string, value int) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "_id", Value: postID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$inc", Value: bson.D{
+			primitive.E{Key: "replies_count", Value: value},
+		}},
+	}
+
+	_, err := sa.db.posts.UpdateOneWithContext(context, filter, update, nil)
+	return err
+}
+
+// FindReplies Finds a page of the direct replies of a post. The nested replies are loaded up to the provided depth with the same limit on every level.
+// The replies which are not addressed to the user are skipped. The replies waiting for review or rejected are skipped unless the user is their creator or a group admin.
+func (sa *Adapter) FindReplies(context TransactionContext, clientID string, userID string, isAdmin bool, groupID string, parentID string,
+	offset *int64, limit *int64, depth int, order *string) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "parent_id", Value: parentID},
+		primitive.E{Key: "date_expired", Value: nil},
+	}
+
+	labelIDs, err := sa.findMemberLabelIDs(context, clientID, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	conditions := []bson.M{
+		{"$or": append(postAudienceFilter(&userID, labelIDs), bson.M{"member.user_id": userID})},
+	}
+	if !isAdmin {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"status": bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
+			{"member.user_id": userID},
+		}})
+	}
+	filter = append(filter, primitive.E{Key: "$and", Value: conditions})
+
+	findOptions := options.Find()
+	if order != nil && "asc" == *order {
+		findOptions.SetSort(bson.D{{Key: "date_created", Value: 1}})
+	} else {
+		findOptions.SetSort(bson.D{{Key: "date_created", Value: -1}})
+	}
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	posts := make([]model.Post, 0)
+	err = sa.db.posts.FindWithContext(context, filter, &posts, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range posts {
+		posts[i].Replies = make([]model.Post, 0)
+		if depth > 1 && posts[i].RepliesCount > 0 {
+			replies, err := sa.FindReplies(context, clientID, userID, isAdmin, groupID, posts[i].ID, nil, limit, depth-1, order)
+			if err != nil {
+				return nil, err
+			}
+			posts[i].Replies = replies
+		}
+	}
+
+	return posts, nil
+}
+
+// FindPostWithoutReplies Retrieves a post by groupID and postID without loading its replies
+func (sa *Adapter) FindPostWithoutReplies(context TransactionContext, clientID string, userID *string, groupID string, postID string, filterByToMembers bool) (*model.Post, error) {
+	post, err := sa.findPostWithContext(context, clientID, userID, groupID, postID, true, filterByToMembers)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil || post.GroupID != groupID {
+		return nil, nil
+	}
+	return post, nil
+}
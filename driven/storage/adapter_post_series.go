@@ -0,0 +1,135 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertPostSeries inserts a new recurring post series
+func (sa *Adapter) InsertPostSeries(series model.PostSeries) error {
+	_, err := sa.db.postSeries.InsertOne(series)
+	return err
+}
+
+// FindPostSeries finds a recurring post series by id
+func (sa *Adapter) FindPostSeries(context TransactionContext, clientID string, groupID string, id string) (*model.PostSeries, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "_id", Value: id},
+	}
+
+	var list []model.PostSeries
+	err := sa.db.postSeries.FindWithContext(context, filter, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &list[0], nil
+}
+
+// FindPostSeriesList finds the recurring post series of a group
+func (sa *Adapter) FindPostSeriesList(clientID string, groupID string, statuses []string) ([]model.PostSeries, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+	}
+	if len(statuses) > 0 {
+		filter = append(filter, primitive.E{Key: "status", Value: bson.M{"$in": statuses}})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_created", Value: -1}})
+
+	list := make([]model.PostSeries, 0)
+	err := sa.db.postSeries.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindDuePostSeries finds the active series which have an occurrence to publish
+func (sa *Adapter) FindDuePostSeries(context TransactionContext, now time.Time) ([]model.PostSeries, error) {
+	filter := bson.D{
+		primitive.E{Key: "status", Value: model.PostSeriesStatusActive},
+		primitive.E{Key: "date_next_occurrence", Value: bson.M{"$lte": now}},
+	}
+
+	var list []model.PostSeries
+	err := sa.db.postSeries.FindWithContext(context, filter, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ClaimPostSeriesOccurrence moves the series to its next occurrence. It returns false if the occurrence has already been claimed by another run.
+func (sa *Adapter) ClaimPostSeriesOccurrence(context TransactionContext, series model.PostSeries, occurrence time.Time, next *time.Time) (bool, error) {
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: series.ID},
+		primitive.E{Key: "status", Value: model.PostSeriesStatusActive},
+		primitive.E{Key: "date_next_occurrence", Value: occurrence},
+	}
+
+	status := model.PostSeriesStatusActive
+	if next == nil {
+		status = model.PostSeriesStatusCompleted
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: status},
+			primitive.E{Key: "date_next_occurrence", Value: next},
+			primitive.E{Key: "date_last_occurrence", Value: occurrence},
+			primitive.E{Key: "date_updated", Value: time.Now()},
+		}},
+		primitive.E{Key: "$inc", Value: bson.D{
+			primitive.E{Key: "occurrences_count", Value: 1},
+		}},
+	}
+
+	res, err := sa.db.postSeries.UpdateOneWithContext(context, filter, update, nil)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// UpdatePostSeriesStatus updates the status and the next occurrence of a series
+func (sa *Adapter) UpdatePostSeriesStatus(context TransactionContext, clientID string, groupID string, id string, status string, next *time.Time) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "_id", Value: id},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: status},
+			primitive.E{Key: "date_next_occurrence", Value: next},
+			primitive.E{Key: "date_updated", Value: time.Now()},
+		}},
+	}
+
+	_, err := sa.db.postSeries.UpdateOneWithContext(context, filter, update, nil)
+	return err
+}
@@ -0,0 +1,187 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindPostsToExpire Finds the top level posts which have reached their expiration date but are not hidden yet
+func (sa *Adapter) FindPostsToExpire(context TransactionContext, now time.Time) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "parent_id", Value: nil},
+		primitive.E{Key: "date_expires", Value: bson.M{"$lte": now}},
+		primitive.E{Key: "date_expired", Value: nil},
+	}
+
+	var posts []model.Post
+	err := sa.db.posts.FindWithContext(context, filter, &posts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// HideExpiredPost Hides the post and all of its replies
+func (sa *Adapter) HideExpiredPost(context TransactionContext, clientID string, groupID string, postID string, dateExpired time.Time) error {
+	wrapper := func(ctx TransactionContext) error {
+		postIDs, err := sa.findPostTreeIDs(ctx, clientID, groupID, postID)
+		if err != nil {
+			return err
+		}
+
+		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "_id", Value: bson.M{"$in": postIDs}},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "date_expired", Value: dateExpired},
+			}},
+		}
+
+		_, err = sa.db.posts.UpdateManyWithContext(ctx, filter, update, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, groupID, true, false, false, false)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
+}
+
+// FindExpiredPosts Finds the hidden top level posts of the group ordered by expiration
+// This method doesn't construct tree hierarchy!
+func (sa *Adapter) FindExpiredPosts(clientID string, groupID string, offset *int64, limit *int64) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "parent_id", Value: nil},
+		primitive.E{Key: "date_expired", Value: bson.M{"$ne": nil}},
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_expired", Value: -1}})
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	posts := make([]model.Post, 0)
+	err := sa.db.posts.Find(filter, &posts, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindPostsToPurge Finds the top level posts which have been hidden before the provided date
+func (sa *Adapter) FindPostsToPurge(context TransactionContext, expiredBefore time.Time) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "parent_id", Value: nil},
+		primitive.E{Key: "date_expired", Value: bson.M{"$lt": expiredBefore}},
+	}
+
+	var posts []model.Post
+	err := sa.db.posts.FindWithContext(context, filter, &posts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// findPostTreeIDs Finds the ids of the post and of all of its replies at any depth
+func (sa *Adapter) findPostTreeIDs(context TransactionContext, clientID string, groupID string, postID string) ([]string, error) {
+	type postRecord struct {
+		ID string `bson:"_id"`
+	}
+	findOptions := options.Find().SetProjection(bson.D{primitive.E{Key: "_id", Value: 1}})
+
+	// the replies are collected level by level as the older replies may miss their top parent id
+	postIDs := []string{postID}
+	parentIDs := []string{postID}
+	for len(parentIDs) > 0 {
+		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "parent_id", Value: bson.M{"$in": parentIDs}},
+		}
+		var replies []postRecord
+		err := sa.db.posts.FindWithContext(context, filter, &replies, findOptions)
+		if err != nil {
+			return nil, err
+		}
+		parentIDs = make([]string, len(replies))
+		for i, reply := range replies {
+			parentIDs[i] = reply.ID
+		}
+		postIDs = append(postIDs, parentIDs...)
+	}
+	return postIDs, nil
+}
+
+// PurgeExpiredPost Deletes the expired post together with all of its replies, their reactions and their seen records
+func (sa *Adapter) PurgeExpiredPost(context TransactionContext, clientID string, groupID string, postID string) error {
+	wrapper := func(ctx TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "_id", Value: postID},
+			primitive.E{Key: "date_expired", Value: bson.M{"$ne": nil}},
+		}
+		count, err := sa.db.posts.CountDocumentsWithContext(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+
+		// the reactions are kept within the posts so they are deleted together with them
+		postIDs, err := sa.findPostTreeIDs(ctx, clientID, groupID, postID)
+		if err != nil {
+			return err
+		}
+
+		_, err = sa.db.posts.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "_id", Value: bson.M{"$in": postIDs}}}, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = sa.db.postsSeen.DeleteManyWithContext(ctx, bson.D{primitive.E{Key: "post_id", Value: bson.M{"$in": postIDs}}}, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, groupID, true, false, false, false)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindPendingPosts Finds the posts which wait for a group admin review ordered by creation date
+// This method doesn't construct tree hierarchy!
+func (sa *Adapter) FindPendingPosts(clientID string, groupID string, offset *int64, limit *int64) ([]model.Post, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "status", Value: model.PostStatusPendingReview},
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_created", Value: 1}})
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	posts := make([]model.Post, 0)
+	err := sa.db.posts.Find(filter, &posts, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// UpdatePostStatus Updates the moderation status of a post
+func (sa *Adapter) UpdatePostStatus(context TransactionContext, clientID string, groupID string, postID string, status string, reviewedBy *string, rejectReason string) error {
+	wrapper := func(ctx TransactionContext) error {
+		now := time.Now()
+		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "_id", Value: postID},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "status", Value: status},
+				primitive.E{Key: "reviewed_by", Value: reviewedBy},
+				primitive.E{Key: "reject_reason", Value: rejectReason},
+				primitive.E{Key: "date_reviewed", Value: now},
+			}},
+		}
+
+		_, err := sa.db.posts.UpdateOneWithContext(ctx, filter, update, nil)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, groupID, true, false, false, false)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"groups/core/model"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateMembershipDateLastRead Moves forward the last read marker of the user within the group. It never moves it back.
+func (sa *Adapter) UpdateMembershipDateLastRead(clientID string, groupID string, userID string, dateLastRead time.Time) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "user_id", Value: userID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$max", Value: bson.D{
+			primitive.E{Key: "date_last_read", Value: dateLastRead},
+		}},
+	}
+
+	_, err := sa.db.groupMemberships.UpdateOne(filter, update, nil)
+	return err
+}
+
+// MarkPostSeen Records that the user has seen the post. The first seen date is kept on repeated calls.
+func (sa *Adapter) MarkPostSeen(clientID string, groupID string, postID string, userID string) error {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "post_id", Value: postID},
+		primitive.E{Key: "user_id", Value: userID},
+	}
+	update := bson.D{
+		primitive.E{Key: "$setOnInsert", Value: bson.D{
+			primitive.E{Key: "_id", Value: fmt.Sprintf("%s_%s", postID, userID)},
+			primitive.E{Key: "date_seen", Value: time.Now()},
+		}},
+	}
+
+	_, err := sa.db.postsSeen.UpdateOne(filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// FindPostSeenRecords Finds who has seen the post ordered by seen date
+func (sa *Adapter) FindPostSeenRecords(clientID string, groupID string, postID string, offset *int64, limit *int64) ([]model.PostSeen, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "post_id", Value: postID},
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "date_seen", Value: 1}})
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	records := make([]model.PostSeen, 0)
+	err := sa.db.postsSeen.Find(filter, &records, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// CountUnreadPosts Counts the posts created after the last read marker of the user for each of the provided memberships.
+// The own posts, the scheduled posts which are not published yet, the expired posts and the posts which are not addressed to the user are not counted.
+func (sa *Adapter) CountUnreadPosts(clientID string, userID string, memberships []model.GroupMembership) (map[string]int64, error) {
+	result := map[string]int64{}
+
+	groupFilters := []bson.M{}
+	for _, membership := range memberships {
+		if !membership.IsAdminOrMember() {
+			continue
+		}
+
+		dateLastRead := membership.DateCreated
+		if membership.DateLastRead != nil {
+			dateLastRead = *membership.DateLastRead
+		}
+		groupFilters = append(groupFilters, bson.M{
+			"group_id":     membership.GroupID,
+			"date_created": bson.M{"$gt": dateLastRead},
+			"$or":          postAudienceFilter(&userID, membership.LabelIDs),
+		})
+		result[membership.GroupID] = 0
+	}
+	if len(groupFilters) == 0 {
+		return result, nil
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "client_id", Value: clientID},
+			{Key: "member.user_id", Value: bson.M{"$ne": userID}},
+			{Key: "date_expired", Value: nil},
+			{Key: "$and", Value: []bson.M{{"$or": []bson.M{
+				{"date_scheduled": nil},
+				{"date_scheduled": bson.M{"$lte": time.Now()}},
+			}}}},
+			{Key: "status", Value: bson.M{"$nin": []string{model.PostStatusPendingReview, model.PostStatusRejected}}},
+			{Key: "$or", Value: groupFilters},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$group_id"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+
+	type aggregator struct {
+		GroupID string `bson:"_id"`
+		Count   int64  `bson:"count"`
+	}
+	var list []aggregator
+	err := sa.db.posts.Aggregate(pipeline, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range list {
+		result[item.GroupID] = item.Count
+	}
+
+	return result, nil
+}
+
+// DeletePostsSeenByAccountsIDs deletes the post seen records by accountsIDs
+func (sa *Adapter) DeletePostsSeenByAccountsIDs(log *logs.Logger, context TransactionContext, accountsIDs []string) error {
+	filter := bson.D{
+		primitive.E{Key: "user_id", Value: primitive.M{"$in": accountsIDs}},
+	}
+	_, err := sa.db.postsSeen.DeleteManyWithContext(context, filter, nil)
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateGroupProfileFields Replaces the member profile fields of a group. The values of the removed fields are removed from the memberships.
+func (sa *Adapter) UpdateGroupProfileFields(clientID string, groupID string, fields []model.MemberProfileField, removedKeys []string) error {
+	return sa.PerformTransaction(func(context TransactionContext) error {
+		res, err := sa.db.groups.UpdateOneWithContext(context, bson.D{
+			primitive.E{Key: "_id", Value: groupID},
+			primitive.E{Key: "client_id", Value: clientID},
+		}, bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "profile_fields", Value: fields},
+				primitive.E{Key: "date_updated", Value: time.Now()},
+			}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return errors.New("the group does not exist")
+		}
+
+		if len(removedKeys) == 0 {
+			return nil
+		}
+		unset := bson.D{}
+		for _, key := range removedKeys {
+			unset = append(unset, primitive.E{Key: "profile_values." + key, Value: ""})
+		}
+		_, err = sa.db.groupMemberships.UpdateManyWithContext(context, bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+		}, bson.D{primitive.E{Key: "$unset", Value: unset}}, nil)
+		return err
+	})
+}
+
+// UpdateMembershipProfileValues Sets the profile values of a membership. Nil values are removed.
+func (sa *Adapter) UpdateMembershipProfileValues(clientID string, membershipID string, values map[string]interface{}) error {
+	set := bson.D{primitive.E{Key: "date_updated", Value: time.Now()}}
+	unset := bson.D{}
+	for key, value := range values {
+		if value == nil {
+			unset = append(unset, primitive.E{Key: "profile_values." + key, Value: ""})
+		} else {
+			set = append(set, primitive.E{Key: "profile_values." + key, Value: value})
+		}
+	}
+	update := bson.D{primitive.E{Key: "$set", Value: set}}
+	if len(unset) > 0 {
+		update = append(update, primitive.E{Key: "$unset", Value: unset})
+	}
+
+	res, err := sa.db.groupMemberships.UpdateOne(bson.D{
+		primitive.E{Key: "_id", Value: membershipID},
+		primitive.E{Key: "client_id", Value: clientID},
+	}, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the membership does not exist")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"groups/core/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindReactionsConfig finds the reactions config for the specified clientID
+func (sa *Adapter) FindReactionsConfig(context TransactionContext, clientID string) (*model.ReactionsConfig, error) {
+	filter := bson.M{"type": "reactions", "client_id": clientID}
+
+	var configs []model.ReactionsConfig
+	err := sa.db.configs.FindWithContext(context, filter, &configs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return &configs[0], nil
+}
+
+// SaveReactionsConfig saves the provided reactions config
+func (sa *Adapter) SaveReactionsConfig(context TransactionContext, config model.ReactionsConfig) error {
+	filter := bson.M{"type": "reactions", "client_id": config.ClientID}
+
+	config.Type = "reactions"
+
+	upsert := true
+	opts := options.ReplaceOptions{Upsert: &upsert}
+	return sa.db.configs.ReplaceOneWithContext(context, filter, config, &opts)
+}
+
+// FindPostReactions Finds who has reacted to the post with what. The reactions are ordered by reaction and then by reaction time.
+func (sa *Adapter) FindPostReactions(clientID string, groupID string, postID string, reaction *string, offset *int64, limit *int64) ([]model.PostReaction, error) {
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "group_id", Value: groupID},
+			primitive.E{Key: "_id", Value: postID},
+		}}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "reaction", Value: bson.D{{Key: "$objectToArray", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$reactions", bson.D{}}}}}}},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$reaction"}},
+	}
+	if reaction != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "reaction.k", Value: *reaction}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "reaction.k", Value: 1}}}},
+		bson.D{{Key: "$unwind", Value: "$reaction.v"}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "reaction", Value: "$reaction.k"},
+			{Key: "user_id", Value: "$reaction.v"},
+		}}},
+	)
+	if offset != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: *offset}})
+	}
+	if limit != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: *limit}})
+	}
+
+	reactions := make([]model.PostReaction, 0)
+	err := sa.db.posts.Aggregate(pipeline, &reactions, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
@@ -6,6 +6,7 @@ import (
 	"groups/core/model"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -201,6 +202,10 @@ func (sa *Adapter) FindGroupMembershipsWithContext(ctx TransactionContext, clien
 	if filter.Name != nil {
 		matchFilter = append(matchFilter, bson.E{Key: "name", Value: primitive.Regex{Pattern: fmt.Sprintf(`%s`, *filter.Name), Options: "i"}})
 	}
+	if len(filter.LabelIDs) > 0 {
+		matchFilter = append(matchFilter, bson.E{Key: "label_ids", Value: bson.M{"$in": filter.LabelIDs}})
+	}
+	matchFilter = appendProfileValuesFilter(matchFilter, filter.ProfileValues)
 
 	findOptions := options.FindOptions{
 		Sort: bson.D{
@@ -266,11 +271,12 @@ func (sa *Adapter) FindUserGroupMembershipsWithContext(ctx TransactionContext, c
 }
 
 // CreatePendingMembership creates a pending membership for a specific group
-func (sa *Adapter) CreatePendingMembership(clientID string, user *model.User, group *model.Group, membership *model.GroupMembership) error {
+func (sa *Adapter) CreatePendingMembership(context TransactionContext, clientID string, user *model.User, group *model.Group, membership *model.GroupMembership) error {
 	if membership != nil && group != nil {
 
 		//1. check if the user is already a member of this group - pending or member or admin or rejected
-		storedMembership, err := sa.FindGroupMembership(clientID, group.ID, user.ID)
+		storedMembership, err := sa.FindGroupMembershipWithContext(context, clientID, group.ID, user.ID)
+		reopenRejected := false
 		if err == nil && storedMembership != nil {
 			switch storedMembership.Status {
 			case "admin":
@@ -280,7 +286,11 @@ func (sa *Adapter) CreatePendingMembership(clientID string, user *model.User, gr
 			case "pending":
 				return errors.New("the user is pending for the group")
 			case "rejected":
-				return errors.New("the user is rejected for the group")
+				// a new request of a rejected user makes the membership pending again
+				if membership.Status != model.MembershipStatusPending {
+					return errors.New("the user is rejected for the group")
+				}
+				reopenRejected = true
 			default:
 				return errors.New("error creating a pending user")
 			}
@@ -291,25 +301,78 @@ func (sa *Adapter) CreatePendingMembership(clientID string, user *model.User, gr
 			return errors.New("member answers mismatch")
 		}
 
+		if reopenRejected {
+			return sa.reopenRejectedMembership(context, clientID, storedMembership, membership)
+		}
+
 		membership.ID = uuid.NewString()
 		membership.ClientID = clientID
 		membership.GroupID = group.ID
 		membership.DateCreated = time.Now().UTC()
+		membership.SetSearchFields()
 
-		err = sa.PerformTransaction(func(context TransactionContext) error {
-			_, err := sa.db.groupMemberships.InsertOneWithContext(context, membership)
+		wrapper := func(ctx TransactionContext) error {
+			_, err := sa.db.groupMemberships.InsertOneWithContext(ctx, membership)
 			if err != nil {
 				return err
 			}
 
-			return sa.UpdateGroupStats(context, clientID, membership.GroupID, false, true, false, true)
-		})
+			return sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
+		}
+
+		if context != nil {
+			return wrapper(context)
+		}
+		return sa.PerformTransaction(wrapper)
+	}
+
+	return nil
+}
+
+// reopenRejectedMembership makes a rejected membership pending again with the data of the new request
+func (sa *Adapter) reopenRejectedMembership(context TransactionContext, clientID string, storedMembership *model.GroupMembership, membership *model.GroupMembership) error {
+	now := time.Now().UTC()
+	membership.ID = storedMembership.ID
+	membership.ClientID = clientID
+	membership.GroupID = storedMembership.GroupID
+	membership.DateCreated = storedMembership.DateCreated
+	membership.DateUpdated = &now
+	newTransitions := membership.StatusHistory
+	membership.StatusHistory = append(storedMembership.StatusHistory, newTransitions...)
+
+	wrapper := func(ctx TransactionContext) error {
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: storedMembership.ID},
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "status", Value: model.MembershipStatusRejected},
+		}
+		update := bson.D{
+			primitive.E{Key: "$set", Value: bson.D{
+				primitive.E{Key: "status", Value: membership.Status},
+				primitive.E{Key: "reject_reason", Value: ""},
+				primitive.E{Key: "member_answers", Value: membership.MemberAnswers},
+				primitive.E{Key: "notifications_preferences", Value: membership.NotificationsPreferences},
+				primitive.E{Key: "date_updated", Value: now},
+			}},
+			primitive.E{Key: "$push", Value: bson.D{
+				primitive.E{Key: "status_history", Value: bson.M{"$each": newTransitions}},
+			}},
+		}
+		res, err := sa.db.groupMemberships.UpdateOneWithContext(ctx, filter, update, nil)
 		if err != nil {
 			return err
 		}
+		if res.MatchedCount == 0 {
+			return errors.New("the membership status has changed meanwhile")
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
 	}
 
-	return nil
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
 }
 
 // SingleMembershipOperation wraps single membership operation for possible updates
@@ -325,10 +388,32 @@ type SingleMembershipOperation struct {
 	SyncID     *string
 }
 
-// BulkUpdateGroupMembershipsByExternalID Bulk update with a list of memberships
-func (sa *Adapter) BulkUpdateGroupMembershipsByExternalID(clientID string, groupID string, saveOperations []SingleMembershipOperation, updateGroupStats bool) error {
+// BulkUpdateGroupMembershipsByExternalID Bulk update with a list of memberships. Returns the memberships created by the update.
+func (sa *Adapter) BulkUpdateGroupMembershipsByExternalID(context TransactionContext, clientID string, groupID string, saveOperations []SingleMembershipOperation, updateGroupStats bool) ([]model.GroupMembership, error) {
 	now := time.Now()
 
+	// the current statuses are needed for the status histories
+	externalIDs := make([]string, len(saveOperations))
+	for i, operation := range saveOperations {
+		externalIDs[i] = operation.ExternalID
+	}
+	var existingMemberships []model.GroupMembership
+	err := sa.db.groupMemberships.FindWithContext(context, bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "group_id", Value: groupID},
+		primitive.E{Key: "external_id", Value: bson.M{"$in": externalIDs}},
+	}, &existingMemberships, options.Find().SetProjection(bson.D{
+		primitive.E{Key: "external_id", Value: 1},
+		primitive.E{Key: "status", Value: 1},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	currentStatuses := map[string]string{}
+	for _, membership := range existingMemberships {
+		currentStatuses[membership.ExternalID] = membership.Status
+	}
+
 	var updateModels []mongo.WriteModel
 	upsert := true
 	for _, operation := range saveOperations {
@@ -339,40 +424,81 @@ func (sa *Adapter) BulkUpdateGroupMembershipsByExternalID(clientID string, group
 		}
 		if operation.Name != nil {
 			update["name"] = *operation.Name
+			update["search_name"] = model.MemberSearchName(*operation.Name)
 		}
 		if operation.Email != nil {
 			update["email"] = *operation.Email
+			update["search_email"] = strings.ToLower(*operation.Email)
 		}
+		var transition *model.MembershipTransition
 		if operation.Status != nil {
-			update["status"] = *operation.Status
+			var transitionErr error
+			currentStatus := currentStatuses[operation.ExternalID]
+			if currentStatus != *operation.Status {
+				transition, transitionErr = model.NewMembershipTransition(currentStatus, *operation.Status, model.MembershipTransitionSourceSync, nil, "")
+				if transitionErr != nil {
+					log.Printf("skipping the status of %s in group %s - %s", operation.ExternalID, groupID, transitionErr)
+				}
+			}
+			if transitionErr == nil {
+				update["status"] = *operation.Status
+			}
 		}
 		if operation.SyncID != nil {
 			update["sync_id"] = *operation.SyncID
 		}
 		onInsert := bson.M{"_id": uuid.NewString(), "member_answers": operation.Answers, "date_created": now}
+		updateOperation := bson.M{"$set": update, "$setOnInsert": onInsert}
+		if transition != nil {
+			updateOperation["$push"] = bson.M{"status_history": *transition}
+		}
 		updateModels = append(updateModels, &mongo.UpdateOneModel{
 			Filter: filter,
-			Update: bson.M{"$set": update, "$setOnInsert": onInsert},
+			Update: updateOperation,
 			Upsert: &upsert,
 		})
 	}
 
-	if len(updateModels) > 0 {
-		return sa.PerformTransaction(func(context TransactionContext) error {
-			_, err := sa.db.groupMemberships.BulkWrite(updateModels, nil)
+	if len(updateModels) == 0 {
+		return nil, nil
+	}
+
+	var created []model.GroupMembership
+	wrapper := func(ctx TransactionContext) error {
+		result, err := sa.db.groupMemberships.BulkWriteWithContext(ctx, updateModels, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(result.UpsertedIDs) > 0 {
+			createdIDs := make([]interface{}, 0, len(result.UpsertedIDs))
+			for _, id := range result.UpsertedIDs {
+				createdIDs = append(createdIDs, id)
+			}
+			err = sa.db.groupMemberships.FindWithContext(ctx, bson.D{
+				primitive.E{Key: "_id", Value: bson.M{"$in": createdIDs}},
+			}, &created, nil)
 			if err != nil {
 				return err
 			}
+		}
 
-			if updateGroupStats {
-				return sa.UpdateGroupStats(context, clientID, groupID, false, false, true, true)
-			}
+		if updateGroupStats {
+			return sa.UpdateGroupStats(ctx, clientID, groupID, false, false, true, true)
+		}
 
-			return nil
-		})
+		return nil
 	}
 
-	return nil
+	if context != nil {
+		err = wrapper(context)
+	} else {
+		err = sa.PerformTransaction(wrapper)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 // SaveGroupMembershipByExternalID creates or updates a group membership for a given external ID
@@ -389,9 +515,11 @@ func (sa *Adapter) SaveGroupMembershipByExternalID(clientID string, groupID stri
 	}
 	if name != nil {
 		update["name"] = *name
+		update["search_name"] = model.MemberSearchName(*name)
 	}
 	if email != nil {
 		update["email"] = *email
+		update["search_email"] = strings.ToLower(*email)
 	}
 	if status != nil {
 		update["status"] = *status
@@ -426,7 +554,7 @@ func (sa *Adapter) SaveGroupMembershipByExternalID(clientID string, groupID stri
 }
 
 // CreateMembership Created a member to a group
-func (sa *Adapter) CreateMembership(clientID string, current *model.User, group *model.Group, membership *model.GroupMembership) error {
+func (sa *Adapter) CreateMembership(context TransactionContext, clientID string, current *model.User, group *model.Group, membership *model.GroupMembership) error {
 	if group != nil {
 
 		if len(membership.UserID) == 0 && len(membership.ExternalID) == 0 {
@@ -434,19 +562,19 @@ func (sa *Adapter) CreateMembership(clientID string, current *model.User, group
 			return fmt.Errorf("expected user_id or external_id")
 		}
 
-		existingMembership, err := sa.FindGroupMembership(clientID, group.ID, current.ID)
+		existingMembership, err := sa.FindGroupMembershipWithContext(context, clientID, group.ID, current.ID)
 		if err != nil || existingMembership == nil || !existingMembership.IsAdmin() {
 			log.Printf("error: storage.CreateMembership() - current user is not admin of the group")
 			return fmt.Errorf("current user is not admin of the group")
 		}
 
-		existingMembership, _ = sa.FindGroupMembership(clientID, group.ID, membership.UserID)
+		existingMembership, _ = sa.FindGroupMembershipWithContext(context, clientID, group.ID, membership.UserID)
 		if existingMembership != nil {
 			log.Printf("error: storage.CreateMembership() - member of group '%s' with user id %s already exists", group.Title, membership.UserID)
 			return fmt.Errorf("member of group '%s' with user id %s already exists", group.Title, membership.UserID)
 		}
 
-		existingMembership, _ = sa.FindGroupMembership(clientID, group.ID, membership.ExternalID)
+		existingMembership, _ = sa.FindGroupMembershipWithContext(context, clientID, group.ID, membership.ExternalID)
 		if existingMembership != nil {
 			log.Printf("error: storage.CreateMembership() - member of group '%s' with external id %s already exists", group.Title, membership.ExternalID)
 			return fmt.Errorf("member of group '%s' with external id %s already exists", group.Title, membership.ExternalID)
@@ -457,15 +585,21 @@ func (sa *Adapter) CreateMembership(clientID string, current *model.User, group
 		membership.GroupID = group.ID
 		membership.DateCreated = time.Now()
 		membership.MemberAnswers = group.CreateMembershipEmptyAnswers()
+		membership.SetSearchFields()
 
-		return sa.PerformTransaction(func(context TransactionContext) error {
-			_, err := sa.db.groupMemberships.InsertOne(membership)
+		wrapper := func(ctx TransactionContext) error {
+			_, err := sa.db.groupMemberships.InsertOneWithContext(ctx, membership)
 			if err != nil {
 				return err
 			}
 
-			return sa.UpdateGroupStats(context, clientID, membership.GroupID, false, true, false, true)
-		})
+			return sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
+		}
+
+		if context != nil {
+			return wrapper(context)
+		}
+		return sa.PerformTransaction(wrapper)
 	}
 
 	return nil
@@ -480,6 +614,7 @@ func (sa *Adapter) CreateMemberships(context TransactionContext, clientID string
 		memberships[index].ID = uuid.NewString()
 		memberships[index].ClientID = clientID
 		memberships[index].DateCreated = now
+		memberships[index].SetSearchFields()
 		if memberships[index].UserID != "" && memberships[index].ExternalID != "" && memberships[index].Email != "" && memberships[index].Status != "" {
 			objects = append(objects, memberships[index])
 		}
@@ -493,16 +628,20 @@ func (sa *Adapter) CreateMemberships(context TransactionContext, clientID string
 	return nil
 }
 
-// ApplyMembershipApproval applies a membership approval
-func (sa *Adapter) ApplyMembershipApproval(clientID string, membershipID string, approve bool, rejectReason string) (*model.GroupMembership, error) {
+// ApplyMembershipApproval applies a membership approval. The membership must still have the source status of the transition.
+func (sa *Adapter) ApplyMembershipApproval(context TransactionContext, clientID string, membershipID string, approve bool, rejectReason string, transition model.MembershipTransition) (*model.GroupMembership, error) {
 	var membership model.GroupMembership
-	err := sa.PerformTransaction(func(context TransactionContext) error {
+	wrapper := func(ctx TransactionContext) error {
 		status := "rejected"
 		if approve {
 			status = "member"
 		}
 
-		filter := bson.D{primitive.E{Key: "_id", Value: membershipID}, primitive.E{Key: "client_id", Value: clientID}}
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: membershipID},
+			primitive.E{Key: "client_id", Value: clientID},
+			primitive.E{Key: "status", Value: transition.FromStatus},
+		}
 		update := bson.D{
 			primitive.E{Key: "$set", Value: bson.D{
 				primitive.E{Key: "status", Value: status},
@@ -510,23 +649,33 @@ func (sa *Adapter) ApplyMembershipApproval(clientID string, membershipID string,
 				primitive.E{Key: "date_updated", Value: time.Now()},
 			},
 			},
+			primitive.E{Key: "$push", Value: bson.D{
+				primitive.E{Key: "status_history", Value: transition},
+			}},
 		}
 		after := options.After
-		err := sa.db.groupMemberships.FindOneAndUpdateWithContext(context, filter, update, &membership, &options.FindOneAndUpdateOptions{ReturnDocument: &after})
+		err := sa.db.groupMemberships.FindOneAndUpdateWithContext(ctx, filter, update, &membership, &options.FindOneAndUpdateOptions{ReturnDocument: &after})
 		if err != nil {
 			return err
 		}
 
-		sa.UpdateGroupStats(context, clientID, membership.GroupID, false, true, false, true)
+		sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
 
 		return err
-	})
+	}
+
+	var err error
+	if context != nil {
+		err = wrapper(context)
+	} else {
+		err = sa.PerformTransaction(wrapper)
+	}
 	return &membership, err
 }
 
-// UpdateMembership updates a membership
-func (sa *Adapter) UpdateMembership(clientID string, _ *model.User, membershipID string, membership *model.GroupMembership) error {
-	return sa.PerformTransaction(func(context TransactionContext) error {
+// UpdateMembership updates a membership. The transition is appended to the status history if the status changes.
+func (sa *Adapter) UpdateMembership(context TransactionContext, clientID string, _ *model.User, membershipID string, membership *model.GroupMembership, transition *model.MembershipTransition) error {
+	wrapper := func(ctx TransactionContext) error {
 		filter := bson.D{primitive.E{Key: "_id", Value: membershipID}, primitive.E{Key: "client_id", Value: clientID}}
 		update := bson.D{
 			primitive.E{Key: "$set", Value: bson.D{
@@ -538,21 +687,33 @@ func (sa *Adapter) UpdateMembership(clientID string, _ *model.User, membershipID
 			},
 			},
 		}
+		if transition != nil {
+			// the status must not have changed meanwhile
+			filter = append(filter, primitive.E{Key: "status", Value: transition.FromStatus})
+			update = append(update, primitive.E{Key: "$push", Value: bson.D{
+				primitive.E{Key: "status_history", Value: *transition},
+			}})
+		}
 		var membership model.GroupMembership
-		err := sa.db.groupMemberships.FindOneAndUpdateWithContext(context, filter, update, &membership, nil)
+		err := sa.db.groupMemberships.FindOneAndUpdateWithContext(ctx, filter, update, &membership, nil)
 		if err != nil {
 			return err
 		}
 
-		return sa.UpdateGroupStats(context, clientID, membership.GroupID, false, true, false, true)
-	})
+		return sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
+	}
 
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
 }
 
-// UpdateMemberships Updates multiple memberships for userids in a group
-func (sa *Adapter) UpdateMemberships(clientID string, user *model.User, groupID string, operation model.MembershipMultiUpdate) error {
-	return sa.PerformTransaction(func(context TransactionContext) error {
+// UpdateMemberships Updates multiple memberships for userids in a group. The transitions are appended to the status histories of their user ids.
+func (sa *Adapter) UpdateMemberships(context TransactionContext, clientID string, user *model.User, groupID string, operation model.MembershipMultiUpdate, transitions map[string]model.MembershipTransition) error {
+	wrapper := func(ctx TransactionContext) error {
 		filter := bson.D{
+			primitive.E{Key: "client_id", Value: clientID},
 			primitive.E{Key: "group_id", Value: groupID},
 			primitive.E{Key: "user_id", Value: bson.M{"$in": operation.UserIDs}},
 		}
@@ -571,15 +732,37 @@ func (sa *Adapter) UpdateMemberships(clientID string, user *model.User, groupID
 			update := bson.D{
 				primitive.E{Key: "$set", Value: operarions},
 			}
-			_, err := sa.db.groupMemberships.UpdateManyWithContext(context, filter, update, nil)
+			_, err := sa.db.groupMemberships.UpdateManyWithContext(ctx, filter, update, nil)
 			if err != nil {
 				return err
 			}
 
-			return sa.UpdateGroupStats(context, clientID, groupID, false, true, false, true)
+			for userID, transition := range transitions {
+				historyFilter := bson.D{
+					primitive.E{Key: "client_id", Value: clientID},
+					primitive.E{Key: "group_id", Value: groupID},
+					primitive.E{Key: "user_id", Value: userID},
+				}
+				historyUpdate := bson.D{
+					primitive.E{Key: "$push", Value: bson.D{
+						primitive.E{Key: "status_history", Value: transition},
+					}},
+				}
+				_, err = sa.db.groupMemberships.UpdateOneWithContext(ctx, historyFilter, historyUpdate, nil)
+				if err != nil {
+					return err
+				}
+			}
+
+			return sa.UpdateGroupStats(ctx, clientID, groupID, false, true, false, true)
 		}
 		return nil
-	})
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
 }
 
 // DeleteMembership deletes a member membership from a specific group
@@ -615,6 +798,10 @@ func (sa *Adapter) DeleteMembershipWithContext(ctx TransactionContext, clientID
 				log.Printf("error deleting membership - %s", err)
 				return err
 			}
+			err = sa.deleteMemberNotesOfMemberships(context, clientID, groupID, []string{currentMembership.ID})
+			if err != nil {
+				return err
+			}
 			return sa.UpdateGroupStats(context, clientID, groupID, false, true, false, true)
 		}
 		return nil
@@ -629,27 +816,37 @@ func (sa *Adapter) DeleteMembershipWithContext(ctx TransactionContext, clientID
 }
 
 // DeleteMembershipByID deletes a membership by ID
-func (sa *Adapter) DeleteMembershipByID(clientID string, current *model.User, membershipID string) error {
-	return sa.PerformTransaction(func(context TransactionContext) error {
+func (sa *Adapter) DeleteMembershipByID(context TransactionContext, clientID string, current *model.User, membershipID string) error {
+	wrapper := func(ctx TransactionContext) error {
 		membership, err := sa.FindGroupMembershipByID(clientID, membershipID)
 		if err != nil || membership == nil {
 			return fmt.Errorf("membership %s not found", membershipID)
 		}
 
 		filter := bson.D{primitive.E{Key: "_id", Value: membershipID}, primitive.E{Key: "client_id", Value: clientID}}
-		_, err = sa.db.groupMemberships.DeleteManyWithContext(context, filter, nil)
+		_, err = sa.db.groupMemberships.DeleteManyWithContext(ctx, filter, nil)
 		if err != nil {
 			return err
 		}
 
-		return sa.UpdateGroupStats(context, clientID, membership.GroupID, false, true, false, true)
-	})
+		err = sa.deleteMemberNotesOfMemberships(ctx, clientID, membership.GroupID, []string{membership.ID})
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, membership.GroupID, false, true, false, true)
+	}
+
+	if context != nil {
+		return wrapper(context)
+	}
+	return sa.PerformTransaction(wrapper)
 }
 
-// DeleteUnsyncedGroupMemberships deletes group memberships that do not exist in the latest sync
-func (sa *Adapter) DeleteUnsyncedGroupMemberships(clientID string, groupID string, syncID string) (int64, error) {
-	var deletedCount int64 = 0
-	err := sa.PerformTransaction(func(context TransactionContext) error {
+// DeleteUnsyncedGroupMemberships deletes group memberships that do not exist in the latest sync. Returns the deleted memberships.
+func (sa *Adapter) DeleteUnsyncedGroupMemberships(context TransactionContext, clientID string, groupID string, syncID string) ([]model.GroupMembership, error) {
+	var deletedMemberships []model.GroupMembership
+	wrapper := func(ctx TransactionContext) error {
 		filter := bson.M{
 			"client_id": clientID,
 			"group_id":  groupID,
@@ -657,19 +854,41 @@ func (sa *Adapter) DeleteUnsyncedGroupMemberships(clientID string, groupID strin
 			"status":    bson.M{"$ne": "admin"},
 		}
 
-		result, err := sa.db.groupMemberships.DeleteMany(filter, nil)
+		err := sa.db.groupMemberships.FindWithContext(ctx, filter, &deletedMemberships, nil)
 		if err != nil {
 			return err
 		}
+		if len(deletedMemberships) == 0 {
+			return nil
+		}
 
-		deletedCount = result.DeletedCount
-		if deletedCount > 0 {
-			return sa.UpdateGroupStats(context, clientID, groupID, false, false, true, true)
+		membershipIDs := make([]string, len(deletedMemberships))
+		for i, membership := range deletedMemberships {
+			membershipIDs[i] = membership.ID
+		}
+		_, err = sa.db.groupMemberships.DeleteManyWithContext(ctx, bson.M{"_id": bson.M{"$in": membershipIDs}}, nil)
+		if err != nil {
+			return err
 		}
 
-		return nil
-	})
-	return deletedCount, err
+		err = sa.deleteMemberNotesOfMemberships(ctx, clientID, groupID, membershipIDs)
+		if err != nil {
+			return err
+		}
+
+		return sa.UpdateGroupStats(ctx, clientID, groupID, false, false, true, true)
+	}
+
+	var err error
+	if context != nil {
+		err = wrapper(context)
+	} else {
+		err = sa.PerformTransaction(wrapper)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deletedMemberships, nil
 }
 
 // UpdateGroupSyncTimes updates a group uses group membership
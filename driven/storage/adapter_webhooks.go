@@ -0,0 +1,237 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"groups/core/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindWebhookSubscriptions finds the webhook subscriptions of the client
+func (sa *Adapter) FindWebhookSubscriptions(context TransactionContext, clientID string) ([]model.WebhookSubscription, error) {
+	filter := bson.D{primitive.E{Key: "client_id", Value: clientID}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: 1}})
+
+	list := make([]model.WebhookSubscription, 0)
+	err := sa.db.webhookSubscriptions.FindWithContext(context, filter, &list, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindWebhookSubscription finds a webhook subscription by ID
+func (sa *Adapter) FindWebhookSubscription(clientID string, id string) (*model.WebhookSubscription, error) {
+	filter := bson.D{primitive.E{Key: "_id", Value: id}, primitive.E{Key: "client_id", Value: clientID}}
+
+	var list []model.WebhookSubscription
+	err := sa.db.webhookSubscriptions.Find(filter, &list, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &list[0], nil
+}
+
+// InsertWebhookSubscription inserts a new webhook subscription
+func (sa *Adapter) InsertWebhookSubscription(subscription model.WebhookSubscription) error {
+	_, err := sa.db.webhookSubscriptions.InsertOne(subscription)
+	return err
+}
+
+// UpdateWebhookSubscription updates an existing webhook subscription. The secret is changed only if it is provided.
+func (sa *Adapter) UpdateWebhookSubscription(subscription model.WebhookSubscription) error {
+	filter := bson.D{primitive.E{Key: "_id", Value: subscription.ID}, primitive.E{Key: "client_id", Value: subscription.ClientID}}
+	set := bson.D{
+		primitive.E{Key: "name", Value: subscription.Name},
+		primitive.E{Key: "url", Value: subscription.URL},
+		primitive.E{Key: "event_types", Value: subscription.EventTypes},
+		primitive.E{Key: "active", Value: subscription.Active},
+		primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+	}
+	if subscription.Secret != "" {
+		set = append(set, primitive.E{Key: "secret", Value: subscription.Secret})
+	}
+
+	res, err := sa.db.webhookSubscriptions.UpdateOne(filter, bson.D{primitive.E{Key: "$set", Value: set}}, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return fmt.Errorf("webhook subscription could not be found for id: %s", subscription.ID)
+	}
+	return nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription together with its delivery log
+func (sa *Adapter) DeleteWebhookSubscription(clientID string, id string) error {
+	transaction := func(context TransactionContext) error {
+		filter := bson.D{primitive.E{Key: "_id", Value: id}, primitive.E{Key: "client_id", Value: clientID}}
+		res, err := sa.db.webhookSubscriptions.DeleteOneWithContext(context, filter, nil)
+		if err != nil {
+			return err
+		}
+		if res.DeletedCount != 1 {
+			return fmt.Errorf("webhook subscription could not be found for id: %s", id)
+		}
+
+		_, err = sa.db.webhookDeliveries.DeleteManyWithContext(context, bson.D{primitive.E{Key: "subscription_id", Value: id}}, nil)
+		return err
+	}
+
+	return sa.PerformTransaction(transaction)
+}
+
+// InsertWebhookDeliveries inserts new webhook deliveries
+func (sa *Adapter) InsertWebhookDeliveries(context TransactionContext, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		documents[i] = delivery
+	}
+	_, err := sa.db.webhookDeliveries.InsertManyWithContext(context, documents, nil)
+	return err
+}
+
+// ClaimWebhookDelivery claims a pending delivery which is due for sending. The next attempt date of the
+// claimed delivery is moved forward with the provided lease so that it is not sent concurrently.
+// Returns nil if there is no due delivery.
+func (sa *Adapter) ClaimWebhookDelivery(now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	filter := bson.D{
+		primitive.E{Key: "status", Value: model.WebhookDeliveryStatusPending},
+		primitive.E{Key: "date_next_attempt", Value: bson.M{"$lte": now}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "date_next_attempt", Value: now.Add(lease)},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{primitive.E{Key: "date_next_attempt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery model.WebhookDelivery
+	err := sa.db.webhookDeliveries.FindOneAndUpdate(filter, update, &delivery, opts)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// MarkWebhookDeliveryDelivered marks the delivery as accepted by the receiver
+func (sa *Adapter) MarkWebhookDeliveryDelivered(id string, attempts int, statusCode int) error {
+	now := time.Now().UTC()
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: model.WebhookDeliveryStatusDelivered},
+			primitive.E{Key: "attempts", Value: attempts},
+			primitive.E{Key: "last_error", Value: ""},
+			primitive.E{Key: "last_status_code", Value: statusCode},
+			primitive.E{Key: "date_delivered", Value: now},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+
+	_, err := sa.db.webhookDeliveries.UpdateOne(filter, update, nil)
+	return err
+}
+
+// MarkWebhookDeliveryFailed records a failed attempt of the delivery
+func (sa *Adapter) MarkWebhookDeliveryFailed(id string, attempts int, statusCode int, lastError string, status string, dateNextAttempt time.Time) error {
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: status},
+			primitive.E{Key: "attempts", Value: attempts},
+			primitive.E{Key: "last_error", Value: lastError},
+			primitive.E{Key: "last_status_code", Value: statusCode},
+			primitive.E{Key: "date_next_attempt", Value: dateNextAttempt},
+			primitive.E{Key: "date_updated", Value: time.Now().UTC()},
+		}},
+	}
+
+	_, err := sa.db.webhookDeliveries.UpdateOne(filter, update, nil)
+	return err
+}
+
+// FindWebhookDeliveries finds the delivery log of the subscription by optional status ordered by creation date descending
+func (sa *Adapter) FindWebhookDeliveries(clientID string, subscriptionID string, status *string, offset *int64, limit *int64) ([]model.WebhookDelivery, error) {
+	filter := bson.D{
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "subscription_id", Value: subscriptionID},
+	}
+	if status != nil {
+		filter = append(filter, primitive.E{Key: "status", Value: *status})
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "date_created", Value: -1}})
+	if offset != nil {
+		findOptions.SetSkip(*offset)
+	}
+	if limit != nil {
+		findOptions.SetLimit(*limit)
+	}
+
+	list := make([]model.WebhookDelivery, 0)
+	err := sa.db.webhookDeliveries.Find(filter, &list, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ReplayWebhookDelivery moves a delivered or dead-lettered delivery back to the pending state for immediate sending.
+// Returns false if there is no such delivery.
+func (sa *Adapter) ReplayWebhookDelivery(clientID string, id string) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "client_id", Value: clientID},
+		primitive.E{Key: "status", Value: bson.M{"$ne": model.WebhookDeliveryStatusPending}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: model.WebhookDeliveryStatusPending},
+			primitive.E{Key: "attempts", Value: 0},
+			primitive.E{Key: "date_next_attempt", Value: now},
+			primitive.E{Key: "date_updated", Value: now},
+		}},
+	}
+
+	res, err := sa.db.webhookDeliveries.UpdateOne(filter, update, nil)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
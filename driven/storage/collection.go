@@ -56,6 +56,20 @@ func (collWrapper *collectionWrapper) FindWithContext(ctx context.Context, filte
 	return err
 }
 
+// FindCursor returns a cursor over the found documents. The cursor is not limited by the mongo timeout so that big collections can be iterated.
+// The caller must close the cursor.
+func (collWrapper *collectionWrapper) FindCursor(ctx context.Context, filter interface{}, findOptions *options.FindOptions) (*mongo.Cursor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	return collWrapper.coll.Find(ctx, filter, findOptions)
+}
+
 func (collWrapper *collectionWrapper) FindOne(filter interface{}, result interface{}, findOptions *options.FindOneOptions) error {
 	return collWrapper.FindOneWithContext(context.Background(), filter, result, findOptions)
 }
@@ -429,6 +443,16 @@ func (collWrapper *collectionWrapper) DropIndex(name string) error {
 	return err
 }
 
+// AggregateCursor returns a cursor over the aggregation results. The cursor is not limited by the mongo timeout so that big collections can be aggregated.
+// The caller must close the cursor.
+func (collWrapper *collectionWrapper) AggregateCursor(ctx context.Context, pipeline interface{}, ops *options.AggregateOptions) (*mongo.Cursor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return collWrapper.coll.Aggregate(ctx, pipeline, ops)
+}
+
 func (collWrapper *collectionWrapper) Aggregate(pipeline interface{}, result interface{}, ops *options.AggregateOptions) error {
 	return collWrapper.AggregateWithContext(context.Background(), pipeline, result, ops)
 }
@@ -35,15 +35,27 @@ type database struct {
 	db       *mongo.Database
 	dbClient *mongo.Client
 
-	configs             *collectionWrapper
-	syncTimes           *collectionWrapper
-	enums               *collectionWrapper
-	groups              *collectionWrapper
-	groupMemberships    *collectionWrapper
-	events              *collectionWrapper
-	posts               *collectionWrapper
-	managedGroupConfigs *collectionWrapper
-	users               *collectionWrapper
+	configs                   *collectionWrapper
+	syncTimes                 *collectionWrapper
+	enums                     *collectionWrapper
+	groups                    *collectionWrapper
+	groupMemberships          *collectionWrapper
+	events                    *collectionWrapper
+	posts                     *collectionWrapper
+	managedGroupConfigs       *collectionWrapper
+	users                     *collectionWrapper
+	postsSeen                 *collectionWrapper
+	contentFilters            *collectionWrapper
+	abuseReports              *collectionWrapper
+	postSeries                *collectionWrapper
+	notificationOutbox        *collectionWrapper
+	notificationTemplates     *collectionWrapper
+	webhookSubscriptions      *collectionWrapper
+	webhookDeliveries         *collectionWrapper
+	attendanceSessions        *collectionWrapper
+	attendanceRecords         *collectionWrapper
+	attendanceCheckInFailures *collectionWrapper
+	memberNotes               *collectionWrapper
 
 	listeners []Listener
 }
@@ -125,6 +137,78 @@ func (m *database) start() error {
 		return err
 	}
 
+	postsSeen := &collectionWrapper{database: m, coll: db.Collection("posts_seen")}
+	err = m.applyPostsSeenChecks(postsSeen)
+	if err != nil {
+		return err
+	}
+
+	contentFilters := &collectionWrapper{database: m, coll: db.Collection("content_filters")}
+	err = m.applyContentFiltersChecks(contentFilters)
+	if err != nil {
+		return err
+	}
+
+	abuseReports := &collectionWrapper{database: m, coll: db.Collection("abuse_reports")}
+	err = m.applyAbuseReportsChecks(abuseReports)
+	if err != nil {
+		return err
+	}
+
+	postSeries := &collectionWrapper{database: m, coll: db.Collection("post_series")}
+	err = m.applyPostSeriesChecks(postSeries)
+	if err != nil {
+		return err
+	}
+
+	notificationOutbox := &collectionWrapper{database: m, coll: db.Collection("notification_outbox")}
+	err = m.applyNotificationOutboxChecks(notificationOutbox)
+	if err != nil {
+		return err
+	}
+
+	notificationTemplates := &collectionWrapper{database: m, coll: db.Collection("notification_templates")}
+	err = m.applyNotificationTemplatesChecks(notificationTemplates)
+	if err != nil {
+		return err
+	}
+
+	webhookSubscriptions := &collectionWrapper{database: m, coll: db.Collection("webhook_subscriptions")}
+	err = m.applyWebhookSubscriptionsChecks(webhookSubscriptions)
+	if err != nil {
+		return err
+	}
+
+	webhookDeliveries := &collectionWrapper{database: m, coll: db.Collection("webhook_deliveries")}
+	err = m.applyWebhookDeliveriesChecks(webhookDeliveries)
+	if err != nil {
+		return err
+	}
+
+	attendanceSessions := &collectionWrapper{database: m, coll: db.Collection("attendance_sessions")}
+	err = m.applyAttendanceSessionsChecks(attendanceSessions)
+	if err != nil {
+		return err
+	}
+
+	attendanceRecords := &collectionWrapper{database: m, coll: db.Collection("attendance_records")}
+	err = m.applyAttendanceRecordsChecks(attendanceRecords)
+	if err != nil {
+		return err
+	}
+
+	attendanceCheckInFailures := &collectionWrapper{database: m, coll: db.Collection("attendance_check_in_failures")}
+	err = m.applyAttendanceCheckInFailuresChecks(attendanceCheckInFailures)
+	if err != nil {
+		return err
+	}
+
+	memberNotes := &collectionWrapper{database: m, coll: db.Collection("member_notes")}
+	err = m.applyMemberNotesChecks(memberNotes)
+	if err != nil {
+		return err
+	}
+
 	//apply multi-tenant
 	err = m.applyMultiTenantChecks(client, users, groups, events)
 	if err != nil {
@@ -148,6 +232,26 @@ func (m *database) start() error {
 		return err
 	}
 
+	err = m.ApplyMembershipsSearchFieldsTransition(groupMemberships)
+	if err != nil {
+		return err
+	}
+
+	err = m.ApplyPostsRepliesCountTransition(posts)
+	if err != nil {
+		return err
+	}
+
+	err = m.ApplyPostsReactionCountsTransition(posts)
+	if err != nil {
+		return err
+	}
+
+	err = m.ApplyBodyHTMLTransition(posts, groups)
+	if err != nil {
+		return err
+	}
+
 	//asign the db, db client and the collections
 	m.db = db
 	m.dbClient = client
@@ -161,15 +265,47 @@ func (m *database) start() error {
 	m.posts = posts
 	m.managedGroupConfigs = managedGroupConfigs
 	m.users = users
+	m.postsSeen = postsSeen
+	m.contentFilters = contentFilters
+	m.abuseReports = abuseReports
+	m.postSeries = postSeries
+	m.notificationOutbox = notificationOutbox
+	m.notificationTemplates = notificationTemplates
+	m.webhookSubscriptions = webhookSubscriptions
+	m.webhookDeliveries = webhookDeliveries
+
+	// the change feed needs the pre-images to filter and to describe the deleted documents
+	m.enableChangeStreamPreImages(groups, groupMemberships, posts)
+	m.attendanceSessions = attendanceSessions
+	m.attendanceRecords = attendanceRecords
+	m.attendanceCheckInFailures = attendanceCheckInFailures
+	m.memberNotes = memberNotes
 
 	go m.configs.Watch(nil)
 	go m.managedGroupConfigs.Watch(nil)
+	go m.contentFilters.Watch(nil)
 
 	m.listeners = []Listener{}
 
 	return nil
 }
 
+func (m *database) enableChangeStreamPreImages(collections ...*collectionWrapper) {
+	for _, collection := range collections {
+		ctx, cancel := context.WithTimeout(context.Background(), m.mongoTimeout)
+		command := bson.D{
+			primitive.E{Key: "collMod", Value: collection.coll.Name()},
+			primitive.E{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}},
+		}
+		err := m.db.RunCommand(ctx, command).Err()
+		cancel()
+		if err != nil {
+			// not supported before MongoDB 6.0 - the deletions are not available in the change feed then
+			log.Printf("error enabling the change stream pre-images for %s - %s", collection.coll.Name(), err)
+		}
+	}
+}
+
 func (m *database) applyConfigsChecks(configs *collectionWrapper) error {
 	log.Println("apply configs checks.....")
 
@@ -470,6 +606,11 @@ func (m *database) applyGroupMembershipsChecks(groupMemberships *collectionWrapp
 		return err
 	}
 
+	err = groupMemberships.AddIndex(bson.D{primitive.E{Key: "notifications_preferences.digest_frequency", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
 	err = groupMemberships.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}}, false)
 	if err != nil {
 		return err
@@ -488,6 +629,35 @@ func (m *database) applyGroupMembershipsChecks(groupMemberships *collectionWrapp
 		return err
 	}
 
+	// the member search within a group
+	for _, field := range []string{"name", "search_name", "search_net_id", "search_email", "date_created", "date_last_read"} {
+		err = groupMemberships.AddIndex(bson.D{
+			primitive.E{Key: "client_id", Value: 1},
+			primitive.E{Key: "group_id", Value: 1},
+			primitive.E{Key: field, Value: 1},
+		}, false)
+		if err != nil {
+			return err
+		}
+	}
+	err = groupMemberships.AddIndex(bson.D{
+		primitive.E{Key: "client_id", Value: 1},
+		primitive.E{Key: "group_id", Value: 1},
+		primitive.E{Key: "status", Value: 1},
+		primitive.E{Key: "name", Value: 1},
+	}, false)
+	if err != nil {
+		return err
+	}
+	err = groupMemberships.AddIndex(bson.D{
+		primitive.E{Key: "client_id", Value: 1},
+		primitive.E{Key: "group_id", Value: 1},
+		primitive.E{Key: "label_ids", Value: 1},
+	}, false)
+	if err != nil {
+		return err
+	}
+
 	log.Println("group memberships checks passed")
 	return nil
 }
@@ -716,6 +886,69 @@ func (m *database) applyPostsChecks(posts *collectionWrapper) error {
 		}
 	}
 
+	if indexMapping["group_id_1_date_created_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "group_id", Value: 1},
+				primitive.E{Key: "date_created", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["date_expires_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "date_expires", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["date_expired_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "date_expired", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["origin_id_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "origin_id", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["group_id_1_status_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "group_id", Value: 1},
+				primitive.E{Key: "status", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if indexMapping["group_id_1_pinned_1"] == nil {
+		err := posts.AddIndex(
+			bson.D{
+				primitive.E{Key: "group_id", Value: 1},
+				primitive.E{Key: "pinned", Value: 1},
+			}, false)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Println("posts checks passed")
 	return nil
 }
@@ -729,6 +962,265 @@ func (m *database) applyManagedGroupConfigsChecks(managedGroupConfigs *collectio
 	return nil
 }
 
+func (m *database) applyPostsSeenChecks(postsSeen *collectionWrapper) error {
+	log.Println("apply posts seen checks.....")
+
+	err := postsSeen.AddIndex(bson.D{primitive.E{Key: "post_id", Value: 1}, primitive.E{Key: "user_id", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	err = postsSeen.AddIndex(bson.D{primitive.E{Key: "user_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("posts seen checks passed")
+	return nil
+}
+
+func (m *database) applyContentFiltersChecks(contentFilters *collectionWrapper) error {
+	log.Println("apply content filters checks.....")
+
+	err := contentFilters.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("content filters checks passed")
+	return nil
+}
+
+func (m *database) applyAbuseReportsChecks(abuseReports *collectionWrapper) error {
+	log.Println("apply abuse reports checks.....")
+
+	err := abuseReports.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "target_id", Value: 1}, primitive.E{Key: "status", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = abuseReports.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "status", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the cases created before the active flag was introduced
+	_, err = abuseReports.UpdateMany(bson.D{
+		primitive.E{Key: "status", Value: bson.M{"$in": []string{model.AbuseReportStatusOpen, model.AbuseReportStatusReviewing}}},
+		primitive.E{Key: "active", Value: bson.M{"$exists": false}},
+	}, bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "active", Value: true}}}}, nil)
+	if err != nil {
+		return err
+	}
+
+	// only one active case per target
+	err = abuseReports.AddIndexWithOptions(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "target_id", Value: 1}},
+		options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{primitive.E{Key: "active", Value: true}}))
+	if err != nil {
+		return err
+	}
+
+	log.Println("abuse reports checks passed")
+	return nil
+}
+
+func (m *database) applyPostSeriesChecks(postSeries *collectionWrapper) error {
+	log.Println("apply post series checks.....")
+
+	err := postSeries.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}, primitive.E{Key: "date_next_occurrence", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = postSeries.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("post series checks passed")
+	return nil
+}
+
+func (m *database) applyNotificationOutboxChecks(notificationOutbox *collectionWrapper) error {
+	log.Println("apply notification outbox checks.....")
+
+	err := notificationOutbox.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}, primitive.E{Key: "date_next_attempt", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = notificationOutbox.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "status", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the delivered notifications are kept for a week
+	indexes, _ := notificationOutbox.ListIndexes()
+	indexMapping := map[string]interface{}{}
+	if indexes != nil {
+		for _, index := range indexes {
+			name := index["name"].(string)
+			indexMapping[name] = index
+		}
+	}
+	if indexMapping["date_sent_1"] == nil {
+		expireAfterSeconds := int32(7 * 24 * 60 * 60)
+		err = notificationOutbox.AddIndexWithOptions(
+			bson.D{primitive.E{Key: "date_sent", Value: 1}},
+			&options.IndexOptions{ExpireAfterSeconds: &expireAfterSeconds})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("notification outbox checks passed")
+	return nil
+}
+
+func (m *database) applyNotificationTemplatesChecks(notificationTemplates *collectionWrapper) error {
+	log.Println("apply notification templates checks.....")
+
+	err := notificationTemplates.AddIndex(bson.D{
+		primitive.E{Key: "client_id", Value: 1},
+		primitive.E{Key: "operation", Value: 1},
+		primitive.E{Key: "group_id", Value: 1},
+		primitive.E{Key: "locale", Value: 1},
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	log.Println("notification templates checks passed")
+	return nil
+}
+
+func (m *database) applyWebhookSubscriptionsChecks(webhookSubscriptions *collectionWrapper) error {
+	log.Println("apply webhook subscriptions checks.....")
+
+	err := webhookSubscriptions.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("webhook subscriptions checks passed")
+	return nil
+}
+
+func (m *database) applyWebhookDeliveriesChecks(webhookDeliveries *collectionWrapper) error {
+	log.Println("apply webhook deliveries checks.....")
+
+	err := webhookDeliveries.AddIndex(bson.D{primitive.E{Key: "status", Value: 1}, primitive.E{Key: "date_next_attempt", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = webhookDeliveries.AddIndex(bson.D{primitive.E{Key: "subscription_id", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the delivery log is kept for 30 days
+	indexes, _ := webhookDeliveries.ListIndexes()
+	indexMapping := map[string]interface{}{}
+	if indexes != nil {
+		for _, index := range indexes {
+			name := index["name"].(string)
+			indexMapping[name] = index
+		}
+	}
+	if indexMapping["date_created_1"] == nil {
+		expireAfterSeconds := int32(30 * 24 * 60 * 60)
+		err = webhookDeliveries.AddIndexWithOptions(
+			bson.D{primitive.E{Key: "date_created", Value: 1}},
+			&options.IndexOptions{ExpireAfterSeconds: &expireAfterSeconds})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("webhook deliveries checks passed")
+	return nil
+}
+
+func (m *database) applyAttendanceSessionsChecks(attendanceSessions *collectionWrapper) error {
+	log.Println("apply attendance sessions checks.....")
+
+	err := attendanceSessions.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}, primitive.E{Key: "date", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("attendance sessions checks passed")
+	return nil
+}
+
+func (m *database) applyAttendanceRecordsChecks(attendanceRecords *collectionWrapper) error {
+	log.Println("apply attendance records checks.....")
+
+	// one check-in per member and session
+	err := attendanceRecords.AddIndex(bson.D{primitive.E{Key: "session_id", Value: 1}, primitive.E{Key: "membership_id", Value: 1}}, true)
+	if err != nil {
+		return err
+	}
+
+	err = attendanceRecords.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("attendance records checks passed")
+	return nil
+}
+
+func (m *database) applyAttendanceCheckInFailuresChecks(attendanceCheckInFailures *collectionWrapper) error {
+	log.Println("apply attendance check-in failures checks.....")
+
+	err := attendanceCheckInFailures.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}, primitive.E{Key: "user_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the failures are needed only for the rate limiting
+	indexes, _ := attendanceCheckInFailures.ListIndexes()
+	indexMapping := map[string]interface{}{}
+	if indexes != nil {
+		for _, index := range indexes {
+			name := index["name"].(string)
+			indexMapping[name] = index
+		}
+	}
+	if indexMapping["date_created_1"] == nil {
+		expireAfterSeconds := int32(24 * 60 * 60)
+		err = attendanceCheckInFailures.AddIndexWithOptions(
+			bson.D{primitive.E{Key: "date_created", Value: 1}},
+			&options.IndexOptions{ExpireAfterSeconds: &expireAfterSeconds})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("attendance check-in failures checks passed")
+	return nil
+}
+
+func (m *database) applyMemberNotesChecks(memberNotes *collectionWrapper) error {
+	log.Println("apply member notes checks.....")
+
+	err := memberNotes.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}, primitive.E{Key: "membership_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// the retained notes are attached again by the user when they rejoin the group
+	err = memberNotes.AddIndex(bson.D{primitive.E{Key: "client_id", Value: 1}, primitive.E{Key: "group_id", Value: 1}, primitive.E{Key: "user_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	log.Println("member notes checks passed")
+	return nil
+}
+
 func (m *database) applyMultiTenantChecks(client *mongo.Client, users *collectionWrapper, groups *collectionWrapper, events *collectionWrapper) error {
 	log.Println("apply multi-tenant checks.....")
 
@@ -1026,6 +1518,235 @@ func (m *database) ApplyGroupsAttributesTransition(client *mongo.Client, groups
 	return nil
 }
 
+// transitionBatchSize is the count of the documents updated with one bulk write by the migrations
+const transitionBatchSize = 500
+
+// ApplyBodyHTMLTransition renders the safe HTML of the post bodies and the group descriptions which don't have it yet.
+// The documents which cannot be rendered are skipped so that they never get an empty HTML.
+func (m *database) ApplyBodyHTMLTransition(posts *collectionWrapper, groups *collectionWrapper) error {
+	log.Println("apply body html migration.....")
+
+	err := m.applyFindTransition(posts, bson.D{primitive.E{Key: "body_html", Value: bson.M{"$exists": false}}},
+		bson.D{primitive.E{Key: "body", Value: 1}, primitive.E{Key: "body_format", Value: 1}},
+		func(cursor *mongo.Cursor) (mongo.WriteModel, error) {
+			var post model.Post
+			err := cursor.Decode(&post)
+			if err != nil {
+				return nil, err
+			}
+			err = post.RenderBody()
+			if err != nil {
+				log.Printf("skipping the body html of post %s - %s", post.ID, err)
+				return nil, nil
+			}
+			return mongo.NewUpdateOneModel().SetFilter(bson.D{primitive.E{Key: "_id", Value: post.ID}}).
+				SetUpdate(bson.D{primitive.E{Key: "$set", Value: bson.D{
+					primitive.E{Key: "body_format", Value: post.BodyFormat},
+					primitive.E{Key: "body_html", Value: post.BodyHTML},
+				}}}), nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = m.applyFindTransition(groups, bson.D{primitive.E{Key: "description_html", Value: bson.M{"$exists": false}}},
+		bson.D{primitive.E{Key: "description", Value: 1}, primitive.E{Key: "description_format", Value: 1}},
+		func(cursor *mongo.Cursor) (mongo.WriteModel, error) {
+			var group model.Group
+			err := cursor.Decode(&group)
+			if err != nil {
+				return nil, err
+			}
+			err = group.RenderDescription()
+			if err != nil {
+				log.Printf("skipping the description html of group %s - %s", group.ID, err)
+				return nil, nil
+			}
+			return mongo.NewUpdateOneModel().SetFilter(bson.D{primitive.E{Key: "_id", Value: group.ID}}).
+				SetUpdate(bson.D{primitive.E{Key: "$set", Value: bson.D{
+					primitive.E{Key: "description_format", Value: group.DescriptionFormat},
+					primitive.E{Key: "description_html", Value: group.DescriptionHTML},
+				}}}), nil
+		})
+	if err != nil {
+		return err
+	}
+
+	log.Println("body html migration passed")
+	return nil
+}
+
+// applyFindTransition iterates the matching documents and stores the updates built for them with batched bulk writes. A nil update skips the document.
+func (m *database) applyFindTransition(coll *collectionWrapper, filter bson.D, projection bson.D, build func(cursor *mongo.Cursor) (mongo.WriteModel, error)) error {
+	cursor, err := coll.FindCursor(context.Background(), filter, options.Find().SetProjection(projection).SetBatchSize(transitionBatchSize))
+	if err != nil {
+		return err
+	}
+	return m.applyCursorTransition(coll, cursor, build)
+}
+
+// applyCursorTransition iterates the cursor and stores the updates built for its documents with batched bulk writes. A nil update skips the document.
+// The migrations run without a transaction so they must be safe to run again after a failure. The cursor is closed.
+func (m *database) applyCursorTransition(coll *collectionWrapper, cursor *mongo.Cursor, build func(cursor *mongo.Cursor) (mongo.WriteModel, error)) error {
+	ctx := context.Background()
+	defer cursor.Close(ctx)
+
+	updates := make([]mongo.WriteModel, 0, transitionBatchSize)
+	flush := func() error {
+		if len(updates) == 0 {
+			return nil
+		}
+		_, err := coll.BulkWrite(updates, options.BulkWrite().SetOrdered(false))
+		updates = updates[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		update, err := build(cursor)
+		if err != nil {
+			return err
+		}
+		if update == nil {
+			continue
+		}
+		updates = append(updates, update)
+		if len(updates) >= transitionBatchSize {
+			err = flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// ApplyMembershipsSearchFieldsTransition sets the lower-cased search fields of the memberships which don't have them yet
+func (m *database) ApplyMembershipsSearchFieldsTransition(groupMemberships *collectionWrapper) error {
+	log.Println("apply memberships search fields migration.....")
+
+	filter := bson.D{primitive.E{Key: "$or", Value: bson.A{
+		bson.D{primitive.E{Key: "search_name", Value: bson.M{"$exists": false}}},
+		bson.D{primitive.E{Key: "search_net_id", Value: bson.M{"$exists": false}}},
+		bson.D{primitive.E{Key: "search_email", Value: bson.M{"$exists": false}}},
+	}}}
+	projection := bson.D{
+		primitive.E{Key: "name", Value: 1},
+		primitive.E{Key: "net_id", Value: 1},
+		primitive.E{Key: "email", Value: 1},
+	}
+	err := m.applyFindTransition(groupMemberships, filter, projection,
+		func(cursor *mongo.Cursor) (mongo.WriteModel, error) {
+			var membership model.GroupMembership
+			err := cursor.Decode(&membership)
+			if err != nil {
+				return nil, err
+			}
+			membership.SetSearchFields()
+			return mongo.NewUpdateOneModel().SetFilter(bson.D{primitive.E{Key: "_id", Value: membership.ID}}).
+				SetUpdate(bson.D{primitive.E{Key: "$set", Value: bson.D{
+					primitive.E{Key: "search_name", Value: membership.SearchName},
+					primitive.E{Key: "search_net_id", Value: membership.SearchNetID},
+					primitive.E{Key: "search_email", Value: membership.SearchEmail},
+				}}}), nil
+		})
+	if err != nil {
+		return err
+	}
+
+	log.Println("memberships search fields migration passed")
+	return nil
+}
+
+// ApplyPostsReactionCountsTransition calculates the denormalized reaction counts of the posts which have reactions but don't have counts yet
+func (m *database) ApplyPostsReactionCountsTransition(posts *collectionWrapper) error {
+	log.Println("apply posts reaction counts migration.....")
+
+	filter := bson.D{
+		primitive.E{Key: "reactions", Value: bson.M{"$exists": true}},
+		primitive.E{Key: "reaction_counts", Value: bson.M{"$exists": false}},
+	}
+	err := m.applyFindTransition(posts, filter, bson.D{primitive.E{Key: "reactions", Value: 1}},
+		func(cursor *mongo.Cursor) (mongo.WriteModel, error) {
+			var item struct {
+				ID        string              `bson:"_id"`
+				Reactions map[string][]string `bson:"reactions"`
+			}
+			err := cursor.Decode(&item)
+			if err != nil {
+				return nil, err
+			}
+			counts := bson.M{}
+			for reaction, userIDs := range item.Reactions {
+				if len(userIDs) > 0 {
+					counts[reaction] = len(userIDs)
+				}
+			}
+			return mongo.NewUpdateOneModel().SetFilter(bson.D{primitive.E{Key: "_id", Value: item.ID}}).
+				SetUpdate(bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "reaction_counts", Value: counts}}}}), nil
+		})
+	if err != nil {
+		return err
+	}
+
+	log.Println("posts reaction counts migration passed")
+	return nil
+}
+
+// ApplyPostsRepliesCountTransition calculates the denormalized replies count of the posts which don't have it yet.
+// The counts are set first and the rest of the posts get zero, so a failed run is completed by the next one.
+func (m *database) ApplyPostsRepliesCountTransition(posts *collectionWrapper) error {
+	log.Println("apply posts replies count migration.....")
+
+	missingFilter := bson.D{primitive.E{Key: "replies_count", Value: bson.M{"$exists": false}}}
+	count, err := posts.CountDocuments(missingFilter)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		log.Println("posts replies count migration passed")
+		return nil
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "parent_id", Value: bson.M{"$ne": nil}}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$parent_id"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := posts.AggregateCursor(context.Background(), pipeline, options.Aggregate().SetAllowDiskUse(true).SetBatchSize(transitionBatchSize))
+	if err != nil {
+		return err
+	}
+	err = m.applyCursorTransition(posts, cursor, func(cursor *mongo.Cursor) (mongo.WriteModel, error) {
+		var item struct {
+			PostID string `bson:"_id"`
+			Count  int    `bson:"count"`
+		}
+		err := cursor.Decode(&item)
+		if err != nil {
+			return nil, err
+		}
+		return mongo.NewUpdateOneModel().
+			SetFilter(bson.D{primitive.E{Key: "_id", Value: item.PostID}, primitive.E{Key: "replies_count", Value: bson.M{"$exists": false}}}).
+			SetUpdate(bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "replies_count", Value: item.Count}}}}), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = posts.UpdateMany(missingFilter, bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "replies_count", Value: 0}}}}, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Println("posts replies count migration passed")
+	return nil
+}
+
 func (m *database) onDataChanged(changeDoc map[string]interface{}) {
 	if changeDoc == nil {
 		return
@@ -1052,5 +1773,11 @@ func (m *database) onDataChanged(changeDoc map[string]interface{}) {
 		for _, listener := range m.listeners {
 			go listener.OnManagedGroupConfigsChanged()
 		}
+	case "content_filters":
+		log.Println("content_filters collection changed")
+
+		for _, listener := range m.listeners {
+			go listener.OnContentFiltersChanged()
+		}
 	}
 }
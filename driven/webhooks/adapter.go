@@ -0,0 +1,85 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// EventHeader contains the event type of the delivery
+	EventHeader = "X-Groups-Event"
+	// DeliveryHeader contains the delivery id. It is the same for the retries and the replays of the delivery
+	DeliveryHeader = "X-Groups-Delivery"
+	// TimestampHeader contains the unix time of the attempt
+	TimestampHeader = "X-Groups-Timestamp"
+	// SignatureHeader contains "sha256=" followed by the hex encoded HMAC-SHA256 of "<timestamp>.<body>" with the subscription secret
+	SignatureHeader = "X-Groups-Signature"
+)
+
+// Adapter implements the Webhooks interface
+type Adapter struct {
+	client *http.Client
+}
+
+// NewWebhooksAdapter creates a new webhooks adapter instance
+func NewWebhooksAdapter(timeout time.Duration) *Adapter {
+	return &Adapter{client: &http.Client{Timeout: timeout}}
+}
+
+// Sign computes the signature of the payload sent at the provided timestamp
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts the signed JSON payload to the webhook url. Returns the response status code if a response has been received.
+// Every non 2xx response is considered a failure.
+func (a *Adapter) Deliver(url string, secret string, eventType string, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("error creating webhook request: %s", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, payload))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error sending webhook request: %s", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
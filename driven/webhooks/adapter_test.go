@@ -0,0 +1,93 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   []byte
+		want      string
+	}{
+		{"event", "secret", "1700000000", []byte(`{"type":"group.created"}`), "sha256=522fc5f7e82f544c52ada4c14e973775822096024e1ca65702c8dd046f8ec59e"},
+		{"empty", "", "0", nil, "sha256=b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, tt.payload); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if Sign("secret", "1700000000", []byte("a")) == Sign("other", "1700000000", []byte("a")) {
+		t.Error("Sign() must depend on the secret")
+	}
+	if Sign("secret", "1700000000", []byte("a")) == Sign("secret", "1700000001", []byte("a")) {
+		t.Error("Sign() must depend on the timestamp")
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{"ok", http.StatusOK, http.StatusOK, false},
+		{"no content", http.StatusNoContent, http.StatusNoContent, false},
+		{"not modified", http.StatusNotModified, http.StatusNotModified, true},
+		{"server error", http.StatusInternalServerError, http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(`{"type":"group.created"}`)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != string(payload) {
+					t.Errorf("Deliver() body = %s, want %s", body, payload)
+				}
+				if r.Header.Get(EventHeader) != "group.created" || r.Header.Get(DeliveryHeader) != "d1" {
+					t.Errorf("Deliver() event = %s, delivery = %s", r.Header.Get(EventHeader), r.Header.Get(DeliveryHeader))
+				}
+				if want := Sign("secret", r.Header.Get(TimestampHeader), body); r.Header.Get(SignatureHeader) != want {
+					t.Errorf("Deliver() signature = %s, want %s", r.Header.Get(SignatureHeader), want)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			status, err := NewWebhooksAdapter(5*time.Second).Deliver(server.URL, "secret", "group.created", "d1", payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Deliver() status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
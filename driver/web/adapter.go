@@ -112,8 +112,34 @@ func (we *Adapter) Start() {
 	adminSubrouter.HandleFunc("/group/{group-id}/event/{event-id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteGroupEvent)).Methods("DELETE")
 	adminSubrouter.HandleFunc("/group/{group-id}/posts", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetGroupPosts)).Methods("GET")
 	adminSubrouter.HandleFunc("/group/{groupID}/posts", we.idTokenAuthWrapFunc(we.adminApisHandler.CreateGroupPost)).Methods("POST")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/pending", we.idTokenAuthWrapFunc(we.adminApisHandler.GetGroupPendingPosts)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/expired", we.idTokenAuthWrapFunc(we.adminApisHandler.GetGroupExpiredPosts)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/series", we.idTokenAuthWrapFunc(we.adminApisHandler.GetGroupPostSeries)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/series", we.idTokenAuthWrapFunc(we.adminApisHandler.CreateGroupPostSeries)).Methods("POST")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/series/{seriesID}/status", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateGroupPostSeriesStatus)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions", we.idTokenAuthWrapFunc(we.adminApisHandler.GetAttendanceSessions)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions", we.idTokenAuthWrapFunc(we.adminApisHandler.CreateAttendanceSession)).Methods("POST")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateAttendanceSession)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}", we.idTokenAuthWrapFunc(we.adminApisHandler.DeleteAttendanceSession)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/records", we.idTokenAuthWrapFunc(we.adminApisHandler.GetAttendanceRecords)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/check-in", we.idTokenAuthWrapFunc(we.adminApisHandler.CheckInAttendance)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/check-out", we.idTokenAuthWrapFunc(we.adminApisHandler.CheckOutAttendance)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/check-in-window", we.idTokenAuthWrapFunc(we.adminApisHandler.OpenAttendanceCheckInWindow)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/check-in-window", we.idTokenAuthWrapFunc(we.adminApisHandler.CloseAttendanceCheckInWindow)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/sessions/{sessionID}/check-in-code", we.idTokenAuthWrapFunc(we.adminApisHandler.GetAttendanceCheckInCode)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/attendance/report", we.idTokenAuthWrapFunc(we.adminApisHandler.GetAttendanceReport)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/profile-fields", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateGroupProfileFields)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/memberships/{membershipID}/profile-values", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateMemberProfileValues)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/memberships/{membershipID}/notes", we.idTokenAuthWrapFunc(we.adminApisHandler.GetMemberNotes)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/memberships/{membershipID}/notes", we.idTokenAuthWrapFunc(we.adminApisHandler.CreateMemberNote)).Methods("POST")
+	adminSubrouter.HandleFunc("/group/{groupID}/member-notes/{noteID}", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateMemberNote)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/member-notes/{noteID}", we.idTokenAuthWrapFunc(we.adminApisHandler.DeleteMemberNote)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/group/{groupID}/roster", we.idTokenAuthWrapFunc(we.adminApisHandler.GetMemberRoster)).Methods("GET")
+	adminSubrouter.HandleFunc("/group/{groupID}/member-labels", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateGroupMemberLabels)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/memberships/{membershipID}/labels", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateMembershipLabels)).Methods("PUT")
 	adminSubrouter.HandleFunc("/group/{groupID}/posts/{postID}", we.idTokenAuthWrapFunc(we.adminApisHandler.GetGroupPost)).Methods("GET")
 	adminSubrouter.HandleFunc("/group/{groupID}/posts/{postID}", we.idTokenAuthWrapFunc(we.adminApisHandler.UpdateGroupPost)).Methods("PUT")
+	adminSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/review", we.idTokenAuthWrapFunc(we.adminApisHandler.ReviewGroupPost)).Methods("PUT")
 	adminSubrouter.HandleFunc("/group/{group-id}/posts/{postID}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteGroupPost)).Methods("DELETE")
 	adminSubrouter.HandleFunc("/group/{group-id}/events/v3/load", we.mixedAuthWrapFunc(we.adminApisHandler.GetGroupCalendarEventsV3)).Methods("GET", "POST")
 	adminSubrouter.HandleFunc("/group/events/v3", we.mixedAuthWrapFunc(we.adminApisHandler.CreateCalendarEventMultiGroup)).Methods("POST")
@@ -121,12 +147,35 @@ func (we *Adapter) Start() {
 	adminSubrouter.HandleFunc("/group/{group-id}/events/v3", we.mixedAuthWrapFunc(we.adminApisHandler.UpdateCalendarEventSingleGroup)).Methods("PUT")
 	adminSubrouter.HandleFunc("/memberships/{membership-id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateMembership)).Methods("PUT")
 	adminSubrouter.HandleFunc("/memberships/{membership-id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteMembership)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/memberships/{membership-id}/history", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetMembershipStatusHistory)).Methods("GET")
 	adminSubrouter.HandleFunc("/managed-group-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetManagedGroupConfigs)).Methods("GET")
 	adminSubrouter.HandleFunc("/managed-group-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.CreateManagedGroupConfig)).Methods("POST")
 	adminSubrouter.HandleFunc("/managed-group-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateManagedGroupConfig)).Methods("PUT")
 	adminSubrouter.HandleFunc("/managed-group-configs/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteManagedGroupConfig)).Methods("DELETE")
 	adminSubrouter.HandleFunc("/sync-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetSyncConfig)).Methods("GET")
 	adminSubrouter.HandleFunc("/sync-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.SaveSyncConfig)).Methods("PUT")
+	adminSubrouter.HandleFunc("/reactions-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetReactionsConfig)).Methods("GET")
+	adminSubrouter.HandleFunc("/reactions-configs", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.SaveReactionsConfig)).Methods("PUT")
+	adminSubrouter.HandleFunc("/content-filters", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetContentFilters)).Methods("GET")
+	adminSubrouter.HandleFunc("/content-filters", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.CreateContentFilter)).Methods("POST")
+	adminSubrouter.HandleFunc("/content-filters", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateContentFilter)).Methods("PUT")
+	adminSubrouter.HandleFunc("/content-filters/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteContentFilter)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/notification-templates", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetNotificationTemplates)).Methods("GET")
+	adminSubrouter.HandleFunc("/notification-templates/defaults", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetDefaultNotificationTemplates)).Methods("GET")
+	adminSubrouter.HandleFunc("/notification-templates", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.CreateNotificationTemplate)).Methods("POST")
+	adminSubrouter.HandleFunc("/notification-templates", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateNotificationTemplate)).Methods("PUT")
+	adminSubrouter.HandleFunc("/notification-templates/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteNotificationTemplate)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/abuse-reports", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetAbuseReports)).Methods("GET")
+	adminSubrouter.HandleFunc("/abuse-reports/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetAbuseReport)).Methods("GET")
+	adminSubrouter.HandleFunc("/abuse-reports/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateAbuseReport)).Methods("PUT")
+	adminSubrouter.HandleFunc("/notification-outbox", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetOutboxNotifications)).Methods("GET")
+	adminSubrouter.HandleFunc("/notification-outbox/{id}/replay", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.ReplayOutboxNotification)).Methods("POST")
+	adminSubrouter.HandleFunc("/webhook-subscriptions", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetWebhookSubscriptions)).Methods("GET")
+	adminSubrouter.HandleFunc("/webhook-subscriptions", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.CreateWebhookSubscription)).Methods("POST")
+	adminSubrouter.HandleFunc("/webhook-subscriptions", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.UpdateWebhookSubscription)).Methods("PUT")
+	adminSubrouter.HandleFunc("/webhook-subscriptions/{id}", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.DeleteWebhookSubscription)).Methods("DELETE")
+	adminSubrouter.HandleFunc("/webhook-subscriptions/{id}/deliveries", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.GetWebhookDeliveries)).Methods("GET")
+	adminSubrouter.HandleFunc("/webhook-deliveries/{id}/replay", we.adminIDTokenAuthWrapFunc(we.adminApisHandler.ReplayWebhookDelivery)).Methods("POST")
 
 	// Internal key protection
 	restSubrouter.HandleFunc("/int/user/{identifier}/groups", we.internalKeyAuthFunc(we.internalApisHandler.IntGetUserGroupMemberships)).Methods("GET")
@@ -134,6 +183,7 @@ func (we *Adapter) Start() {
 	restSubrouter.HandleFunc("/int/group/title/{title}/members", we.internalKeyAuthFunc(we.internalApisHandler.IntGetGroupMembersByGroupTitle)).Methods("GET")
 	restSubrouter.HandleFunc("/int/authman/synchronize", we.internalKeyAuthFunc(we.internalApisHandler.SynchronizeAuthman)).Methods("POST")
 	restSubrouter.HandleFunc("/int/stats", we.internalKeyAuthFunc(we.internalApisHandler.GroupStats)).Methods("GET")
+	restSubrouter.HandleFunc("/int/changes", we.internalKeyAuthFunc(we.internalApisHandler.GetChanges)).Methods("GET")
 	restSubrouter.HandleFunc("/int/group/{group-id}/date_updated", we.internalKeyAuthFunc(we.internalApisHandler.UpdateGroupDateUpdated)).Methods("POST")
 	restSubrouter.HandleFunc("/int/group/{group-id}/events", we.internalKeyAuthFunc(we.internalApisHandler.CreateGroupEvent)).Methods("POST")
 	restSubrouter.HandleFunc("/int/group/{group-id}/events/{event-id}", we.internalKeyAuthFunc(we.internalApisHandler.DeleteGroupEvent)).Methods("DELETE")
@@ -151,6 +201,8 @@ func (we *Adapter) Start() {
 	restSubrouter.HandleFunc("/user/groups", we.idTokenAuthWrapFunc(we.apisHandler.GetUserGroups)).Methods("GET")
 	restSubrouter.HandleFunc("/user/login", we.idTokenAuthWrapFunc(we.apisHandler.LoginUser)).Methods("GET")
 	restSubrouter.HandleFunc("/user/stats", we.idTokenAuthWrapFunc(we.apisHandler.GetUserStats)).Methods("GET")
+	restSubrouter.HandleFunc("/user/feed", we.idTokenAuthWrapFunc(we.apisHandler.GetUserFeed)).Methods("GET")
+	restSubrouter.HandleFunc("/reactions-config", we.idTokenAuthWrapFunc(we.apisHandler.GetReactionsConfig)).Methods("GET")
 	restSubrouter.HandleFunc("/user/event/{event-id}/groups", we.idTokenAuthWrapFunc(we.apisHandler.GetAdminGroupIDsForEventID)).Methods("GET")
 	restSubrouter.HandleFunc("/user/event/{event-id}/groups", we.idTokenAuthWrapFunc(we.apisHandler.UpdateGroupMappingsEventID)).Methods("PUT")
 	restSubrouter.HandleFunc("/group/{id}/stats", we.anonymousAuthWrapFunc(we.apisHandler.GetGroupStats)).Methods("GET")
@@ -161,6 +213,7 @@ func (we *Adapter) Start() {
 	restSubrouter.HandleFunc("/group/{group-id}/pending-members", we.idTokenAuthWrapFunc(we.apisHandler.DeletePendingMember)).Methods("DELETE")
 	restSubrouter.HandleFunc("/group/{group-id}/members", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupMembers)).Methods("GET")
 	restSubrouter.HandleFunc("/group/{group-id}/members/v2", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupMembersV2)).Methods("POST")
+	restSubrouter.HandleFunc("/group/{group-id}/members/search", we.idTokenAuthWrapFunc(we.apisHandler.SearchGroupMembers)).Methods("POST")
 	restSubrouter.HandleFunc("/group/{group-id}/members", we.idTokenAuthWrapFunc(we.apisHandler.CreateMember)).Methods("POST")
 	restSubrouter.HandleFunc("/group/{group-id}/members", we.idTokenAuthWrapFunc(we.apisHandler.DeleteMember)).Methods("DELETE")
 	restSubrouter.HandleFunc("/group/{group-id}/members/multi-update", we.idTokenAuthWrapFunc(we.apisHandler.MultiUpdateMembers)).Methods("PUT")
@@ -168,6 +221,10 @@ func (we *Adapter) Start() {
 	restSubrouter.HandleFunc("/memberships/{membership-id}/approval", we.idTokenAuthWrapFunc(we.apisHandler.MembershipApproval)).Methods("PUT")
 	restSubrouter.HandleFunc("/memberships/{membership-id}", we.idTokenAuthWrapFunc(we.apisHandler.DeleteMembership)).Methods("DELETE")
 	restSubrouter.HandleFunc("/memberships/{membership-id}", we.idTokenAuthWrapFunc(we.apisHandler.UpdateMembership)).Methods("PUT")
+	restSubrouter.HandleFunc("/memberships/{membership-id}/history", we.idTokenAuthWrapFunc(we.apisHandler.GetMembershipStatusHistory)).Methods("GET")
+
+	restSubrouter.HandleFunc("/group/{groupID}/attendance/check-in", we.idTokenAuthWrapFunc(we.apisHandler.SelfCheckInAttendance)).Methods("PUT")
+	restSubrouter.HandleFunc("/group/{groupID}/profile-values", we.idTokenAuthWrapFunc(we.apisHandler.UpdateOwnProfileValues)).Methods("PUT")
 
 	restSubrouter.HandleFunc("/group/{group-id}/events", we.idTokenAuthWrapFunc(we.apisHandler.CreateGroupEvent)).Methods("POST")
 	restSubrouter.HandleFunc("/group/{group-id}/events", we.idTokenAuthWrapFunc(we.apisHandler.UpdateGroupEvent)).Methods("PUT")
@@ -179,9 +236,18 @@ func (we *Adapter) Start() {
 	// Client Post APIs
 	restSubrouter.HandleFunc("/group/{groupID}/posts", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPosts)).Methods("GET")
 	restSubrouter.HandleFunc("/group/{groupID}/posts", we.idTokenAuthWrapFunc(we.apisHandler.CreateGroupPost)).Methods("POST")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/pinned", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPinnedPosts)).Methods("GET")
+	restSubrouter.HandleFunc("/group/{groupID}/feed", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupFeed)).Methods("GET")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/cross-post", we.idTokenAuthWrapFunc(we.apisHandler.CreateGroupCrossPost)).Methods("POST")
 	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPost)).Methods("GET")
 	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}", we.idTokenAuthWrapFunc(we.apisHandler.UpdateGroupPost)).Methods("PUT")
 	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/reactions", we.idTokenAuthWrapFunc(we.apisHandler.ReactToGroupPost)).Methods("PUT")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/reactions", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPostReactions)).Methods("GET")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/replies", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPostReplies)).Methods("GET")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/pin", we.idTokenAuthWrapFunc(we.apisHandler.PinGroupPost)).Methods("PUT")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/seen", we.idTokenAuthWrapFunc(we.apisHandler.MarkGroupPostSeen)).Methods("PUT")
+	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/seen", we.idTokenAuthWrapFunc(we.apisHandler.GetGroupPostSeenRecords)).Methods("GET")
+	restSubrouter.HandleFunc("/group/{groupID}/read", we.idTokenAuthWrapFunc(we.apisHandler.MarkGroupAsRead)).Methods("PUT")
 	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}/report/abuse", we.idTokenAuthWrapFunc(we.apisHandler.ReportAbuseGroupPost)).Methods("PUT")
 	restSubrouter.HandleFunc("/group/{groupID}/posts/{postID}", we.idTokenAuthWrapFunc(we.apisHandler.DeleteGroupPost)).Methods("DELETE")
 
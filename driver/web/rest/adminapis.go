@@ -114,7 +114,7 @@ func (h *AdminApisHandler) GetUserGroups(clientID string, current *model.User, w
 		groupIDs = append(groupIDs, grouop.ID)
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewSystemAdminMemberViewer(current), model.MembershipFilter{
 		GroupIDs: groupIDs,
 	})
 	if err != nil {
@@ -236,7 +236,7 @@ func (h *AdminApisHandler) GetAllGroups(clientID string, current *model.User, w
 		groupIDs = append(groupIDs, grouop.ID)
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewSystemAdminMemberViewer(current), model.MembershipFilter{
 		GroupIDs: groupIDs,
 	})
 	if err != nil {
@@ -571,7 +571,7 @@ func (h *AdminApisHandler) GetGroupMembers(clientID string, current *model.User,
 	request.GroupIDs = append(request.GroupIDs, groupID)
 
 	//check if allowed to update
-	members, err := h.app.Services.FindGroupMemberships(clientID, request)
+	members, err := h.app.Services.FindGroupMemberships(clientID, model.NewSystemAdminMemberViewer(current), request)
 	if err != nil {
 		log.Printf("adminapis.GetGroupMembers()  error: %s", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -633,7 +633,7 @@ func (h *AdminApisHandler) GetGroupMembersV2(clientID string, current *model.Use
 	request.GroupIDs = append(request.GroupIDs, groupID)
 
 	//check if allowed to update
-	members, err := h.app.Services.FindGroupMemberships(clientID, request)
+	members, err := h.app.Services.FindGroupMemberships(clientID, model.NewSystemAdminMemberViewer(current), request)
 	if err != nil {
 		log.Printf("adminapis.GetGroupMembers()  error: %s", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -1215,6 +1215,72 @@ func (h *AdminApisHandler) SaveSyncConfig(clientID string, current *model.User,
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetReactionsConfig gets the reactions config
+// @Description Gets the reactions config of the client
+// @ID AdminGetReactionsConfig
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Success 200 {object} model.ReactionsConfig
+// @Security AppUserAuth
+// @Router /api/admin/reactions-configs [get]
+func (h *AdminApisHandler) GetReactionsConfig(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	config, err := h.app.Services.GetReactionsConfig(clientID)
+	if err != nil {
+		log.Printf("error getting reactions config - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		log.Println("Error on marshal reactions config")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// SaveReactionsConfig saves the reactions config
+// @Description Saves the reactions config of the client. Empty allowed_reactions means that any reaction is allowed.
+// @ID AdminSaveReactionsConfig
+// @Tags Admin
+// @Accept json
+// @Param data body model.ReactionsConfig true "body data"
+// @Param APP header string true "APP"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/reactions-configs [put]
+func (h *AdminApisHandler) SaveReactionsConfig(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on save reactions config - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var config model.ReactionsConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		log.Printf("Error on unmarshal the reactions config data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	config.ClientID = clientID
+	err = h.app.Services.UpdateReactionsConfig(config)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
 // SynchronizeAuthman Synchronizes Authman groups membership
 // @Description Synchronizes Authman groups membership
 // @Tags Admin
@@ -1234,3 +1300,26 @@ func (h *AdminApisHandler) SynchronizeAuthman(clientID string, current *model.Us
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 }
+
+// getAdminGroup loads the group and checks if the current user is its admin. It writes the error response and returns nil if not.
+func (h *AdminApisHandler) getAdminGroup(clientID string, current *model.User, groupID string, w http.ResponseWriter) *model.Group {
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("%s is not an admin of %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return nil
+	}
+	return group
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GetAbuseReports gets the abuse cases
+// @Description Gets the abuse cases. The repeated reports of the same group, post or reply are grouped within one case.
+// @ID AdminGetAbuseReports
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param group_id query string false "group_id"
+// @Param target_type query string false "group, post or reply"
+// @Param target_id query string false "target_id"
+// @Param statuses query string false "comma separated list of open, reviewing, actioned or dismissed"
+// @Param assignee_id query string false "assignee_id"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array}  model.AbuseReport
+// @Security AppUserAuth
+// @Router /api/admin/abuse-reports [get]
+func (h *AdminApisHandler) GetAbuseReports(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	filter := model.AbuseReportsFilter{
+		GroupID:    getStringQueryParam(r, "group_id"),
+		TargetType: getStringQueryParam(r, "target_type"),
+		TargetID:   getStringQueryParam(r, "target_id"),
+		AssigneeID: getStringQueryParam(r, "assignee_id"),
+		Offset:     getInt64QueryParam(r, "offset"),
+		Limit:      getInt64QueryParam(r, "limit"),
+	}
+	if statuses := getStringQueryParam(r, "statuses"); statuses != nil {
+		filter.Statuses = strings.Split(*statuses, ",")
+	}
+
+	reports, err := h.app.Services.GetAbuseReports(clientID, filter)
+	if err != nil {
+		log.Printf("error getting abuse reports - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		log.Println("Error on marshal abuse reports")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetAbuseReport gets an abuse case
+// @Description Gets an abuse case with all of its reports
+// @ID AdminGetAbuseReport
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "ID"
+// @Success 200 {object} model.AbuseReport
+// @Security AppUserAuth
+// @Router /api/admin/abuse-reports/{id} [get]
+func (h *AdminApisHandler) GetAbuseReport(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.app.Services.GetAbuseReport(clientID, id)
+	if err != nil {
+		log.Printf("error getting abuse report (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if report == nil {
+		log.Printf("abuse report (%s) not found", id)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Println("Error on marshal abuse report")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// updateAbuseReportRequestBody request body for the update abuse report API call
+type updateAbuseReportRequestBody struct {
+	Status          *string `json:"status"`
+	AssigneeID      *string `json:"assignee_id"`
+	ResolutionNotes *string `json:"resolution_notes"`
+} // @name updateAbuseReportRequestBody
+
+// UpdateAbuseReport updates an abuse case
+// @Description Assigns, reviews or resolves an abuse case. Only the provided fields are updated. An empty assignee_id unassigns the case. The closed (actioned or dismissed) cases cannot be updated.
+// @ID AdminUpdateAbuseReport
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param id path string true "ID"
+// @Param data body updateAbuseReportRequestBody true "body data"
+// @Success 200 {object} model.AbuseReport
+// @Security AppUserAuth
+// @Router /api/admin/abuse-reports/{id} [put]
+func (h *AdminApisHandler) UpdateAbuseReport(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read updateAbuseReportRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body updateAbuseReportRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal updateAbuseReportRequestBody (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.app.Services.UpdateAbuseReport(clientID, current, id, body.Status, body.AssigneeID, body.ResolutionNotes)
+	if err != nil {
+		log.Printf("error updating abuse report (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err = json.Marshal(report)
+	if err != nil {
+		log.Println("Error on marshal abuse report")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
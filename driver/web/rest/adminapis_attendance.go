@@ -0,0 +1,524 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetAttendanceSessions Gets the attendance sessions of the desired group
+// @Description Gets the attendance sessions of the desired attendance group ordered by date. Only group admins are allowed to do it.
+// @ID AdminGetAttendanceSessions
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Success 200 {array} model.AttendanceSession
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions [get]
+func (h *AdminApisHandler) GetAttendanceSessions(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	sessions, err := h.app.Services.GetAttendanceSessions(clientID, current, group)
+	if err != nil {
+		log.Printf("error getting attendance sessions for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		log.Printf("error on marshal attendance sessions for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateAttendanceSession Creates an attendance session within the desired group
+// @Description Creates an attendance session within the desired attendance group. The session may be linked to an event of the group. Only group admins are allowed to do it.
+// @ID AdminCreateAttendanceSession
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body model.AttendanceSession true "body data"
+// @Success 200 {object} model.AttendanceSession
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions [post]
+func (h *AdminApisHandler) CreateAttendanceSession(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read attendance session - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var session model.AttendanceSession
+	err = json.Unmarshal(data, &session)
+	if err != nil {
+		log.Printf("error on unmarshal attendance session for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	result, err := h.app.Services.CreateAttendanceSession(clientID, current, group, session)
+	if err != nil {
+		log.Printf("error creating attendance session for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		log.Printf("error on marshal attendance session for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdateAttendanceSession Updates an attendance session within the desired group
+// @Description Updates the title, the date and the linked event of an attendance session. Only group admins are allowed to do it.
+// @ID AdminUpdateAttendanceSession
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Param data body model.AttendanceSession true "body data"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID} [put]
+func (h *AdminApisHandler) UpdateAttendanceSession(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read attendance session - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var session model.AttendanceSession
+	err = json.Unmarshal(data, &session)
+	if err != nil {
+		log.Printf("error on unmarshal attendance session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	session.ID = sessionID
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err = h.app.Services.UpdateAttendanceSession(clientID, current, group, session)
+	if err != nil {
+		log.Printf("error updating attendance session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteAttendanceSession Deletes an attendance session within the desired group
+// @Description Deletes an attendance session and its attendance records. Only group admins are allowed to do it.
+// @ID AdminDeleteAttendanceSession
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID} [delete]
+func (h *AdminApisHandler) DeleteAttendanceSession(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err := h.app.Services.DeleteAttendanceSession(clientID, current, group, sessionID)
+	if err != nil {
+		log.Printf("error deleting attendance session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetAttendanceRecords Gets the attendance records of an attendance session
+// @Description Gets the attendance records of an attendance session. Only group admins are allowed to do it.
+// @ID AdminGetAttendanceRecords
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Success 200 {array} model.AttendanceRecord
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/records [get]
+func (h *AdminApisHandler) GetAttendanceRecords(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	records, err := h.app.Services.GetAttendanceRecords(clientID, current, group, sessionID)
+	if err != nil {
+		log.Printf("error getting attendance records for session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Printf("error on marshal attendance records for session (%s) - %s", sessionID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// attendanceUsersRequestBody request body for the check-in and check-out API calls
+type attendanceUsersRequestBody struct {
+	UserIDs []string `json:"user_ids" validate:"required"`
+} // @name attendanceUsersRequestBody
+
+// CheckInAttendance Checks in group members to an attendance session
+// @Description Checks in group members to an attendance session. Checking in a member who has checked out checks them in again. Only group admins are allowed to do it.
+// @ID AdminCheckInAttendance
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Param data body attendanceUsersRequestBody true "body data"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in [put]
+func (h *AdminApisHandler) CheckInAttendance(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	h.updateAttendance(clientID, current, w, r, h.app.Services.CheckInAttendance)
+}
+
+// CheckOutAttendance Checks out group members from an attendance session
+// @Description Checks out group members from an attendance session. Only checked in members are checked out. Only group admins are allowed to do it.
+// @ID AdminCheckOutAttendance
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Param data body attendanceUsersRequestBody true "body data"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-out [put]
+func (h *AdminApisHandler) CheckOutAttendance(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	h.updateAttendance(clientID, current, w, r, h.app.Services.CheckOutAttendance)
+}
+
+// updateAttendance reads the users from the request body and applies the check-in or the check-out
+func (h *AdminApisHandler) updateAttendance(clientID string, current *model.User, w http.ResponseWriter, r *http.Request,
+	apply func(clientID string, current *model.User, group *model.Group, sessionID string, userIDs []string) error) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read attendanceUsersRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body attendanceUsersRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal attendanceUsersRequestBody (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err = apply(clientID, current, group, sessionID, body.UserIDs)
+	if err != nil {
+		log.Printf("error updating attendance for session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetAttendanceReport Gets the attendance report of the desired group
+// @Description Gets the attendance report of the desired attendance group with the attendance rate per session and per member. Only group admins are allowed to do it.
+// @ID AdminGetAttendanceReport
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Success 200 {object} model.AttendanceReport
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/report [get]
+func (h *AdminApisHandler) GetAttendanceReport(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	report, err := h.app.Services.GetAttendanceReport(clientID, current, group)
+	if err != nil {
+		log.Printf("error getting attendance report for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("error on marshal attendance report for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// openAttendanceCheckInWindowRequestBody request body for the open check-in window API call
+type openAttendanceCheckInWindowRequestBody struct {
+	DateOpens         *time.Time `json:"date_opens"` // now if not set
+	DateCloses        time.Time  `json:"date_closes" validate:"required"`
+	CodePeriod        int        `json:"code_period"` // in seconds, 30 if not set
+	AutoAddNonMembers bool       `json:"auto_add_non_members"`
+} // @name openAttendanceCheckInWindowRequestBody
+
+// OpenAttendanceCheckInWindow Opens the check-in window of an attendance session
+// @Description Opens the check-in window of an attendance session so that the members can check in themselves with a rotating 6-digit code. The window lasts at most 24 hours. Non-members who check in are added as pending members if auto_add_non_members is set. Opening the window again rotates its secret. Only group admins are allowed to do it.
+// @ID AdminOpenAttendanceCheckInWindow
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Param data body openAttendanceCheckInWindowRequestBody true "body data"
+// @Success 200 {object} model.AttendanceSession
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in-window [put]
+func (h *AdminApisHandler) OpenAttendanceCheckInWindow(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read openAttendanceCheckInWindowRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body openAttendanceCheckInWindowRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal openAttendanceCheckInWindowRequestBody (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	session, err := h.app.Services.OpenAttendanceCheckInWindow(clientID, current, group, sessionID, body.DateOpens, body.DateCloses, body.CodePeriod, body.AutoAddNonMembers)
+	if err != nil {
+		log.Printf("error opening the check-in window of session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(session)
+	if err != nil {
+		log.Printf("error on marshal attendance session (%s) - %s", sessionID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CloseAttendanceCheckInWindow Closes the check-in window of an attendance session
+// @Description Closes the check-in window of an attendance session. Only group admins are allowed to do it.
+// @ID AdminCloseAttendanceCheckInWindow
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in-window [delete]
+func (h *AdminApisHandler) CloseAttendanceCheckInWindow(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err := h.app.Services.CloseAttendanceCheckInWindow(clientID, current, group, sessionID)
+	if err != nil {
+		log.Printf("error closing the check-in window of session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetAttendanceCheckInCode Gets the current check-in code of an attendance session
+// @Description Gets the current check-in code of an attendance session together with its QR payload. The admins display it to the members and fetch it again when it expires. Only group admins are allowed to do it.
+// @ID AdminGetAttendanceCheckInCode
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param sessionID path string true "sessionID"
+// @Success 200 {object} model.AttendanceCheckInCode
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/attendance/sessions/{sessionID}/check-in-code [get]
+func (h *AdminApisHandler) GetAttendanceCheckInCode(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, sessionID := h.getAttendanceSessionParams(w, r)
+	if len(groupID) == 0 || len(sessionID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	code, err := h.app.Services.GetAttendanceCheckInCode(clientID, current, group, sessionID)
+	if err != nil {
+		log.Printf("error getting the check-in code of session (%s) - %s", sessionID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(code)
+	if err != nil {
+		log.Printf("error on marshal the check-in code of session (%s) - %s", sessionID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// getAttendanceSessionParams reads the group and the session ids from the path. It writes the error response and returns empty ids if missing.
+func (h *AdminApisHandler) getAttendanceSessionParams(w http.ResponseWriter, r *http.Request) (string, string) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return "", ""
+	}
+
+	sessionID := params["sessionID"]
+	if len(sessionID) <= 0 {
+		log.Println("sessionID is required")
+		http.Error(w, "session id is required", http.StatusBadRequest)
+		return "", ""
+	}
+	return groupID, sessionID
+}
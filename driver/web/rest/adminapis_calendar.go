@@ -234,7 +234,7 @@ func (h *AdminApisHandler) CreateCalendarEventSingleGroup(clientID string, curre
 		return
 	}
 
-	event, member, err := h.app.Services.CreateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers)
+	event, member, labelIDs, err := h.app.Services.CreateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers, requestData.ToLabelIDs)
 	if err != nil {
 		log.Printf("adminapis.CreateCalendarEventSingleGroup() - Error on validating create event data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -242,8 +242,9 @@ func (h *AdminApisHandler) CreateCalendarEventSingleGroup(clientID string, curre
 	}
 
 	data, err = json.Marshal(createCalendarEventSingleGroupData{
-		Event:     event,
-		ToMembers: member,
+		Event:      event,
+		ToMembers:  member,
+		ToLabelIDs: labelIDs,
 	})
 	if err != nil {
 		log.Printf("adminapis.CreateCalendarEventSingleGroup() - Error on marshaling response data - %s\n", err.Error())
@@ -314,7 +315,7 @@ func (h *AdminApisHandler) UpdateCalendarEventSingleGroup(clientID string, curre
 		return
 	}
 
-	event, member, err := h.app.Services.UpdateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers)
+	event, member, labelIDs, err := h.app.Services.UpdateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers, requestData.ToLabelIDs)
 	if err != nil {
 		log.Printf("adminapis.UpdateCalendarEventSingleGroup() - Error on validating create event data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -322,8 +323,9 @@ func (h *AdminApisHandler) UpdateCalendarEventSingleGroup(clientID string, curre
 	}
 
 	data, err = json.Marshal(updateCalendarEventSingleGroupData{
-		Event:     event,
-		ToMembers: member,
+		Event:      event,
+		ToMembers:  member,
+		ToLabelIDs: labelIDs,
 	})
 	if err != nil {
 		log.Printf("adminapis.UpdateCalendarEventSingleGroup() - Error on marshaling response data - %s\n", err.Error())
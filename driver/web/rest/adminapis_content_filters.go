@@ -0,0 +1,167 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetContentFilters gets the content filters
+// @Description Gets the content filters. If group_id is provided only the client wide filters and the filters of the group are returned.
+// @ID AdminGetContentFilters
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param group_id query string false "group_id"
+// @Success 200 {array}  model.ContentFilter
+// @Security AppUserAuth
+// @Router /api/admin/content-filters [get]
+func (h *AdminApisHandler) GetContentFilters(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	filters, err := h.app.Services.GetContentFilters(clientID, getStringQueryParam(r, "group_id"))
+	if err != nil {
+		log.Printf("error getting content filters - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(filters)
+	if err != nil {
+		log.Println("Error on marshal content filters")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateContentFilter creates a new content filter
+// @Description Creates a new content filter. The filter is applied for all groups of the client if group_id is missing.
+// @ID AdminCreateContentFilter
+// @Tags Admin
+// @Accept plain
+// @Param data body  model.ContentFilter true "body data"
+// @Param APP header string true "APP"
+// @Success 200 {object} model.ContentFilter
+// @Security AppUserAuth
+// @Router /api/admin/content-filters [post]
+func (h *AdminApisHandler) CreateContentFilter(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on create content filter - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var filter model.ContentFilter
+	err = json.Unmarshal(data, &filter)
+	if err != nil {
+		log.Printf("Error on unmarshal the content filter data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newFilter, err := h.app.Services.CreateContentFilter(clientID, current, filter)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := json.Marshal(newFilter)
+	if err != nil {
+		log.Println("Error on marshal created content filter")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// UpdateContentFilter updates an existing content filter
+// @Description Updates an existing content filter
+// @ID AdminUpdateContentFilter
+// @Tags Admin
+// @Accept plain
+// @Param data body  model.ContentFilter true "body data"
+// @Param APP header string true "APP"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/content-filters [put]
+func (h *AdminApisHandler) UpdateContentFilter(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on update content filter - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var filter model.ContentFilter
+	err = json.Unmarshal(data, &filter)
+	if err != nil {
+		log.Printf("Error on unmarshal the content filter data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.Services.UpdateContentFilter(clientID, filter)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteContentFilter Deletes a content filter
+// @Description Deletes a content filter
+// @ID AdminDeleteContentFilter
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "ID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/content-filters/{id} [delete]
+func (h *AdminApisHandler) DeleteContentFilter(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.DeleteContentFilter(clientID, id)
+	if err != nil {
+		log.Printf("error deleting content filter for id (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// UpdateGroupMemberLabels Replaces the member labels of the desired group
+// @Description Replaces the member labels of the desired group. The new labels come without id and get one generated. The removed labels are unassigned from the members. Only group admins are allowed to do it.
+// @ID AdminUpdateGroupMemberLabels
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body []model.MemberLabel true "body data"
+// @Success 200 {array} model.MemberLabel
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/member-labels [put]
+func (h *AdminApisHandler) UpdateGroupMemberLabels(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read member labels - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var labels []model.MemberLabel
+	err = json.Unmarshal(data, &labels)
+	if err != nil {
+		log.Printf("error on unmarshal member labels for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	result, err := h.app.Services.UpdateGroupMemberLabels(clientID, current, group, labels)
+	if err != nil {
+		log.Printf("error updating member labels for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		log.Printf("error on marshal member labels for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+type membershipLabelsRequestBody struct {
+	LabelIDs []string `json:"label_ids"`
+} // @name membershipLabelsRequestBody
+
+// UpdateMembershipLabels Assigns member labels to a member of the desired group
+// @Description Replaces the member labels assigned to a member of the desired group. The posts, events and notifications addressed to any of the labels reach the member. Only group admins are allowed to do it.
+// @ID AdminUpdateMembershipLabels
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param membershipID path string true "membershipID"
+// @Param data body membershipLabelsRequestBody true "body data"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/memberships/{membershipID}/labels [put]
+func (h *AdminApisHandler) UpdateMembershipLabels(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	membershipID := params["membershipID"]
+	if len(membershipID) <= 0 {
+		log.Println("membershipID is required")
+		http.Error(w, "membership id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read membership labels - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var requestData membershipLabelsRequestBody
+	err = json.Unmarshal(data, &requestData)
+	if err != nil {
+		log.Printf("error on unmarshal labels for membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err = h.app.Services.UpdateMembershipLabels(clientID, current, group, membershipID, requestData.LabelIDs)
+	if err != nil {
+		log.Printf("error updating labels for membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
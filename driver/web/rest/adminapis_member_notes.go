@@ -0,0 +1,275 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// memberNoteRequestBody request body for the create and update member note API calls
+type memberNoteRequestBody struct {
+	Text string `json:"text" validate:"required"`
+} // @name memberNoteRequestBody
+
+// GetMemberNotes Gets the admin notes on a member of the desired group
+// @Description Gets the private admin notes on a member ordered by date. The retained notes on a previous membership of the same user are included. Only group admins are allowed to do it.
+// @ID AdminGetMemberNotes
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param membershipID path string true "membershipID"
+// @Success 200 {array} model.MemberNote
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/memberships/{membershipID}/notes [get]
+func (h *AdminApisHandler) GetMemberNotes(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, membershipID := h.getMemberNotesParams(w, r, "membershipID")
+	if len(groupID) == 0 || len(membershipID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	notes, err := h.app.Services.GetMemberNotes(clientID, current, group, membershipID)
+	if err != nil {
+		log.Printf("error getting the notes on membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(notes)
+	if err != nil {
+		log.Printf("error on marshal the notes on membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateMemberNote Creates an admin note on a member of the desired group
+// @Description Creates a private admin note on a member. The notes are never visible to the members. Only group admins are allowed to do it.
+// @ID AdminCreateMemberNote
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param membershipID path string true "membershipID"
+// @Param data body memberNoteRequestBody true "body data"
+// @Success 200 {object} model.MemberNote
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/memberships/{membershipID}/notes [post]
+func (h *AdminApisHandler) CreateMemberNote(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, membershipID := h.getMemberNotesParams(w, r, "membershipID")
+	if len(groupID) == 0 || len(membershipID) == 0 {
+		return
+	}
+
+	requestData, ok := readMemberNoteRequestBody(w, r)
+	if !ok {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	note, err := h.app.Services.CreateMemberNote(clientID, current, group, membershipID, requestData.Text)
+	if err != nil {
+		log.Printf("error creating a note on membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(note)
+	if err != nil {
+		log.Printf("error on marshal member note (%s) - %s", note.ID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdateMemberNote Updates an admin note within the desired group
+// @Description Updates the text of a private admin note on a member. Only group admins are allowed to do it.
+// @ID AdminUpdateMemberNote
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param noteID path string true "noteID"
+// @Param data body memberNoteRequestBody true "body data"
+// @Success 200 {object} model.MemberNote
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/member-notes/{noteID} [put]
+func (h *AdminApisHandler) UpdateMemberNote(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, noteID := h.getMemberNotesParams(w, r, "noteID")
+	if len(groupID) == 0 || len(noteID) == 0 {
+		return
+	}
+
+	requestData, ok := readMemberNoteRequestBody(w, r)
+	if !ok {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	note, err := h.app.Services.UpdateMemberNote(clientID, current, group, noteID, requestData.Text)
+	if err != nil {
+		log.Printf("error updating member note (%s) - %s", noteID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(note)
+	if err != nil {
+		log.Printf("error on marshal member note (%s) - %s", noteID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// DeleteMemberNote Deletes an admin note within the desired group
+// @Description Deletes a private admin note on a member. Only group admins are allowed to do it.
+// @ID AdminDeleteMemberNote
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param noteID path string true "noteID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/member-notes/{noteID} [delete]
+func (h *AdminApisHandler) DeleteMemberNote(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	groupID, noteID := h.getMemberNotesParams(w, r, "noteID")
+	if len(groupID) == 0 || len(noteID) == 0 {
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err := h.app.Services.DeleteMemberNote(clientID, current, group, noteID)
+	if err != nil {
+		log.Printf("error deleting member note (%s) - %s", noteID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetMemberRoster Gets the roster of the desired group
+// @Description Exports the members of the group together with the private admin notes on them. Only group admins are allowed to do it.
+// @ID AdminGetMemberRoster
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Success 200 {array} model.MemberRosterEntry
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/roster [get]
+func (h *AdminApisHandler) GetMemberRoster(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	roster, err := h.app.Services.GetMemberRoster(clientID, current, group)
+	if err != nil {
+		log.Printf("error getting the roster of group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(roster)
+	if err != nil {
+		log.Printf("error on marshal the roster of group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// readMemberNoteRequestBody reads the member note request body. It writes the error response and returns false if it is invalid.
+func readMemberNoteRequestBody(w http.ResponseWriter, r *http.Request) (memberNoteRequestBody, bool) {
+	var requestData memberNoteRequestBody
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read member note - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return requestData, false
+	}
+
+	err = json.Unmarshal(data, &requestData)
+	if err != nil {
+		log.Printf("error on unmarshal member note - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return requestData, false
+	}
+	return requestData, true
+}
+
+// getMemberNotesParams reads the group id and the desired id from the path. It writes the error response and returns empty ids if missing.
+func (h *AdminApisHandler) getMemberNotesParams(w http.ResponseWriter, r *http.Request, idKey string) (string, string) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return "", ""
+	}
+	id := params[idKey]
+	if len(id) <= 0 {
+		log.Printf("%s is required", idKey)
+		http.Error(w, idKey+" is required", http.StatusBadRequest)
+		return "", ""
+	}
+	return groupID, id
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetOutboxNotifications gets the notification outbox items
+// @Description Gets the notification outbox items ordered by creation date descending
+// @ID AdminGetOutboxNotifications
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param status query string false "pending, sent or dead"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.OutboxNotification
+// @Security AppUserAuth
+// @Router /api/admin/notification-outbox [get]
+func (h *AdminApisHandler) GetOutboxNotifications(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	status := getStringQueryParam(r, "status")
+	if status != nil && !model.IsValidOutboxStatus(*status) {
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.app.Services.GetOutboxNotifications(clientID, status, getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting outbox notifications - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		log.Println("Error on marshal outbox notifications")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ReplayOutboxNotification replays a dead-lettered notification
+// @Description Moves a dead-lettered notification back to the outbox for immediate delivery
+// @ID AdminReplayOutboxNotification
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "id"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/notification-outbox/{id}/replay [post]
+func (h *AdminApisHandler) ReplayOutboxNotification(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.ReplayOutboxNotification(clientID, id)
+	if err != nil {
+		log.Printf("error replaying outbox notification - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetNotificationTemplates gets the notification templates
+// @Description Gets the notification templates of the client by optional group and operation
+// @ID AdminGetNotificationTemplates
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param group_id query string false "group_id"
+// @Param operation query string false "operation"
+// @Success 200 {array} model.NotificationTemplate
+// @Security AppUserAuth
+// @Router /api/admin/notification-templates [get]
+func (h *AdminApisHandler) GetNotificationTemplates(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	templates, err := h.app.Services.GetNotificationTemplates(clientID, getStringQueryParam(r, "group_id"), getStringQueryParam(r, "operation"))
+	if err != nil {
+		log.Printf("error getting notification templates - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(templates)
+	if err != nil {
+		log.Println("Error on marshal notification templates")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetDefaultNotificationTemplates gets the built-in notification templates
+// @Description Gets the built-in notification templates mapped by operation. They are used when neither the client nor the group has its own template.
+// @ID AdminGetDefaultNotificationTemplates
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Success 200 {object} map[string]model.NotificationTemplate
+// @Security AppUserAuth
+// @Router /api/admin/notification-templates/defaults [get]
+func (h *AdminApisHandler) GetDefaultNotificationTemplates(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := json.Marshal(h.app.Services.GetDefaultNotificationTemplates())
+	if err != nil {
+		log.Println("Error on marshal default notification templates")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateNotificationTemplate creates a new notification template
+// @Description Creates a new notification template. The template is applied for all groups of the client if group_id is missing and for all locales if locale is empty.
+// @ID AdminCreateNotificationTemplate
+// @Tags Admin
+// @Accept plain
+// @Param data body model.NotificationTemplate true "body data"
+// @Param APP header string true "APP"
+// @Success 200 {object} model.NotificationTemplate
+// @Security AppUserAuth
+// @Router /api/admin/notification-templates [post]
+func (h *AdminApisHandler) CreateNotificationTemplate(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on create notification template - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var template model.NotificationTemplate
+	err = json.Unmarshal(data, &template)
+	if err != nil {
+		log.Printf("Error on unmarshal the notification template data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newTemplate, err := h.app.Services.CreateNotificationTemplate(clientID, template)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := json.Marshal(newTemplate)
+	if err != nil {
+		log.Println("Error on marshal created notification template")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// UpdateNotificationTemplate updates an existing notification template
+// @Description Updates the title and the body of an existing notification template
+// @ID AdminUpdateNotificationTemplate
+// @Tags Admin
+// @Accept plain
+// @Param data body model.NotificationTemplate true "body data"
+// @Param APP header string true "APP"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/notification-templates [put]
+func (h *AdminApisHandler) UpdateNotificationTemplate(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on update notification template - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var template model.NotificationTemplate
+	err = json.Unmarshal(data, &template)
+	if err != nil {
+		log.Printf("Error on unmarshal the notification template data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.Services.UpdateNotificationTemplate(clientID, template)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteNotificationTemplate Deletes a notification template
+// @Description Deletes a notification template. The less specific or the built-in template is used afterwards.
+// @ID AdminDeleteNotificationTemplate
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "ID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/notification-templates/{id} [delete]
+func (h *AdminApisHandler) DeleteNotificationTemplate(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.DeleteNotificationTemplate(clientID, id)
+	if err != nil {
+		log.Printf("error deleting notification template for id (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
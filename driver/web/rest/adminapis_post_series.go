@@ -0,0 +1,205 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateGroupPostSeries Creates a recurring post within the desired group.
+// @Description Creates a recurring post within the desired group. The scheduler publishes each occurrence as a separate post. The recurrence is daily, weekly on given weekdays, monthly or a RRULE subset (FREQ, INTERVAL, BYDAY, COUNT, UNTIL) and it requires an end date or an occurrences count. Only group admins are allowed to do it.
+// @ID AdminCreateGroupPostSeries
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body model.PostSeries true "body data"
+// @Success 200 {object} model.PostSeries
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/series [post]
+func (h *AdminApisHandler) CreateGroupPostSeries(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read post series - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var series model.PostSeries
+	err = json.Unmarshal(data, &series)
+	if err != nil {
+		log.Printf("error on unmarshal post series for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	result, err := h.app.Services.CreatePostSeries(clientID, current, group, series)
+	if err != nil {
+		log.Printf("error creating post series for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		log.Printf("error on marshal post series for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetGroupPostSeries Gets the recurring posts of the desired group.
+// @Description Gets the recurring posts of the desired group. Only group admins are allowed to do it.
+// @ID AdminGetGroupPostSeries
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param statuses query string false "comma separated list of active, paused, canceled or completed"
+// @Success 200 {array} model.PostSeries
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/series [get]
+func (h *AdminApisHandler) GetGroupPostSeries(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	var statuses []string
+	if statusesParam := getStringQueryParam(r, "statuses"); statusesParam != nil {
+		statuses = strings.Split(*statusesParam, ",")
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	list, err := h.app.Services.GetPostSeries(clientID, current, group, statuses)
+	if err != nil {
+		log.Printf("error getting post series for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		log.Printf("error on marshal post series for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// updatePostSeriesStatusRequestBody request body for the update post series status API call
+type updatePostSeriesStatusRequestBody struct {
+	Status string `json:"status" validate:"required,oneof=active paused canceled"`
+} // @name updatePostSeriesStatusRequestBody
+
+// UpdateGroupPostSeriesStatus Pauses, resumes or cancels a recurring post within the desired group.
+// @Description Pauses, resumes or cancels a recurring post within the desired group. The occurrences missed while the series is paused are skipped. Only group admins are allowed to do it.
+// @ID AdminUpdateGroupPostSeriesStatus
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param seriesID path string true "seriesID"
+// @Param data body updatePostSeriesStatusRequestBody true "body data"
+// @Success 200 {object} model.PostSeries
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/series/{seriesID}/status [put]
+func (h *AdminApisHandler) UpdateGroupPostSeriesStatus(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	seriesID := params["seriesID"]
+	if len(seriesID) <= 0 {
+		log.Println("seriesID is required")
+		http.Error(w, "series id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read updatePostSeriesStatusRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body updatePostSeriesStatusRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal updatePostSeriesStatusRequestBody (%s) - %s", seriesID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	series, err := h.app.Services.UpdatePostSeriesStatus(clientID, current, group, seriesID, body.Status)
+	if err != nil {
+		log.Printf("error updating post series (%s) - %s", seriesID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err = json.Marshal(series)
+	if err != nil {
+		log.Printf("error on marshal post series (%s) - %s", seriesID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
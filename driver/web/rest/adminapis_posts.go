@@ -357,3 +357,206 @@ func (h *AdminApisHandler) UpdateGroupPost(clientID string, current *model.User,
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
+
+// GetGroupPendingPosts Gets the posts which wait for review within the desired group.
+// @Description Gets the posts which wait for review within the desired group. Only group admins are allowed to do it.
+// @ID AdminGetGroupPendingPosts
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.Post
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/pending [get]
+func (h *AdminApisHandler) GetGroupPendingPosts(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("%s is not allowed to review posts for %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	posts, err := h.app.Services.GetPendingPosts(clientID, current, group, getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting pending posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(posts)
+	if err != nil {
+		log.Printf("error on marshal pending posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// reviewGroupPostRequestBody request body for the review post API call
+type reviewGroupPostRequestBody struct {
+	Approve      bool   `json:"approve"`
+	RejectReason string `json:"reject_reason"`
+} // @name reviewGroupPostRequestBody
+
+// ReviewGroupPost Approves or rejects a post which waits for review within the desired group.
+// @Description Approves or rejects a post which waits for review within the desired group. The group members are notified for the post only when it's approved. The creator is notified for the decision.
+// @ID AdminReviewGroupPost
+// @Tags Admin
+// @Accept  json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Param data body reviewGroupPostRequestBody true "body data"
+// @Success 200 {object} model.Post
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/{postID}/review [put]
+func (h *AdminApisHandler) ReviewGroupPost(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read reviewGroupPostRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body reviewGroupPostRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal reviewGroupPostRequestBody (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("%s is not allowed to review posts for %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	post, err := h.app.Services.ReviewPost(clientID, current, group, postID, body.Approve, body.RejectReason)
+	if err != nil {
+		log.Printf("error reviewing post (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err = json.Marshal(post)
+	if err != nil {
+		log.Printf("error on marshal post (%s) - %s", postID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetGroupExpiredPosts Gets the expired posts within the desired group.
+// @Description Gets the expired top level posts within the desired group. The expired posts and their replies are hidden for the members and they are purged after a grace period. Only group admins are allowed to do it.
+// @ID AdminGetGroupExpiredPosts
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.Post
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/posts/expired [get]
+func (h *AdminApisHandler) GetGroupExpiredPosts(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("%s is not allowed to see expired posts for %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	posts, err := h.app.Services.GetExpiredPosts(clientID, current, group, getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting expired posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(posts)
+	if err != nil {
+		log.Printf("error on marshal expired posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// UpdateGroupProfileFields Replaces the custom member profile fields of the desired group
+// @Description Replaces the custom member profile fields of the desired group. Every field has a type (text, number, boolean, select or date), a visibility (admins, members or public) and says whether the members can edit it. The values of the removed fields are removed from the memberships. Only group admins are allowed to do it.
+// @ID AdminUpdateGroupProfileFields
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body []model.MemberProfileField true "body data"
+// @Success 200 {array} model.MemberProfileField
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/profile-fields [put]
+func (h *AdminApisHandler) UpdateGroupProfileFields(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read profile fields - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var fields []model.MemberProfileField
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		log.Printf("error on unmarshal profile fields for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	result, err := h.app.Services.UpdateGroupProfileFields(clientID, current, group, fields)
+	if err != nil {
+		log.Printf("error updating profile fields for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		log.Printf("error on marshal profile fields for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// UpdateMemberProfileValues Updates the profile values of a member of the desired group
+// @Description Updates the profile values of a member of the desired group. A null value removes the value. Only group admins are allowed to do it.
+// @ID AdminUpdateMemberProfileValues
+// @Tags Admin
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param membershipID path string true "membershipID"
+// @Param data body map[string]interface{} true "profile values by field key"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/group/{groupID}/memberships/{membershipID}/profile-values [put]
+func (h *AdminApisHandler) UpdateMemberProfileValues(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	membershipID := params["membershipID"]
+	if len(membershipID) <= 0 {
+		log.Println("membershipID is required")
+		http.Error(w, "membership id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read profile values - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		log.Printf("error on unmarshal profile values for membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group := h.getAdminGroup(clientID, current, groupID, w)
+	if group == nil {
+		return
+	}
+
+	err = h.app.Services.UpdateMemberProfileValues(clientID, current, group, membershipID, values)
+	if err != nil {
+		log.Printf("error updating profile values for membership (%s) - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
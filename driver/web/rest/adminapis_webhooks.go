@@ -0,0 +1,241 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetWebhookSubscriptions gets the webhook subscriptions
+// @Description Gets the webhook subscriptions of the client. The secrets are not returned.
+// @ID AdminGetWebhookSubscriptions
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Success 200 {array} model.WebhookSubscription
+// @Security AppUserAuth
+// @Router /api/admin/webhook-subscriptions [get]
+func (h *AdminApisHandler) GetWebhookSubscriptions(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.app.Services.GetWebhookSubscriptions(clientID)
+	if err != nil {
+		log.Printf("error getting webhook subscriptions - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(subscriptions)
+	if err != nil {
+		log.Println("Error on marshal webhook subscriptions")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CreateWebhookSubscription creates a new webhook subscription
+// @Description Creates a new webhook subscription. A secret is generated if it is missing. The secret is returned only in this response.
+// @Description Every delivery is signed with the X-Groups-Signature header - "sha256=" followed by the hex encoded HMAC-SHA256 of "<X-Groups-Timestamp>.<body>".
+// @ID AdminCreateWebhookSubscription
+// @Tags Admin
+// @Accept plain
+// @Param data body model.WebhookSubscription true "body data"
+// @Param APP header string true "APP"
+// @Success 200 {object} model.WebhookSubscription
+// @Security AppUserAuth
+// @Router /api/admin/webhook-subscriptions [post]
+func (h *AdminApisHandler) CreateWebhookSubscription(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on create webhook subscription - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var subscription model.WebhookSubscription
+	err = json.Unmarshal(data, &subscription)
+	if err != nil {
+		log.Printf("Error on unmarshal the webhook subscription data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newSubscription, err := h.app.Services.CreateWebhookSubscription(clientID, current, subscription)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := json.Marshal(newSubscription)
+	if err != nil {
+		log.Println("Error on marshal created webhook subscription")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// UpdateWebhookSubscription updates an existing webhook subscription
+// @Description Updates an existing webhook subscription. The secret is changed only if it is provided.
+// @ID AdminUpdateWebhookSubscription
+// @Tags Admin
+// @Accept plain
+// @Param data body model.WebhookSubscription true "body data"
+// @Param APP header string true "APP"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/webhook-subscriptions [put]
+func (h *AdminApisHandler) UpdateWebhookSubscription(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error reading body on update webhook subscription - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var subscription model.WebhookSubscription
+	err = json.Unmarshal(data, &subscription)
+	if err != nil {
+		log.Printf("Error on unmarshal the webhook subscription data - %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.Services.UpdateWebhookSubscription(clientID, subscription)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteWebhookSubscription Deletes a webhook subscription
+// @Description Deletes a webhook subscription together with its delivery log
+// @ID AdminDeleteWebhookSubscription
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "ID"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/webhook-subscriptions/{id} [delete]
+func (h *AdminApisHandler) DeleteWebhookSubscription(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.DeleteWebhookSubscription(clientID, id)
+	if err != nil {
+		log.Printf("error deleting webhook subscription for id (%s) - %s", id, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetWebhookDeliveries gets the delivery log of a webhook subscription
+// @Description Gets the delivery log of a webhook subscription ordered by creation date descending
+// @ID AdminGetWebhookDeliveries
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "subscription id"
+// @Param status query string false "pending, delivered or dead"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.WebhookDelivery
+// @Security AppUserAuth
+// @Router /api/admin/webhook-subscriptions/{id}/deliveries [get]
+func (h *AdminApisHandler) GetWebhookDeliveries(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	status := getStringQueryParam(r, "status")
+	if status != nil && !model.IsValidWebhookDeliveryStatus(*status) {
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.app.Services.GetWebhookDeliveries(clientID, id, status, getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting webhook deliveries - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		log.Println("Error on marshal webhook deliveries")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ReplayWebhookDelivery replays a webhook delivery
+// @Description Sends a delivered or dead-lettered delivery again with the same payload and delivery id
+// @ID AdminReplayWebhookDelivery
+// @Tags Admin
+// @Param APP header string true "APP"
+// @Param id path string true "delivery id"
+// @Success 200
+// @Security AppUserAuth
+// @Router /api/admin/webhook-deliveries/{id}/replay [post]
+func (h *AdminApisHandler) ReplayWebhookDelivery(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	if len(id) <= 0 {
+		log.Println("id param is required")
+		http.Error(w, "id param is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.Services.ReplayWebhookDelivery(clientID, id)
+	if err != nil {
+		log.Printf("error replaying webhook delivery - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+}
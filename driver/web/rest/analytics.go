@@ -128,12 +128,14 @@ func (h *AnalyticsApisHandler) AnalyticsGetGroups(clientID string, w http.Respon
 }
 
 type analyticsGetPostsResponse struct {
-	ID           string  `json:"id"`
-	ClientID     string  `json:"client_id"`
-	GroupID      string  `json:"group_id"`
-	MemberUserID string  `json:"member_user_id"`
-	DateCreated  string  `json:"date_created"`
-	DateUpdated  *string `json:"date_updated"`
+	ID             string         `json:"id"`
+	ClientID       string         `json:"client_id"`
+	GroupID        string         `json:"group_id"`
+	MemberUserID   string         `json:"member_user_id"`
+	ReactionCounts map[string]int `json:"reaction_counts"`
+	ReactionsCount int            `json:"reactions_count"`
+	DateCreated    string         `json:"date_created"`
+	DateUpdated    *string        `json:"date_updated"`
 }
 
 // AnalyticsGetPosts Gets posts
@@ -193,13 +195,21 @@ func (h *AnalyticsApisHandler) AnalyticsGetPosts(clientID string, w http.Respons
 			val := post.DateUpdated.Format(time.RFC3339)
 			dateUpdated = &val
 		}
+		reactionCounts := map[string]int{}
+		reactionsCount := 0
+		for reaction, count := range post.ReactionCounts {
+			reactionCounts[reaction] = count
+			reactionsCount += count
+		}
 		reponse[i] = analyticsGetPostsResponse{
-			ID:           post.ID,
-			ClientID:     post.ClientID,
-			GroupID:      post.GroupID,
-			MemberUserID: post.Creator.UserID,
-			DateCreated:  post.DateCreated.Format(time.RFC3339),
-			DateUpdated:  dateUpdated,
+			ID:             post.ID,
+			ClientID:       post.ClientID,
+			GroupID:        post.GroupID,
+			MemberUserID:   post.Creator.UserID,
+			ReactionCounts: reactionCounts,
+			ReactionsCount: reactionsCount,
+			DateCreated:    post.DateCreated.Format(time.RFC3339),
+			DateUpdated:    dateUpdated,
 		}
 	}
 
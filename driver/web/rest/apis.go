@@ -483,7 +483,7 @@ func (h *ApisHandler) GetGroups(clientID string, current *model.User, w http.Res
 		groupIDs = append(groupIDs, grouop.ID)
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewUserMemberViewer(current), model.MembershipFilter{
 		GroupIDs: groupIDs,
 	})
 	if err != nil {
@@ -630,7 +630,7 @@ func (h *ApisHandler) GetUserGroups(clientID string, current *model.User, w http
 		groupIDs = append(groupIDs, grouop.ID)
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewUserMemberViewer(current), model.MembershipFilter{
 		GroupIDs: groupIDs,
 	})
 	if err != nil {
@@ -826,7 +826,7 @@ func (h *ApisHandler) GetGroup(clientID string, current *model.User, w http.Resp
 		return
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewUserMemberViewer(current), model.MembershipFilter{
 		GroupIDs: []string{id},
 	})
 	if err != nil {
@@ -1019,7 +1019,7 @@ func (h *ApisHandler) GetGroupMembers(clientID string, current *model.User, w ht
 	request.GroupIDs = append(request.GroupIDs, groupID)
 
 	//check if allowed to update
-	members, err := h.app.Services.FindGroupMemberships(clientID, request)
+	members, err := h.app.Services.FindGroupMemberships(clientID, model.NewUserMemberViewer(current), request)
 	if err != nil {
 		log.Printf("api.GetGroupMembers error: %s", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -1081,7 +1081,7 @@ func (h *ApisHandler) GetGroupMembersV2(clientID string, current *model.User, w
 	request.GroupIDs = append(request.GroupIDs, groupID)
 
 	//check if allowed to update
-	members, err := h.app.Services.FindGroupMemberships(clientID, request)
+	members, err := h.app.Services.FindGroupMemberships(clientID, model.NewUserMemberViewer(current), request)
 	if err != nil {
 		log.Printf("api.GetGroupMembersV2 error: %s", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -1717,10 +1717,11 @@ func (h *ApisHandler) GetGroupEventsV2(clientID string, current *model.User, w h
 		return
 	}
 
-	// Remove  ToMembersList for non-admins
+	// Remove  ToMembersList and ToLabelIDs for non-admins
 	if len(events) > 0 && !group.CurrentMember.IsAdmin() {
 		for i, event := range events {
 			event.ToMembersList = nil
+			event.ToLabelIDs = nil
 			events[i] = event
 		}
 	}
@@ -1739,7 +1740,8 @@ func (h *ApisHandler) GetGroupEventsV2(clientID string, current *model.User, w h
 
 type groupEventRequest struct {
 	EventID       string           `json:"event_id" validate:"required"`
-	ToMembersList []model.ToMember `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToMembersList []model.ToMember `json:"to_members" bson:"to_members"`     // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToLabelIDs    []string         `json:"to_label_ids" bson:"to_label_ids"` // the member labels the event is addressed to in addition to to_members
 } // @name groupEventRequest
 
 // CreateGroupEvent creates a group event
@@ -1809,7 +1811,7 @@ func (h *ApisHandler) CreateGroupEvent(clientID string, current *model.User, w h
 		return
 	}
 
-	_, err = h.app.Services.CreateEvent(clientID, current, requestData.EventID, group, requestData.ToMembersList, &model.Creator{
+	_, err = h.app.Services.CreateEvent(clientID, current, requestData.EventID, group, requestData.ToMembersList, requestData.ToLabelIDs, &model.Creator{
 		UserID: current.ID,
 		Name:   current.Name,
 		Email:  current.Email,
@@ -1892,7 +1894,7 @@ func (h *ApisHandler) UpdateGroupEvent(clientID string, current *model.User, w h
 		return
 	}
 
-	err = h.app.Services.UpdateEvent(clientID, current, requestData.EventID, group.ID, requestData.ToMembersList)
+	err = h.app.Services.UpdateEvent(clientID, current, requestData.EventID, group.ID, requestData.ToMembersList, requestData.ToLabelIDs)
 	if err != nil {
 		log.Printf("Error on updating a group event - %s\n", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1975,6 +1977,7 @@ func (h *ApisHandler) DeleteGroupEvent(clientID string, current *model.User, w h
 // @Param offset query string false "offset"
 // @Param limit query integer false "limit"
 // @Param order query string false "asc|desc"
+// @Param replies_limit query integer false "paging only - load only the latest direct replies of the top level posts"
 // @Success 200 {array} model.Post
 // @Security AppUserAuth
 // @Security APIKeyAuth
@@ -2037,6 +2040,7 @@ func (h *ApisHandler) GetGroupPosts(clientID string, current *model.User, w http
 	if ok && len(orders[0]) > 0 {
 		filter.Order = &orders[0]
 	}
+	filter.RepliesLimit = getInt64QueryParam(r, "replies_limit")
 
 	//check if allowed to delete
 	group, err := h.app.Services.GetGroupEntity(clientID, id)
@@ -2335,11 +2339,12 @@ type reactToGroupPostRequestBody struct {
 } // @name reactToGroupPostRequestBody
 
 // ReactToGroupPost Reacts to a post within the desired group.
-// @Description Reacts to a post within the desired group.
+// @Description Reacts to a post within the desired group. Reacting again with the same reaction removes it. The reaction must be one of the allowed reactions of the group or the client if they are defined.
 // @ID ReactToGroupPost
 // @Tags Client
 // @Accept  json
 // @Param APP header string true "APP"
+// @Param data body reactToGroupPostRequestBody true "body data"
 // @Success 200 {string} Success
 // @Security AppUserAuth
 // @Security APIKeyAuth
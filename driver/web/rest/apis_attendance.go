@@ -0,0 +1,106 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// selfCheckInAttendanceRequestBody request body for the self check-in API call
+type selfCheckInAttendanceRequestBody struct {
+	Code      string  `json:"code" validate:"required"`
+	SessionID *string `json:"session_id"` // optional, it is set when the code is scanned from a QR code
+} // @name selfCheckInAttendanceRequestBody
+
+// SelfCheckInAttendance Checks in the current user to an attendance session
+// @Description Checks in the current user to the attendance session whose check-in window accepts the code. Non-members are added as pending members if the window allows it. Too many invalid codes block the check-in for a while.
+// @ID SelfCheckInAttendance
+// @Tags Client
+// @Accept json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body selfCheckInAttendanceRequestBody true "body data"
+// @Success 200 {object} model.AttendanceRecord
+// @Failure 403 {string} string "the code is invalid or expired"
+// @Failure 429 {string} string "too many invalid codes"
+// @Security AppUserAuth
+// @Router /api/group/{groupID}/attendance/check-in [put]
+func (h *ApisHandler) SelfCheckInAttendance(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read selfCheckInAttendanceRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body selfCheckInAttendanceRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal selfCheckInAttendanceRequestBody (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	record, err := h.app.Services.SelfCheckInAttendance(clientID, current, group, body.SessionID, body.Code)
+	if err != nil {
+		log.Printf("error checking in %s to group (%s) - %s", current.Email, groupID, err.Error())
+		if errors.Is(err, model.ErrTooManyAttendanceCheckInFailures) {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+		} else if errors.Is(err, model.ErrInvalidAttendanceCode) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	data, err = json.Marshal(record)
+	if err != nil {
+		log.Printf("error on marshal attendance record for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -156,8 +156,9 @@ func (h *ApisHandler) CreateCalendarEventMultiGroup(clientID string, current *mo
 }
 
 type createCalendarEventSingleGroupData struct {
-	Event     map[string]interface{} `json:"event"`
-	ToMembers []model.ToMember       `json:"to_members"`
+	Event      map[string]interface{} `json:"event"`
+	ToMembers  []model.ToMember       `json:"to_members"`
+	ToLabelIDs []string               `json:"to_label_ids"`
 }
 
 // CreateCalendarEventSingleGroup Create a calendar event and link it to a single group id
@@ -218,7 +219,7 @@ func (h *ApisHandler) CreateCalendarEventSingleGroup(clientID string, current *m
 		return
 	}
 
-	event, member, err := h.app.Services.CreateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers)
+	event, member, labelIDs, err := h.app.Services.CreateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers, requestData.ToLabelIDs)
 	if err != nil {
 		log.Printf("api.CreateCalendarEventSingleGroup() Error on validating create event data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -226,8 +227,9 @@ func (h *ApisHandler) CreateCalendarEventSingleGroup(clientID string, current *m
 	}
 
 	data, err = json.Marshal(createCalendarEventSingleGroupData{
-		Event:     event,
-		ToMembers: member,
+		Event:      event,
+		ToMembers:  member,
+		ToLabelIDs: labelIDs,
 	})
 	if err != nil {
 		log.Printf("api.CreateCalendarEventSingleGroup() Error on marshaling response data - %s\n", err.Error())
@@ -241,8 +243,9 @@ func (h *ApisHandler) CreateCalendarEventSingleGroup(clientID string, current *m
 }
 
 type updateCalendarEventSingleGroupData struct {
-	Event     map[string]interface{} `json:"event"`
-	ToMembers []model.ToMember       `json:"to_members"`
+	Event      map[string]interface{} `json:"event"`
+	ToMembers  []model.ToMember       `json:"to_members"`
+	ToLabelIDs []string               `json:"to_label_ids"`
 }
 
 // UpdateCalendarEventSingleGroup Updates a calendar event for a single group id
@@ -303,7 +306,7 @@ func (h *ApisHandler) UpdateCalendarEventSingleGroup(clientID string, current *m
 		return
 	}
 
-	event, member, err := h.app.Services.UpdateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers)
+	event, member, labelIDs, err := h.app.Services.UpdateCalendarEventSingleGroup(clientID, current, requestData.Event, groupID, requestData.ToMembers, requestData.ToLabelIDs)
 	if err != nil {
 		log.Printf("api.UpdateCalendarEventSingleGroup() Error on validating create event data - %s\n", err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -311,8 +314,9 @@ func (h *ApisHandler) UpdateCalendarEventSingleGroup(clientID string, current *m
 	}
 
 	data, err = json.Marshal(updateCalendarEventSingleGroupData{
-		Event:     event,
-		ToMembers: member,
+		Event:      event,
+		ToMembers:  member,
+		ToLabelIDs: labelIDs,
 	})
 	if err != nil {
 		log.Printf("api.UpdateCalendarEventSingleGroup() Error on marshaling response data - %s\n", err.Error())
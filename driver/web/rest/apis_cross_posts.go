@@ -0,0 +1,95 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// createCrossPostRequestBody request body for the cross-post API call
+type createCrossPostRequestBody struct {
+	model.Post
+	GroupIDs []string `json:"group_ids"` // the other groups which get a copy of the post
+} // @name createCrossPostRequestBody
+
+// CreateGroupCrossPost Creates a post within the desired group and copies of it within other groups
+// @Description Creates a post within the desired group and copies of it within the groups from group_ids. The post preferences of every group are validated separately. The copies are linked to the origin post with origin_id and follow its edits and deletion. Every user is notified only once.
+// @ID CreateGroupCrossPost
+// @Tags Client
+// @Accept  json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body createCrossPostRequestBody true "body data"
+// @Success 200 {array} model.Post
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/cross-post [post]
+func (h *ApisHandler) CreateGroupCrossPost(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read createCrossPostRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body createCrossPostRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal createCrossPostRequestBody (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	membership, _ := h.app.Services.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		log.Printf("%s is not a member of %s", current.Email, groupID)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	groupIDs := append([]string{groupID}, body.GroupIDs...)
+	posts, err := h.app.Services.CrossPost(clientID, current, &body.Post, groupIDs)
+	if err != nil {
+		log.Printf("error cross-posting to groups %v - %s", groupIDs, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err = json.Marshal(posts)
+	if err != nil {
+		log.Printf("error on marshal cross-posted posts - %s", err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"groups/core/model"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// feedKeepAliveInterval is the interval of the comments which keep the idle feed connections alive
+const feedKeepAliveInterval = 20 * time.Second
+
+// GetGroupFeed Streams the real-time events of the desired group
+// @Description Streams the real-time events of the group as Server-Sent Events: post.created, post.updated, post.deleted, post.reactions and membership.approved.
+// @Description Every event is delivered only to the members who can see it. Reconnect and reload the posts if the stream is closed.
+// @ID GetGroupFeed
+// @Tags Client
+// @Produce text/event-stream
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Success 200 {object} model.FeedEvent
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/feed [get]
+func (h *ApisHandler) GetGroupFeed(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "groupID is required", http.StatusBadRequest)
+		return
+	}
+
+	membership, _ := h.app.Services.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		log.Printf("%s is not allowed to get the feed of group %s", current.Email, groupID)
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	h.streamFeed(clientID, current, &groupID, w, r)
+}
+
+// GetUserFeed Streams the real-time events of all groups of the current user
+// @Description Streams the real-time events of all groups in which the current user is a member as Server-Sent Events. See the group feed for the events.
+// @ID GetUserFeed
+// @Tags Client
+// @Produce text/event-stream
+// @Param APP header string true "APP"
+// @Success 200 {object} model.FeedEvent
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/user/feed [get]
+func (h *ApisHandler) GetUserFeed(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	h.streamFeed(clientID, current, nil, w, r)
+}
+
+func (h *ApisHandler) streamFeed(clientID string, current *model.User, groupID *string, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe, err := h.app.Services.SubscribeToFeed(clientID, current, groupID)
+	if err != nil {
+		log.Printf("error subscribing to the feed - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(feedKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				// the subscription has been dropped
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("error marshalling feed event - %s", err.Error())
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetMembershipStatusHistory Gets the status transitions of a membership
+// @Description Gets the status transitions of a membership in chronological order. Only the group admins can get them.
+// @ID GetMembershipStatusHistory
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param membership-id path string true "Membership ID"
+// @Success 200 {array} model.MembershipTransition
+// @Security AppUserAuth
+// @Router /api/memberships/{membership-id}/history [get]
+func (h *ApisHandler) GetMembershipStatusHistory(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	membershipID := params["membership-id"]
+	if len(membershipID) <= 0 {
+		log.Println("Membership id is required")
+		http.Error(w, "Membership id is required", http.StatusBadRequest)
+		return
+	}
+
+	membership, err := h.app.Services.FindGroupMembershipByID(clientID, membershipID)
+	if err != nil || membership == nil {
+		log.Printf("Membership %s not found - %v\n", membershipID, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	currentMembership, _ := h.app.Services.FindGroupMembership(clientID, membership.GroupID, current.ID)
+	if currentMembership == nil || !currentMembership.IsAdmin() {
+		log.Printf("%s is not allowed to get the history of membership %s", current.Email, membershipID)
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	history, err := h.app.Services.GetMembershipStatusHistory(clientID, membershipID)
+	if err != nil {
+		log.Printf("error getting the history of membership %s - %s", membershipID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		log.Println("Error on marshal the membership history")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// GetGroupPinnedPosts Gets the pinned posts and the active announcements within the desired group.
+// @Description Gets the pinned posts and the active announcements within the desired group. Expired announcements are not returned.
+// @ID GetGroupPinnedPosts
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Success 200 {array} model.Post
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/pinned [get]
+func (h *ApisHandler) GetGroupPinnedPosts(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "groupID is required", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroupEntity(clientID, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	membership, _ := h.app.Services.FindGroupMembership(clientID, group.ID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		log.Printf("%s is not allowed to get pinned posts for group %s", current.Email, group.Title)
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	posts, err := h.app.Services.GetPinnedPosts(clientID, current, groupID)
+	if err != nil {
+		log.Printf("error getting pinned posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(posts)
+	if err != nil {
+		log.Printf("error on marshal pinned posts for group (%s) - %s", groupID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// pinGroupPostRequestBody request body for the pin post API call
+type pinGroupPostRequestBody struct {
+	Pinned                  bool       `json:"pinned"`
+	IsAnnouncement          bool       `json:"is_announcement"`
+	DateAnnouncementExpires *time.Time `json:"date_announcement_expires"`
+} // @name pinGroupPostRequestBody
+
+// PinGroupPost Pins or unpins a top level post within the desired group. Optionally marks it as an announcement.
+// @Description Pins or unpins a top level post within the desired group. Optionally marks it as an announcement. Only group admins are allowed to do it.
+// @ID PinGroupPost
+// @Tags Client
+// @Accept  json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Param data body pinGroupPostRequestBody true "body data"
+// @Success 200 {object} model.Post
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/{postID}/pin [put]
+func (h *ApisHandler) PinGroupPost(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read pinGroupPostRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body pinGroupPostRequestBody
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		log.Printf("error on unmarshal pinGroupPostRequestBody (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdmin() {
+		log.Printf("%s is not allowed to pin posts for %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	post, err := h.app.Services.PinPost(clientID, current, group, postID, body.Pinned, body.IsAnnouncement, body.DateAnnouncementExpires)
+	if err != nil {
+		log.Printf("error pinning post (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err = json.Marshal(post)
+	if err != nil {
+		log.Printf("error on marshal post (%s) - %s", postID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetGroupPostReplies Gets a page of the replies of a post or a reply within the desired group
+// @Description Gets a page of the direct replies of a post or a reply within the desired group. The nested replies are loaded up to the provided depth (1 to 5) with the same limit on every level. Every reply contains its replies_count so the deeper levels can be paged with the same API.
+// @ID GetGroupPostReplies
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit - 20 by default, 100 at most"
+// @Param depth query integer false "depth - 1 by default"
+// @Param order query string false "asc|desc - desc by default"
+// @Success 200 {array} model.Post
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/{postID}/replies [get]
+func (h *ApisHandler) GetGroupPostReplies(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	depth := 1
+	if depthParam := getInt64QueryParam(r, "depth"); depthParam != nil {
+		depth = int(*depthParam)
+	}
+
+	replies, err := h.app.Services.GetPostReplies(clientID, current, groupID, postID, getInt64QueryParam(r, "offset"),
+		getInt64QueryParam(r, "limit"), depth, getStringQueryParam(r, "order"))
+	if err != nil {
+		log.Printf("error getting replies for post (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(replies)
+	if err != nil {
+		log.Printf("error on marshal replies for post (%s) - %s", postID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
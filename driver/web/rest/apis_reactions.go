@@ -0,0 +1,103 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetGroupPostReactions Gets who has reacted to a post within the desired group with what
+// @Description Gets who has reacted to a post within the desired group with what. The reactions are ordered by reaction and then by reaction time.
+// @ID GetGroupPostReactions
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Param reaction query string false "reaction - only the users who have reacted with it"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.PostReaction
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/{postID}/reactions [get]
+func (h *ApisHandler) GetGroupPostReactions(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	reactions, err := h.app.Services.GetPostReactions(clientID, current, groupID, postID, getStringQueryParam(r, "reaction"),
+		getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting reactions for post (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(reactions)
+	if err != nil {
+		log.Printf("error on marshal reactions for post (%s) - %s", postID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetReactionsConfig Gets the reactions allowed for the client
+// @Description Gets the reactions allowed for the client. Empty allowed_reactions means that any reaction is allowed. The groups may narrow them with the allowed_reactions of their post preferences.
+// @ID GetReactionsConfig
+// @Tags Client
+// @Param APP header string true "APP"
+// @Success 200 {object} model.ReactionsConfig
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/reactions-config [get]
+func (h *ApisHandler) GetReactionsConfig(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	config, err := h.app.Services.GetReactionsConfig(clientID)
+	if err != nil {
+		log.Printf("error getting reactions config - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		log.Println("Error on marshal reactions config")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,203 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"groups/core/model"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// markGroupAsReadRequestBody request body for the mark group as read API call
+type markGroupAsReadRequestBody struct {
+	DateLastRead *time.Time `json:"date_last_read"`
+} // @name markGroupAsReadRequestBody
+
+// MarkGroupAsRead Moves forward the last read marker of the current user within the desired group
+// @Description Moves forward the last read marker of the current user within the desired group. The current time is used if date_last_read is missing.
+// @ID MarkGroupAsRead
+// @Tags Client
+// @Accept  json
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param data body markGroupAsReadRequestBody false "body data"
+// @Success 200 {string} Success
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/read [put]
+func (h *ApisHandler) MarkGroupAsRead(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Error on read markGroupAsReadRequestBody - %s\n", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var body markGroupAsReadRequestBody
+	if len(data) > 0 {
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			log.Printf("error on unmarshal markGroupAsReadRequestBody (%s) - %s", groupID, err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	membership, _ := h.app.Services.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		log.Printf("%s is not a member of %s", current.Email, groupID)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	err = h.app.Services.MarkGroupAsRead(clientID, current, groupID, body.DateLastRead)
+	if err != nil {
+		log.Printf("error marking group (%s) as read - %s", groupID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Success"))
+}
+
+// MarkGroupPostSeen Records that the current user has seen a post within the desired group
+// @Description Records that the current user has seen a post within the desired group
+// @ID MarkGroupPostSeen
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Success 200 {string} Success
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/{postID}/seen [put]
+func (h *ApisHandler) MarkGroupPostSeen(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	membership, _ := h.app.Services.FindGroupMembership(clientID, groupID, current.ID)
+	if membership == nil || !membership.IsAdminOrMember() {
+		log.Printf("%s is not a member of %s", current.Email, groupID)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	err := h.app.Services.MarkPostSeen(clientID, current, groupID, postID)
+	if err != nil {
+		log.Printf("error marking post (%s) as seen - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Success"))
+}
+
+// GetGroupPostSeenRecords Gets who has seen a post within the desired group
+// @Description Gets who has seen a post within the desired group. Only the creator of the post and the group admins are allowed to do it.
+// @ID GetGroupPostSeenRecords
+// @Tags Client
+// @Param APP header string true "APP"
+// @Param groupID path string true "groupID"
+// @Param postID path string true "postID"
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit"
+// @Success 200 {array} model.PostSeen
+// @Security AppUserAuth
+// @Security APIKeyAuth
+// @Router /api/group/{groupID}/posts/{postID}/seen [get]
+func (h *ApisHandler) GetGroupPostSeenRecords(clientID string, current *model.User, w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	groupID := params["groupID"]
+	if len(groupID) <= 0 {
+		log.Println("groupID is required")
+		http.Error(w, "group id is required", http.StatusBadRequest)
+		return
+	}
+
+	postID := params["postID"]
+	if len(postID) <= 0 {
+		log.Println("postID is required")
+		http.Error(w, "post id is required", http.StatusBadRequest)
+		return
+	}
+
+	group, err := h.app.Services.GetGroup(clientID, current, groupID)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		log.Printf("there is no a group for the provided group id - %s", groupID)
+		//do not say to much to the user as we do not know if he/she is an admin for the group yet
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if group.CurrentMember == nil || !group.CurrentMember.IsAdminOrMember() {
+		log.Printf("%s is not a member of %s", current.Email, group.Title)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+		return
+	}
+
+	records, err := h.app.Services.GetPostSeenRecords(clientID, current, group, postID, getInt64QueryParam(r, "offset"), getInt64QueryParam(r, "limit"))
+	if err != nil {
+		log.Printf("error getting seen records for post (%s) - %s", postID, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Printf("error on marshal seen records for post (%s) - %s", postID, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -118,7 +118,7 @@ func (h *InternalApisHandler) IntGetGroup(clientID string, w http.ResponseWriter
 		return
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewInternalMemberViewer(), model.MembershipFilter{
 		GroupIDs: []string{group.ID},
 	})
 	if err != nil {
@@ -181,7 +181,7 @@ func (h *InternalApisHandler) IntGetGroupMembersByGroupTitle(clientID string, w
 		return
 	}
 
-	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.MembershipFilter{
+	membershipCollection, err := h.app.Services.FindGroupMemberships(clientID, model.NewInternalMemberViewer(), model.MembershipFilter{
 		GroupIDs: []string{group.ID},
 		Offset:   offset,
 		Limit:    limit,
@@ -297,7 +297,8 @@ func (h *InternalApisHandler) GroupStats(clientID string, w http.ResponseWriter,
 type intCreateGroupEventRequestBody struct {
 	EventID       string           `json:"event_id" bson:"event_id" validate:"required"`
 	Creator       *model.Creator   `json:"creator" bson:"creator"`
-	ToMembersList []model.ToMember `json:"to_members" bson:"to_members"` // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToMembersList []model.ToMember `json:"to_members" bson:"to_members"`     // nil or empty means everyone; non-empty means visible to those user ids and admins
+	ToLabelIDs    []string         `json:"to_label_ids" bson:"to_label_ids"` // the member labels the event is addressed to in addition to to_members
 } // @name intCreateGroupEventRequestBody
 
 // UpdateGroupDateUpdated Updates the date updated field of the desired group
@@ -391,7 +392,7 @@ func (h *InternalApisHandler) CreateGroupEvent(clientID string, w http.ResponseW
 		return
 	}
 
-	grEvent, err := h.app.Services.CreateEvent(clientID, nil, requestData.EventID, group, requestData.ToMembersList, requestData.Creator)
+	grEvent, err := h.app.Services.CreateEvent(clientID, nil, requestData.EventID, group, requestData.ToMembersList, requestData.ToLabelIDs, requestData.Creator)
 	if err != nil {
 		log.Printf("Error on creating an event - %s\n", err)
 		http.Error(w, fmt.Sprintf("Error on creating an event - %s\n", err), http.StatusInternalServerError)
@@ -0,0 +1,134 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"groups/core/model"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// sseChangesWait is the wait in seconds for every batch of the streamed change feed
+const sseChangesWait int64 = 15
+
+// GetChanges reads the change feed of the groups, the memberships and the posts
+// @Description Reads the normalized changes of the groups, the memberships and the posts of the client. The sensitive fields are redacted.
+// @Description The request waits up to "wait" seconds for the first change (long-poll). Continue reading with the returned next_token as "since".
+// @Description Without "since" the feed starts from now. 410 Gone means that the feed cannot be resumed from the token and the consumer must resynchronize.
+// @Description Send "Accept: text/event-stream" to receive the changes as Server-Sent Events instead. The event id is the token to resume from.
+// @ID IntGetChanges
+// @Tags Internal
+// @Param since query string false "the token to continue from"
+// @Param limit query integer false "the max count of the changes - default 100, max 1000"
+// @Param wait query integer false "the max wait in seconds for the first change - default 20, max 60"
+// @Success 200 {object} model.ChangeBatch
+// @Security IntAPIKeyAuth
+// @Router /api/int/changes [get]
+func (h *InternalApisHandler) GetChanges(clientID string, w http.ResponseWriter, r *http.Request) {
+	since := getStringQueryParam(r, "since")
+	if since == nil {
+		// the standard Server-Sent Events reconnect header
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if len(lastEventID) > 0 {
+			since = &lastEventID
+		}
+	}
+	limit := getInt64QueryParam(r, "limit")
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		h.streamChanges(clientID, since, limit, w, r)
+		return
+	}
+
+	batch, err := h.app.Services.GetChanges(clientID, since, limit, getInt64QueryParam(r, "wait"))
+	if errors.Is(err, model.ErrChangeTokenExpired) {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		log.Printf("error getting changes - %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		log.Println("Error on marshal changes")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (h *InternalApisHandler) streamChanges(clientID string, since *string, limit *int64, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	wait := sseChangesWait
+	started := false
+	for r.Context().Err() == nil {
+		batch, err := h.app.Services.GetChanges(clientID, since, limit, &wait)
+		if err != nil {
+			if !started {
+				status := http.StatusInternalServerError
+				if errors.Is(err, model.ErrChangeTokenExpired) {
+					status = http.StatusGone
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+			log.Printf("error streaming changes - %s", err.Error())
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+			flusher.Flush()
+			return
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		for _, change := range batch.Changes {
+			data, err := json.Marshal(change)
+			if err != nil {
+				log.Printf("error marshalling change %s - %s", change.ID, err.Error())
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: change\ndata: %s\n\n", change.ID, data)
+		}
+		if len(batch.Changes) == 0 {
+			// keeps the connection alive
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+
+		if len(batch.NextToken) > 0 {
+			nextToken := batch.NextToken
+			since = &nextToken
+		}
+	}
+}
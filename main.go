@@ -23,10 +23,12 @@ import (
 	"groups/driven/notifications"
 	"groups/driven/rewards"
 	storage "groups/driven/storage"
+	"groups/driven/webhooks"
 	web "groups/driver/web"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/rokwire/core-auth-library-go/v3/authservice"
 	"github.com/rokwire/core-auth-library-go/v3/keys"
@@ -150,6 +152,9 @@ func main() {
 	}
 	rewardsAdapter := rewards.NewRewardsAdapter(rewardsServiceReg.Host, intrernalAPIKey)
 
+	// Webhooks adapter
+	webhooksAdapter := webhooks.NewWebhooksAdapter(10 * time.Second)
+
 	supportedClientIDs := []string{"edu.illinois.rokwire", "edu.illinois.covid"}
 
 	config := &model.ApplicationConfig{
@@ -162,7 +167,7 @@ func main() {
 
 	//application
 	application := core.NewApplication(Version, Build, storageAdapter, notificationsAdapter, authmanAdapter,
-		coreAdapter, rewardsAdapter, calendarAdapter, serviceID, logger, config)
+		coreAdapter, rewardsAdapter, calendarAdapter, webhooksAdapter, serviceID, logger, config)
 	application.Start()
 
 	//web adapter
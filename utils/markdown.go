@@ -0,0 +1,175 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// The renderers escape all user content before they add any markup, so the result contains only the HTML subset which they generate:
+// p, br, h1-h6, strong, em, del, code, pre, blockquote, ul, ol, li, hr and a with http, https or mailto links.
+
+var (
+	markdownHeadingRegex     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownRuleRegex        = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	markdownUnorderedRegex   = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	markdownOrderedRegex     = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	markdownQuoteRegex       = regexp.MustCompile(`^\s{0,3}&gt;\s?(.*)$`)
+	markdownFenceRegex       = regexp.MustCompile("^\\s{0,3}```")
+	markdownLinkRegex        = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+	markdownStrongRegex      = regexp.MustCompile(`\*\*([^*\s](?s:.*?[^*\s])??)\*\*|__([^_\s](?s:.*?[^_\s])??)__`)
+	markdownEmphasisRegex    = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	markdownStrikethroughRex = regexp.MustCompile(`~~([^~]+)~~`)
+	markdownPlaceholderRegex = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// RenderPlainText renders plain text as safe HTML. The paragraphs are separated by empty lines and the line breaks are kept.
+func RenderPlainText(source string) string {
+	source = normalizeMarkupSource(source)
+
+	var builder strings.Builder
+	for _, paragraph := range strings.Split(source, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(line)
+		}
+		builder.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+	return builder.String()
+}
+
+// RenderMarkdown renders a markdown source as safe HTML. It supports headings, paragraphs, block quotes, lists, code blocks,
+// horizontal rules, code spans, strong, emphasis, strikethrough and links. Raw HTML is escaped and shown as text.
+func RenderMarkdown(source string) string {
+	lines := strings.Split(html.EscapeString(normalizeMarkupSource(source)), "\n")
+
+	var builder strings.Builder
+	var paragraph []string
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			builder.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</p>")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushParagraph()
+		case markdownFenceRegex.MatchString(line):
+			flushParagraph()
+			var code []string
+			for i++; i < len(lines) && !markdownFenceRegex.MatchString(lines[i]); i++ {
+				code = append(code, lines[i])
+			}
+			builder.WriteString("<pre><code>" + strings.Join(code, "\n") + "</code></pre>")
+		case markdownHeadingRegex.MatchString(line):
+			flushParagraph()
+			match := markdownHeadingRegex.FindStringSubmatch(line)
+			level := len(match[1])
+			builder.WriteString(fmt.Sprintf("<h%d>%s</h%d>", level, renderMarkdownInline(match[2]), level))
+		case markdownRuleRegex.MatchString(line):
+			flushParagraph()
+			builder.WriteString("<hr>")
+		case markdownQuoteRegex.MatchString(line):
+			flushParagraph()
+			var quote []string
+			for ; i < len(lines) && markdownQuoteRegex.MatchString(lines[i]); i++ {
+				quote = append(quote, markdownQuoteRegex.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			builder.WriteString("<blockquote><p>" + renderMarkdownInline(strings.Join(quote, "\n")) + "</p></blockquote>")
+		case markdownUnorderedRegex.MatchString(line), markdownOrderedRegex.MatchString(line):
+			flushParagraph()
+			itemRegex, tag := markdownUnorderedRegex, "ul"
+			if !markdownUnorderedRegex.MatchString(line) {
+				itemRegex, tag = markdownOrderedRegex, "ol"
+			}
+			builder.WriteString("<" + tag + ">")
+			for ; i < len(lines) && itemRegex.MatchString(lines[i]); i++ {
+				builder.WriteString("<li>" + renderMarkdownInline(itemRegex.FindStringSubmatch(lines[i])[1]) + "</li>")
+			}
+			i--
+			builder.WriteString("</" + tag + ">")
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flushParagraph()
+
+	return builder.String()
+}
+
+// renderMarkdownInline renders the inline markdown of already escaped text
+func renderMarkdownInline(text string) string {
+	// the code spans and the links are replaced with placeholders so that their content is not processed further
+	var placeholders []string
+	addPlaceholder := func(value string) string {
+		placeholders = append(placeholders, value)
+		return fmt.Sprintf("\x00%d\x00", len(placeholders)-1)
+	}
+
+	parts := strings.Split(text, "`")
+	for i := range parts {
+		// the odd parts are between backticks. The last one is not closed.
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = addPlaceholder("<code>" + parts[i] + "</code>")
+		} else if i%2 == 1 {
+			parts[i] = "`" + parts[i]
+		}
+	}
+	text = strings.Join(parts, "")
+
+	text = markdownLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkRegex.FindStringSubmatch(link)
+		if !isSafeMarkdownURL(match[2]) {
+			return match[1]
+		}
+		return addPlaceholder(fmt.Sprintf(`<a href="%s" rel="nofollow noopener noreferrer">`, match[2])) + match[1] + addPlaceholder("</a>")
+	})
+
+	text = markdownStrongRegex.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = markdownEmphasisRegex.ReplaceAllString(text, "<em>$1$2</em>")
+	text = markdownStrikethroughRex.ReplaceAllString(text, "<del>$1</del>")
+	text = strings.ReplaceAll(text, "\n", "<br>")
+
+	return markdownPlaceholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		var index int
+		fmt.Sscanf(markdownPlaceholderRegex.FindStringSubmatch(placeholder)[1], "%d", &index)
+		return placeholders[index]
+	})
+}
+
+// isSafeMarkdownURL checks if the already escaped link target uses an allowed scheme
+func isSafeMarkdownURL(url string) bool {
+	lowerURL := strings.ToLower(url)
+	return strings.HasPrefix(lowerURL, "http://") || strings.HasPrefix(lowerURL, "https://") || strings.HasPrefix(lowerURL, "mailto:")
+}
+
+func normalizeMarkupSource(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	// the NUL character is used for the internal placeholders
+	return strings.ReplaceAll(source, "\x00", "")
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+const testLinkAttributes = ` rel="nofollow noopener noreferrer"`
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		// raw HTML
+		{"script", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"raw html with an event handler", `Hello <b onclick="x()">bold</b>`, "<p>Hello &lt;b onclick=&#34;x()&#34;&gt;bold&lt;/b&gt;</p>"},
+		{"script in a code block", "```\n<script>x</script>\n```", "<pre><code>&lt;script&gt;x&lt;/script&gt;</code></pre>"},
+		{"html in a heading and a quote", "# Title <i>\n\n> quote & <tag>", "<h1>Title &lt;i&gt;</h1><blockquote><p>quote &amp; &lt;tag&gt;</p></blockquote>"},
+
+		// link schemes
+		{"javascript link", "[click](javascript:void)", "<p>click</p>"},
+		{"mixed case javascript link", "[click](JaVaScRiPt:void)", "<p>click</p>"},
+		{"javascript link with a call", "[click](javascript:alert(1))", "<p>[click](javascript:alert(1))</p>"},
+		{"data link", "[click](data:text/html;base64,PHNjcmlwdD4=)", "<p>click</p>"},
+		{"vbscript link", "[click](vbscript:msgbox)", "<p>click</p>"},
+		{"https link", "[site](https://example.com)", `<p><a href="https://example.com"` + testLinkAttributes + `>site</a></p>`},
+		{"mixed case https link", "[site](HTTPS://example.com)", `<p><a href="HTTPS://example.com"` + testLinkAttributes + `>site</a></p>`},
+		{"mailto link", "[mail](mailto:a@example.com)", `<p><a href="mailto:a@example.com"` + testLinkAttributes + `>mail</a></p>`},
+
+		// attribute breakout
+		{"quotes in the link text", `[x" onmouseover="alert(1)](https://example.com)`,
+			`<p><a href="https://example.com"` + testLinkAttributes + `>x&#34; onmouseover=&#34;alert(1)</a></p>`},
+		{"quotes in the href", `[x](https://example.com/"onmouseover="alert)`,
+			`<p><a href="https://example.com/&#34;onmouseover=&#34;alert"` + testLinkAttributes + `>x</a></p>`},
+		{"single quotes in the href", `[x](https://example.com/'onmouseover='alert)`,
+			`<p><a href="https://example.com/&#39;onmouseover=&#39;alert"` + testLinkAttributes + `>x</a></p>`},
+		{"tags in the href", "[x](https://example.com/?a=1&b=<2>)",
+			`<p><a href="https://example.com/?a=1&amp;b=&lt;2&gt;"` + testLinkAttributes + `>x</a></p>`},
+		{"quotes in the text", `it's "quoted"`, "<p>it&#39;s &#34;quoted&#34;</p>"},
+
+		// emphasis and code spans
+		{"strong", "**bold** and __bold__", "<p><strong>bold</strong> and <strong>bold</strong></p>"},
+		{"two strong spans", "**a** and **b**", "<p><strong>a</strong> and <strong>b</strong></p>"},
+		{"emphasis within strong", "**bold *and italic* text**", "<p><strong>bold <em>and italic</em> text</strong></p>"},
+		{"strong within emphasis", "*emphasis with **strong** inside*", "<p><em>emphasis with <strong>strong</strong> inside</em></p>"},
+		{"strong and emphasis", "***x***", "<p><em><strong>x</strong></em></p>"},
+		{"strikethrough", "~~gone~~ and _em_", "<p><del>gone</del> and <em>em</em></p>"},
+		{"spaced asterisks", "2 * 3 * 4 and ** not bold **", "<p>2 * 3 * 4 and ** not bold **</p>"},
+		{"code span", "`<b>code</b> **not bold** [x](https://example.com)`",
+			"<p><code>&lt;b&gt;code&lt;/b&gt; **not bold** [x](https://example.com)</code></p>"},
+		{"unclosed code span", "`unclosed *code*", "<p>`unclosed <em>code</em></p>"},
+
+		// blocks
+		{"paragraphs and line breaks", "line1\nline2\n\npara2", "<p>line1<br>line2</p><p>para2</p>"},
+		{"lists", "- a\n- b\n\n1. one", "<ul><li>a</li><li>b</li></ul><ol><li>one</li></ol>"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p><hr><p>b</p>"},
+		{"placeholder character", "a\x00b\x000\x00", "<p>ab0</p>"},
+		{"empty", " \n\n ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderMarkdown(tt.source)
+			if got != tt.want {
+				t.Errorf("RenderMarkdown() = %q, want %q", got, tt.want)
+			}
+			lowerGot := strings.ToLower(got)
+			for _, unsafe := range []string{"<script", "href=\"javascript:", "href=\"data:", "\" on"} {
+				if strings.Contains(lowerGot, unsafe) {
+					t.Errorf("RenderMarkdown() = %q contains %s", got, unsafe)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPlainText(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"script", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"markdown is not rendered", "**not bold** [x](https://example.com)", "<p>**not bold** [x](https://example.com)</p>"},
+		{"quotes", `"double" 'single'`, "<p>&#34;double&#34; &#39;single&#39;</p>"},
+		{"line breaks and paragraphs", "a\r\nb\n\n\nc", "<p>a<br>b</p><p>c</p>"},
+		{"empty", "  \n\n ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderPlainText(tt.source); got != tt.want {
+				t.Errorf("RenderPlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}